@@ -16,6 +16,7 @@ import (
 	"golang.org/x/tools/go/ssa/ssautil"
 
 	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
 )
 
 type CallGraphType string
@@ -135,33 +136,33 @@ func (a *Analysis) DoAnalysis(
 	dir string,
 	patterns ...string,
 ) error {
-	comm.OutputInfo("开始分析")
-	defer comm.OutputInfo("结束分析")
+	comm.Log.Infof("开始分析")
+	defer comm.Log.Infof("结束分析")
 
 	cfg := &packages.Config{
 		Mode:       packages.LoadAllSyntax,
 		Dir:        dir,
-		BuildFlags: getBuildFlags(buildTags...),
+		BuildFlags: BuildFlags(buildTags...),
 	}
 
-	comm.OutputDebug("加载软件包")
+	comm.Log.Debugf("加载软件包")
 
 	initial, err := packages.Load(cfg, patterns...)
 	if err != nil {
 		return err
 	}
 	if packages.PrintErrors(initial) > 0 {
-		return fmt.Errorf("软件包中存在错误")
+		return i18n.Errorf("软件包中存在错误")
 	}
 
-	comm.OutputDebug("成功加载 %d 个起始软件包，开始构建程序", len(initial))
+	comm.Log.Debugf("成功加载 %d 个起始软件包，开始构建程序", len(initial))
 
 	// Create and build SSA-form program representation.
 	mode := ssa.InstantiateGenerics
 	prog, pkgs := ssautil.AllPackages(initial, mode)
 	prog.Build()
 
-	comm.OutputDebug("构建完成，计算函数调用图（算法：%s）", algo)
+	comm.Log.Debugf("构建完成，计算函数调用图（算法：%s）", algo)
 
 	var graph *callgraph.Graph
 	var mainPkg *ssa.Package
@@ -179,10 +180,10 @@ func (a *Analysis) DoAnalysis(
 		}
 		graph = rta.Analyze(roots, true).CallGraph
 	default:
-		return fmt.Errorf("无效的分析调用图算法类型：%s", algo)
+		return i18n.Errorf("无效的分析调用图算法类型：%s", algo)
 	}
 
-	comm.OutputDebug("调用图中存在 %d 个节点", len(graph.Nodes))
+	comm.Log.Debugf("调用图中存在 %d 个节点", len(graph.Nodes))
 
 	a.prog = prog
 	a.pkgs = gatherAllPkgs(graph)
@@ -272,7 +273,8 @@ func (a *Analysis) PathSearch(start *callgraph.Node, skipSyntheticEdges, showRef
 	search(start, nil)
 }
 
-func getBuildFlags(customBuildTags ...string) []string {
+// BuildFlags 加载项目软件包时使用的构建参数, 始终包含 gbc_generate_exclude 标记
+func BuildFlags(customBuildTags ...string) []string {
 	customBuildTags = append(customBuildTags, "gbc_generate_exclude")
 	buildFlagTags := getBuildFlagTags(append(build.Default.BuildTags, customBuildTags...))
 	return buildFlagTags
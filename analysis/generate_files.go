@@ -12,6 +12,7 @@ import (
 	"text/template"
 
 	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
 )
 
 const fileTemplate = `// Code generated by {{ quote .Generator }}. DO NOT EDIT.
@@ -24,7 +25,7 @@ import (
 	"github.com/zjutjh/mygo/kit"
 	"github.com/zjutjh/mygo/swagger"
 
-	"app/comm"
+	"{{ .ModulePath }}/comm"
 )
 
 func init() {
@@ -71,6 +72,7 @@ type handlerInfo struct {
 
 type fileInfo struct {
 	Generator   string
+	ModulePath  string
 	PackageName string
 	Handlers    []handlerInfo
 }
@@ -82,8 +84,11 @@ func removePathPrefix(path, prefix string) string {
 	return path
 }
 
+// GeneratedFileName gbc codegen 生成的业务状态码注册文件名
+const GeneratedFileName = "status_codes_generated.go"
+
 func GenerateInitialFiles(moduleName string, infos map[string][]*GinHandlerInfo, storeDir string) error {
-	comm.OutputInfo("开始生成 status_codes_generated.go 文件")
+	comm.Log.Infof("开始生成 status_codes_generated.go 文件")
 	var packageName string
 	if storeDir == "" {
 		packageName = "main"
@@ -92,6 +97,7 @@ func GenerateInitialFiles(moduleName string, infos map[string][]*GinHandlerInfo,
 	}
 	fileInfo := fileInfo{
 		Generator:   "gbc codegen",
+		ModulePath:  moduleName,
 		PackageName: packageName,
 		Handlers:    make([]handlerInfo, 0),
 	}
@@ -126,27 +132,27 @@ func GenerateInitialFiles(moduleName string, infos map[string][]*GinHandlerInfo,
 	slices.SortFunc(fileInfo.Handlers, func(a, b handlerInfo) int {
 		return strings.Compare(a.FullName, b.FullName)
 	})
-	filePath := filepath.Join(storeDir, "status_codes_generated.go")
+	filePath := filepath.Join(storeDir, GeneratedFileName)
 	buffer := bytes.Buffer{}
 	err := tmpl.Execute(&buffer, fileInfo)
 	if err != nil {
-		return fmt.Errorf("生成文件失败: %w", err)
+		return i18n.Errorf("生成文件失败: %w", err)
 	}
 	// format source file
 	raw, err := format.Source(buffer.Bytes())
 	if err != nil {
-		return fmt.Errorf("格式化代码失败: %w", err)
+		return i18n.Errorf("格式化代码失败: %w", err)
 	}
-	comm.OutputInfo("文件路径：%s", filePath)
+	comm.Log.Infof("文件路径：%s", filePath)
 	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
-		return fmt.Errorf("打开文件失败: %w", err)
+		return i18n.Errorf("打开文件失败: %w", err)
 	}
 	defer file.Close()
 	_, err = file.Write(raw)
 	if err != nil {
-		return fmt.Errorf("写入文件失败: %w", err)
+		return i18n.Errorf("写入文件失败: %w", err)
 	}
-	comm.OutputInfo("结束生成文件")
+	comm.Log.Infof("结束生成文件")
 	return nil
 }
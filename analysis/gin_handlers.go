@@ -2,6 +2,8 @@ package analysis
 
 import (
 	"cmp"
+	"fmt"
+	"go/constant"
 	"go/types"
 	"path"
 	"path/filepath"
@@ -41,7 +43,7 @@ func findCtxNextNode(inst *Analysis) *callgraph.Node {
 }
 
 func GetGinHandlers(inst *Analysis) []*callgraph.Node {
-	comm.OutputInfo("查找所有 gin HTTP 处理器")
+	comm.Log.Infof("查找所有 gin HTTP 处理器")
 
 	// 找到 (*gin.Context).Next() 方法的节点
 	node := findCtxNextNode(inst)
@@ -49,12 +51,12 @@ func GetGinHandlers(inst *Analysis) []*callgraph.Node {
 	ans := make([]*callgraph.Node, 0, len(nodes))
 	for node := range nodes {
 		if comm.DebugMode {
-			comm.OutputDebug("node：%v", node)
+			comm.Log.Debugf("node：%v", node)
 		}
 		ans = append(ans, node)
 	}
 
-	comm.OutputInfo("找到 %d 个 gin HTTP 处理器", len(ans))
+	comm.Log.Infof("找到 %d 个 gin HTTP 处理器", len(ans))
 	return ans
 }
 
@@ -68,6 +70,7 @@ type GinHandlerInfo struct {
 type KitCode struct {
 	Code    int64  // 业务码
 	VarName string // 变量名
+	Message string // 提示信息, 第二个参数不是常量时为空
 }
 
 func GetPackageName(n *callgraph.Node) string {
@@ -114,6 +117,13 @@ func CollectGlobalCodeVars(inst *Analysis, targetPkgPath, targetFuncName string)
 					}
 					if c, ok := callIns.Call.Args[0].(*ssa.Const); ok {
 						code := c.Int64()
+						// 第二个参数（提示信息）为常量时一并记录
+						msg := ""
+						if len(callIns.Call.Args) > 1 {
+							if m, ok := callIns.Call.Args[1].(*ssa.Const); ok && m.Value != nil && m.Value.Kind() == constant.String {
+								msg = constant.StringVal(m.Value)
+							}
+						}
 						// 查看这个 call 的引用处，如果有 Store 到 *ssa.Global，则记录该 global 对应的 code 并记录变量名
 						if refs := callIns.Referrers(); refs != nil {
 							for _, r := range *refs {
@@ -122,6 +132,7 @@ func CollectGlobalCodeVars(inst *Analysis, targetPkgPath, targetFuncName string)
 										res[g] = KitCode{
 											Code:    code,
 											VarName: g.Name(),
+											Message: msg,
 										}
 									}
 								}
@@ -135,6 +146,26 @@ func CollectGlobalCodeVars(inst *Analysis, targetPkgPath, targetFuncName string)
 	return res
 }
 
+// CodeEntries 将 CollectGlobalCodeVars 的结果转换为业务状态码列表, 包含依赖中声明的业务状态码, 按状态码排序
+func CodeEntries(inst *Analysis, globalCodeMap map[*ssa.Global]KitCode) []CodeEntry {
+	res := make([]CodeEntry, 0, len(globalCodeMap))
+	for g, c := range globalCodeMap {
+		pkgPath := g.Pkg.Pkg.Path()
+		pos := inst.prog.Fset.Position(g.Pos())
+		res = append(res, CodeEntry{
+			Package:  pkgPath,
+			Var:      c.VarName,
+			Code:     c.Code,
+			Message:  c.Message,
+			Position: fmt.Sprintf("%s:%d", path.Join(pkgPath, filepath.Base(pos.Filename)), pos.Line),
+		})
+	}
+	slices.SortFunc(res, func(a, b CodeEntry) int {
+		return cmp.Or(cmp.Compare(a.Code, b.Code), strings.Compare(a.Package, b.Package), strings.Compare(a.Var, b.Var))
+	})
+	return res
+}
+
 func ParseGinHandler(inst *Analysis, skipSyntheticEdges, showReferences bool, handlerNode *callgraph.Node, allHandlers map[*callgraph.Node]struct{}, globalCodeMap map[*ssa.Global]KitCode) (*GinHandlerInfo, error) {
 	// 以 handlerNode 为根节点，探索其调用图
 	slicePool := &sync.Pool{
@@ -177,7 +208,7 @@ func ParseGinHandler(inst *Analysis, skipSyntheticEdges, showReferences bool, ha
 	slices.Sort(vars)
 	pkgName := GetPackageName(handlerNode)
 	if comm.DebugMode {
-		comm.OutputDebug("处理器 %s.%s 引用的状态码：%v", pkgName, handlerNode.Func.Name(), vars)
+		comm.Log.With("package", pkgName).Debugf("处理器 %s.%s 引用的状态码：%v", pkgName, handlerNode.Func.Name(), vars)
 	}
 	// 由 globalCodeMap 构建 code -> 变量名列表
 	varNamesMap := make(map[int64][]string)
@@ -230,14 +261,14 @@ func outputNodeChain(nodeChain []*callgraph.Node, diff []int64) {
 	if len(nodeChain) == 0 {
 		return
 	}
-	comm.OutputDebug("status code %v is gathered from Node Chain:", diff)
+	comm.Log.Debugf("status code %v is gathered from Node Chain:", diff)
 	formatString := make([]string, 0, len(nodeChain))
 	values := make([]any, 0, len(nodeChain)*2)
 	for _, n := range nodeChain {
 		formatString = append(formatString, "%s.%s")
 		values = append(values, GetPackageName(n), n.Func.Name())
 	}
-	comm.OutputDebug("\t"+strings.Join(formatString, " -> "), values...)
+	comm.Log.Debugf("\t"+strings.Join(formatString, " -> "), values...)
 }
 
 func findAllReferences(slicePool *sync.Pool, fn *ssa.Function, vars map[int64]struct{}, globalCodeMap map[*ssa.Global]KitCode) {
@@ -0,0 +1,372 @@
+package analysis
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+const (
+	KitPkgPath   = "github.com/zjutjh/mygo/kit"
+	CobraPkgPath = "github.com/spf13/cobra"
+	GinPkgPath   = "github.com/gin-gonic/gin"
+)
+
+// RequestParts API请求参数可能包含的部分, 顺序与模板保持一致
+var RequestParts = []string{"Uri", "Header", "Query", "Body"}
+
+type APIEntry struct {
+	Package  string   `json:"package"`  // 包路径
+	Type     string   `json:"type"`     // 类型名称, 如 LoginApi
+	Name     string   `json:"name"`     // Info name 标签
+	Desc     string   `json:"desc"`     // Info desc 标签
+	Request  []string `json:"request"`  // 请求参数部分 (Uri/Header/Query/Body)
+	Handler  string   `json:"handler"`  // router注册点函数名, 如 LoginHandler
+	Position string   `json:"position"` // 声明位置
+
+	fields map[string]types.Type
+}
+
+// FieldType API结构体中名为name的字段的类型, 如 Request、Response, 不存在时返回nil
+func (a *APIEntry) FieldType(name string) types.Type {
+	return a.fields[name]
+}
+
+// HandlerFunc API执行入口函数的完整名称, 如 app/api/user.hfLogin, 与 gbc codegen 注册业务状态码时使用的名称一致
+func (a *APIEntry) HandlerFunc() string {
+	return a.Package + ".hf" + strings.TrimSuffix(a.Type, "Api")
+}
+
+type CronEntry struct {
+	Package    string `json:"package"`
+	Type       string `json:"type"`
+	Registered bool   `json:"registered"` // 是否在声明包之外作为值使用 (即在register中注册)
+	Position   string `json:"position"`
+}
+
+type CmdEntry struct {
+	Package  string `json:"package"`
+	Func     string `json:"func"`
+	Position string `json:"position"`
+}
+
+type CodeEntry struct {
+	Package  string `json:"package"`
+	Var      string `json:"var"`
+	Code     int64  `json:"code"`
+	Message  string `json:"message"`
+	Position string `json:"position"`
+}
+
+// Inventory 项目中由gbc脚手架生成的各类制品清单
+type Inventory struct {
+	APIs  []APIEntry  `json:"apis"`
+	Crons []CronEntry `json:"crons"`
+	Cmds  []CmdEntry  `json:"cmds"`
+	Codes []CodeEntry `json:"codes"`
+
+	dir  string
+	pkgs []*packages.Package
+}
+
+// LoadInventory 使用 go/packages 加载dir下的全部软件包并收集制品清单
+func LoadInventory(dir string, buildTags []string) (*Inventory, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedModule,
+		Dir:        dir,
+		BuildFlags: BuildFlags(buildTags...),
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, i18n.Errorf("软件包中存在错误")
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	inv := &Inventory{dir: absDir, pkgs: pkgs}
+	inv.collect()
+	return inv, nil
+}
+
+func (inv *Inventory) position(fset *token.FileSet, pos token.Pos) string {
+	return inv.relPosition(fset.Position(pos))
+}
+
+// relPosition 相对于项目目录的 file:line 位置
+func (inv *Inventory) relPosition(p token.Position) string {
+	if rel, err := filepath.Rel(inv.dir, p.Filename); err == nil {
+		p.Filename = rel
+	}
+	return fmt.Sprintf("%s:%d", filepath.ToSlash(p.Filename), p.Line)
+}
+
+func (inv *Inventory) collect() {
+	instantiated := make(map[*types.TypeName]map[*types.Package]struct{})
+	for _, pkg := range inv.pkgs {
+		// 类型为 T 或 *T 的值表达式, 包括复合字面量、new(T) 与构造函数的返回值
+		for _, tv := range pkg.TypesInfo.Types {
+			if !tv.IsValue() {
+				continue
+			}
+			t := tv.Type
+			if ptr, ok := t.(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			named, ok := types.Unalias(t).(*types.Named)
+			if !ok {
+				continue
+			}
+			obj := named.Obj()
+			if instantiated[obj] == nil {
+				instantiated[obj] = make(map[*types.Package]struct{})
+			}
+			instantiated[obj][pkg.Types] = struct{}{}
+		}
+		for _, f := range pkg.Syntax {
+			inv.collectCodes(pkg, f)
+		}
+	}
+	for _, pkg := range inv.pkgs {
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			switch obj := scope.Lookup(name).(type) {
+			case *types.TypeName:
+				if api, ok := inv.apiEntry(pkg, obj); ok {
+					inv.APIs = append(inv.APIs, api)
+					continue
+				}
+				if IsCronJob(obj) {
+					registered := false
+					for p := range instantiated[obj] {
+						if p != pkg.Types {
+							registered = true
+						}
+					}
+					inv.Crons = append(inv.Crons, CronEntry{
+						Package:    pkg.PkgPath,
+						Type:       obj.Name(),
+						Registered: registered,
+						Position:   inv.position(pkg.Fset, obj.Pos()),
+					})
+				}
+			case *types.Func:
+				if isCmdRun(obj) {
+					inv.Cmds = append(inv.Cmds, CmdEntry{
+						Package:  pkg.PkgPath,
+						Func:     obj.Name(),
+						Position: inv.position(pkg.Fset, obj.Pos()),
+					})
+				}
+			}
+		}
+	}
+	slices.SortFunc(inv.Codes, func(a, b CodeEntry) int {
+		return cmp.Or(cmp.Compare(a.Code, b.Code), strings.Compare(a.Var, b.Var))
+	})
+}
+
+func (inv *Inventory) apiEntry(pkg *packages.Package, obj *types.TypeName) (APIEntry, bool) {
+	api, ok := NewAPIEntry(obj)
+	if ok {
+		api.Position = inv.position(pkg.Fset, obj.Pos())
+	}
+	return api, ok
+}
+
+// NewAPIEntry 根据类型构造API条目: 名称以Api结尾且具有Info字段的结构体, 否则返回false; 不包含声明位置
+func NewAPIEntry(obj *types.TypeName) (APIEntry, bool) {
+	if !strings.HasSuffix(obj.Name(), "Api") || obj.IsAlias() || obj.Pkg() == nil {
+		return APIEntry{}, false
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return APIEntry{}, false
+	}
+	api := APIEntry{
+		Package: obj.Pkg().Path(),
+		Type:    obj.Name(),
+		Request: []string{},
+	}
+	hasInfo := false
+	api.fields = make(map[string]types.Type, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		api.fields[field.Name()] = field.Type()
+		switch field.Name() {
+		case "Info":
+			hasInfo = true
+			tag := reflect.StructTag(st.Tag(i))
+			api.Name = tag.Get("name")
+			api.Desc = tag.Get("desc")
+		case "Request":
+			api.Request = requestParts(field.Type())
+		}
+	}
+	if !hasInfo {
+		return APIEntry{}, false
+	}
+	handler := strings.TrimSuffix(obj.Name(), "Api") + "Handler"
+	if fn, ok := obj.Pkg().Scope().Lookup(handler).(*types.Func); ok {
+		api.Handler = fn.Name()
+	}
+	return api, true
+}
+
+func requestParts(t types.Type) []string {
+	parts := []string{}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return parts
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		if slices.Contains(RequestParts, st.Field(i).Name()) {
+			parts = append(parts, st.Field(i).Name())
+		}
+	}
+	return parts
+}
+
+// IsCronJob 是否为gbc模板生成的定时任务类型: 名称以Job结尾的结构体, 且存在 Run() 方法 (无参数无返回值)
+func IsCronJob(obj *types.TypeName) bool {
+	if obj.IsAlias() || !strings.HasSuffix(obj.Name(), "Job") {
+		return false
+	}
+	if _, ok := obj.Type().Underlying().(*types.Struct); !ok {
+		return false
+	}
+	ms := types.NewMethodSet(types.NewPointer(obj.Type()))
+	sel := ms.Lookup(obj.Pkg(), "Run")
+	if sel == nil {
+		return false
+	}
+	sig := sel.Type().(*types.Signature)
+	return sig.Params().Len() == 0 && sig.Results().Len() == 0
+}
+
+// isCmdRun 是否为 func(*cobra.Command, []string) error 形式的命令函数
+func isCmdRun(fn *types.Func) bool {
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() != nil || sig.Params().Len() != 2 || sig.Results().Len() != 1 {
+		return false
+	}
+	if !IsNamedPointer(sig.Params().At(0).Type(), CobraPkgPath, "Command") {
+		return false
+	}
+	slice, ok := sig.Params().At(1).Type().(*types.Slice)
+	if !ok || !types.Identical(slice.Elem(), types.Typ[types.String]) {
+		return false
+	}
+	return types.Identical(sig.Results().At(0).Type(), types.Universe.Lookup("error").Type())
+}
+
+// IsNamedPointer t是否为指向 pkgPath.name 的指针
+func IsNamedPointer(t types.Type, pkgPath, name string) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	return IsNamed(ptr.Elem(), pkgPath, name)
+}
+
+// IsNamed t是否为命名类型 pkgPath.name
+func IsNamed(t types.Type, pkgPath, name string) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == pkgPath && named.Obj().Name() == name
+}
+
+// HasMethod t或*t是否有名为name的方法, 如 MarshalJSON
+func HasMethod(t types.Type, name string) bool {
+	for _, t := range []types.Type{t, types.NewPointer(t)} {
+		ms := types.NewMethodSet(t)
+		for i := 0; i < ms.Len(); i++ {
+			if ms.At(i).Obj().Name() == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// collectCodes 收集包级 var X = kit.NewCode(code, "msg") 声明
+func (inv *Inventory) collectCodes(pkg *packages.Package, f *ast.File) {
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, value := range vs.Values {
+				if i >= len(vs.Names) {
+					break
+				}
+				code, msg, ok := ParseNewCodeCall(pkg.TypesInfo, value)
+				if !ok {
+					continue
+				}
+				inv.Codes = append(inv.Codes, CodeEntry{
+					Package:  pkg.PkgPath,
+					Var:      vs.Names[i].Name,
+					Code:     code,
+					Message:  msg,
+					Position: inv.position(pkg.Fset, vs.Names[i].Pos()),
+				})
+			}
+		}
+	}
+}
+
+// ParseNewCodeCall 解析 kit.NewCode(code, "msg") 调用, 参数须为常量
+func ParseNewCodeCall(info *types.Info, expr ast.Expr) (int64, string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return 0, "", false
+	}
+	var ident *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	case *ast.Ident:
+		ident = fun
+	default:
+		return 0, "", false
+	}
+	fn, ok := info.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != KitPkgPath || fn.Name() != "NewCode" {
+		return 0, "", false
+	}
+	tv, ok := info.Types[call.Args[0]]
+	if !ok || tv.Value == nil {
+		return 0, "", false
+	}
+	code, ok := constant.Int64Val(constant.ToInt(tv.Value))
+	if !ok {
+		return 0, "", false
+	}
+	msg := ""
+	if len(call.Args) > 1 {
+		if tv, ok := info.Types[call.Args[1]]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			msg = constant.StringVal(tv.Value)
+		}
+	}
+	return code, msg, true
+}
@@ -0,0 +1,90 @@
+package analysis
+
+import (
+	"bufio"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/gcexportdata"
+)
+
+// loadFixture 加载 testdata/project, 依赖替换为 testdata/mod 下的替身模块
+func loadFixture(t *testing.T) *Inventory {
+	t.Helper()
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOPROXY", "off")
+	// go/packages 读取依赖的导出数据, 无法读取时会直接退出进程
+	out, err := exec.Command("go", "list", "-export", "-f", "{{.Export}}", "errors").Output()
+	if err != nil {
+		t.Skipf("go not available: %v", err)
+	}
+	f, err := os.Open(strings.TrimSpace(string(out)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gcexportdata.NewReader(bufio.NewReader(f))
+	if err == nil {
+		_, err = gcexportdata.Read(r, token.NewFileSet(), make(map[string]*types.Package), "errors")
+	}
+	if err != nil {
+		t.Skipf("golang.org/x/tools cannot read export data of the local go toolchain: %v", err)
+	}
+
+	inv, err := LoadInventory("testdata/project", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return inv
+}
+
+func TestLoadInventory(t *testing.T) {
+	inv := loadFixture(t)
+
+	apis := make([]string, 0, len(inv.APIs))
+	for _, api := range inv.APIs {
+		apis = append(apis, api.Package+"."+api.Type+" "+api.Name+"/"+api.Desc+" "+strings.Join(api.Request, ",")+" "+api.Handler+" "+api.Position)
+	}
+	wantAPIs := []string{
+		"app/api/user.InfoApi 用户信息/ Query InfoHandler api/user/info.go:14",
+		"app/api/user.LoginApi 登录/用户登录 Uri,Body LoginHandler api/user/login.go:14",
+	}
+	if !reflect.DeepEqual(apis, wantAPIs) {
+		t.Errorf("APIs =\n%s\nwant\n%s", strings.Join(apis, "\n"), strings.Join(wantAPIs, "\n"))
+	}
+	if api := inv.APIs[1]; api.HandlerFunc() != "app/api/user.hfLogin" || api.FieldType("Response") == nil || api.FieldType("Missing") != nil {
+		t.Errorf("LoginApi handler func = %s, Response field type = %v", api.HandlerFunc(), api.FieldType("Response"))
+	}
+
+	// 仅名称以Job结尾且具有 Run() 方法的结构体是定时任务, 复合字面量、构造函数与new均视为注册
+	wantCrons := []CronEntry{
+		{Package: "app/cron", Type: "CleanJob", Registered: true, Position: "cron/jobs.go:3"},
+		{Package: "app/cron", Type: "IdleJob", Registered: false, Position: "cron/jobs.go:22"},
+		{Package: "app/cron", Type: "SyncJob", Registered: true, Position: "cron/jobs.go:7"},
+		{Package: "app/cron", Type: "TickJob", Registered: true, Position: "cron/jobs.go:17"},
+	}
+	if !reflect.DeepEqual(inv.Crons, wantCrons) {
+		t.Errorf("Crons = %+v, want %+v", inv.Crons, wantCrons)
+	}
+
+	wantCmds := []CmdEntry{{Package: "app/cmd", Func: "HelloRun", Position: "cmd/hello.go:5"}}
+	if !reflect.DeepEqual(inv.Cmds, wantCmds) {
+		t.Errorf("Cmds = %+v, want %+v", inv.Cmds, wantCmds)
+	}
+
+	wantCodes := []CodeEntry{
+		{Package: "app/comm", Var: "CodeOK", Code: 0, Message: "ok", Position: "comm/code.go:8"},
+		{Package: "app/comm", Var: "CodeParameterInvalid", Code: 400, Message: "参数错误", Position: "comm/code.go:9"},
+		{Package: "app/comm", Var: "CodeUserNotFound", Code: 10001, Message: "用户不存在", Position: "comm/code.go:10"},
+		{Package: "app/comm", Var: "CodePasswordWrong", Code: 10002, Message: "密码错误", Position: "comm/code.go:11"},
+	}
+	if !reflect.DeepEqual(inv.Codes, wantCodes) {
+		t.Errorf("Codes = %+v, want %+v", inv.Codes, wantCodes)
+	}
+}
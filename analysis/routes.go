@@ -0,0 +1,311 @@
+package analysis
+
+import (
+	"cmp"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const SwaggerPkgPath = "github.com/zjutjh/mygo/swagger"
+
+// routeMethods gin路由注册方法与对应的HTTP方法, Any 表示任意方法
+var routeMethods = map[string]string{
+	"GET":     "GET",
+	"POST":    "POST",
+	"PUT":     "PUT",
+	"PATCH":   "PATCH",
+	"DELETE":  "DELETE",
+	"HEAD":    "HEAD",
+	"OPTIONS": "OPTIONS",
+	"Any":     "Any",
+}
+
+// Route 项目中注册的一条gin路由
+type Route struct {
+	Method   string      `json:"method"`        // GET、POST 等, Any 表示任意方法
+	Path     string      `json:"path"`          // gin格式的完整路径, 如 /user/info/:id
+	API      *APIEntry   `json:"api,omitempty"` // 处理器对应的API, 不是由API的Handler注册时为空
+	Codes    []CodeEntry `json:"codes"`         // 处理器可能返回的业务状态码, 来自 gbc codegen 生成的注册文件
+	Position string      `json:"position"`
+}
+
+// Routes 收集 gin.Engine/RouterGroup 上以常量路径注册的路由
+// 通过 r.Group("/x") 赋值的变量会带上分组前缀; 分组作为函数参数传递时无法得知前缀, 按空前缀处理
+func (inv *Inventory) Routes() []Route {
+	handlerCodes := inv.registeredCodes()
+	routes := make([]Route, 0)
+	for _, pkg := range inv.pkgs {
+		prefixes := make(map[types.Object]string)
+		for _, f := range pkg.Syntax {
+			ast.Inspect(f, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.AssignStmt:
+					if len(n.Lhs) == len(n.Rhs) {
+						for i, lhs := range n.Lhs {
+							inv.recordGroup(pkg, prefixes, lhs, n.Rhs[i])
+						}
+					}
+				case *ast.ValueSpec:
+					if len(n.Names) == len(n.Values) {
+						for i, name := range n.Names {
+							inv.recordGroup(pkg, prefixes, name, n.Values[i])
+						}
+					}
+				case *ast.CallExpr:
+					if r, ok := inv.route(pkg, prefixes, n); ok {
+						if r.API != nil {
+							r.Codes = handlerCodes[r.API.HandlerFunc()]
+						}
+						routes = append(routes, r)
+					}
+				}
+				return true
+			})
+		}
+	}
+	slices.SortFunc(routes, func(a, b Route) int {
+		return cmp.Or(strings.Compare(a.Path, b.Path), strings.Compare(a.Method, b.Method))
+	})
+	return routes
+}
+
+// isRouter t是否为gin的路由注册器
+func isRouter(t types.Type) bool {
+	return IsNamedPointer(t, GinPkgPath, "Engine") || IsNamedPointer(t, GinPkgPath, "RouterGroup") ||
+		IsNamed(t, GinPkgPath, "IRouter") || IsNamed(t, GinPkgPath, "IRoutes")
+}
+
+// recordGroup 记录 g := r.Group("/x") 形式的分组前缀
+func (inv *Inventory) recordGroup(pkg *packages.Package, prefixes map[types.Object]string, lhs ast.Expr, rhs ast.Expr) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok {
+		return
+	}
+	prefix, ok := groupPrefix(pkg.TypesInfo, prefixes, rhs)
+	if !ok {
+		return
+	}
+	obj := pkg.TypesInfo.Defs[ident]
+	if obj == nil {
+		obj = pkg.TypesInfo.Uses[ident]
+	}
+	if obj != nil {
+		prefixes[obj] = prefix
+	}
+}
+
+// groupPrefix 计算路由注册器表达式的路径前缀
+func groupPrefix(info *types.Info, prefixes map[types.Object]string, expr ast.Expr) (string, bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		if !isRouter(info.TypeOf(e)) {
+			return "", false
+		}
+		return prefixes[info.Uses[e]], true
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Group" || len(e.Args) == 0 || !isRouter(info.TypeOf(sel.X)) {
+			return "", false
+		}
+		parent, ok := groupPrefix(info, prefixes, sel.X)
+		if !ok {
+			return "", false
+		}
+		rel, ok := constString(info, e.Args[0])
+		if !ok {
+			return "", false
+		}
+		return joinPaths(parent, rel), true
+	}
+	return "", false
+}
+
+// route 解析 r.GET("/path", handlers...) 与 r.Handle("GET", "/path", handlers...) 形式的路由注册
+func (inv *Inventory) route(pkg *packages.Package, prefixes map[types.Object]string, call *ast.CallExpr) (Route, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !isRouter(pkg.TypesInfo.TypeOf(sel.X)) {
+		return Route{}, false
+	}
+	args := call.Args
+	method, ok := routeMethods[sel.Sel.Name]
+	if sel.Sel.Name == "Handle" && len(args) > 0 {
+		method, ok = constString(pkg.TypesInfo, args[0])
+		args = args[1:]
+	}
+	if !ok || len(args) < 2 {
+		return Route{}, false
+	}
+	prefix, ok := groupPrefix(pkg.TypesInfo, prefixes, sel.X)
+	if !ok {
+		return Route{}, false
+	}
+	rel, ok := constString(pkg.TypesInfo, args[0])
+	if !ok {
+		return Route{}, false
+	}
+	return Route{
+		Method:   method,
+		Path:     joinPaths(prefix, rel),
+		API:      inv.handlerAPI(pkg.TypesInfo, args[len(args)-1]),
+		Position: inv.position(pkg.Fset, call.Pos()),
+	}, true
+}
+
+// handlerAPI 处理器表达式为 pkg.XxxHandler() 时返回对应的API
+func (inv *Inventory) handlerAPI(info *types.Info, expr ast.Expr) *APIEntry {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	ident := calleeIdent(call.Fun)
+	if ident == nil {
+		return nil
+	}
+	fn, ok := info.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return nil
+	}
+	for i := range inv.APIs {
+		if inv.APIs[i].Package == fn.Pkg().Path() && inv.APIs[i].Handler == fn.Name() {
+			return &inv.APIs[i]
+		}
+	}
+	return nil
+}
+
+// registeredCodes 读取 gbc codegen 生成的注册文件中 swagger.MustRegisterBusinessStatusCodes("pkg.hfXxx", []kit.Code{...}) 注册的业务状态码
+// 注册文件带有 gbc_generate_exclude 约束, 不在已加载的软件包中, 因此按语法解析, 以导入路径与变量名匹配业务状态码
+func (inv *Inventory) registeredCodes() map[string][]CodeEntry {
+	codes := make(map[string]CodeEntry, len(inv.Codes))
+	for _, c := range inv.Codes {
+		codes[c.Package+"."+c.Var] = c
+	}
+	res := make(map[string][]CodeEntry)
+	_ = filepath.WalkDir(inv.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p != inv.dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if name != GeneratedFileName {
+			return nil
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), p, nil, 0)
+		if err != nil {
+			return nil
+		}
+		imports := make(map[string]string)
+		for _, spec := range f.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			local := path.Base(importPath)
+			if spec.Name != nil {
+				local = spec.Name.Name
+			}
+			imports[local] = importPath
+		}
+		// 局部变量的初始值, 生成的注册文件先将业务状态码赋值给 statusCodes 再注册
+		values := make(map[string]ast.Expr)
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				if len(n.Lhs) == len(n.Rhs) {
+					for i, lhs := range n.Lhs {
+						if id, ok := lhs.(*ast.Ident); ok {
+							values[id.Name] = n.Rhs[i]
+						}
+					}
+				}
+			case *ast.CallExpr:
+				sel, ok := n.Fun.(*ast.SelectorExpr)
+				if !ok || len(n.Args) != 2 || sel.Sel.Name != "MustRegisterBusinessStatusCodes" {
+					return true
+				}
+				if x, ok := sel.X.(*ast.Ident); !ok || imports[x.Name] != SwaggerPkgPath {
+					return true
+				}
+				lit, ok := n.Args[0].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return true
+				}
+				handler, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					return true
+				}
+				arg := ast.Unparen(n.Args[1])
+				if id, ok := arg.(*ast.Ident); ok && values[id.Name] != nil {
+					arg = ast.Unparen(values[id.Name])
+				}
+				elts, ok := arg.(*ast.CompositeLit)
+				if !ok {
+					return true
+				}
+				for _, elt := range elts.Elts {
+					sel, ok := elt.(*ast.SelectorExpr)
+					if !ok {
+						continue
+					}
+					x, ok := sel.X.(*ast.Ident)
+					if !ok {
+						continue
+					}
+					if code, ok := codes[imports[x.Name]+"."+sel.Sel.Name]; ok {
+						res[handler] = append(res[handler], code)
+					}
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	return res
+}
+
+// calleeIdent 取出 x 或 pkg.x 形式的表达式中的标识符
+func calleeIdent(expr ast.Expr) *ast.Ident {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.SelectorExpr:
+		return e.Sel
+	case *ast.Ident:
+		return e
+	}
+	return nil
+}
+
+func constString(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// joinPaths 与gin拼接分组路径的规则一致: 保留相对路径末尾的 /
+func joinPaths(absolute, relative string) string {
+	if relative == "" {
+		if absolute == "" {
+			return "/"
+		}
+		return absolute
+	}
+	joined := path.Join("/", absolute, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+	return joined
+}
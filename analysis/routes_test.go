@@ -0,0 +1,34 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoutes(t *testing.T) {
+	inv := loadFixture(t)
+
+	got := make([]string, 0)
+	for _, r := range inv.Routes() {
+		s := r.Method + " " + r.Path + " " + r.Position
+		if r.API != nil {
+			s += " " + r.API.Type
+		}
+		for _, c := range r.Codes {
+			s += " " + c.Var
+		}
+		got = append(got, s)
+	}
+	want := []string{
+		"GET /ping register/register.go:17",
+		// 分组作为参数传递时按空前缀处理
+		"DELETE /user/:id register/register.go:28 InfoApi",
+		"PUT /user/info/ register/register.go:20 InfoApi",
+		// 业务状态码来自 gbc codegen 生成的注册文件
+		"POST /user/login/:id register/register.go:19 LoginApi CodeUserNotFound CodePasswordWrong",
+		"Any /user/v2/*rest register/register.go:22",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Routes() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
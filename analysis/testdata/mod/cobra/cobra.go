@@ -0,0 +1,7 @@
+// Package cobra 测试用的cobra替身, 仅声明gbc分析所需的API
+package cobra
+
+type Command struct {
+	Use  string
+	RunE func(cmd *Command, args []string) error
+}
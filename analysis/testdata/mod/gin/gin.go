@@ -0,0 +1,42 @@
+// Package gin 测试用的gin替身, 仅声明gbc分析所需的API
+package gin
+
+type H map[string]any
+
+type HandlerFunc func(*Context)
+
+type Context struct{}
+
+func (c *Context) JSON(code int, obj any)                        {}
+func (c *Context) String(code int, format string, values ...any) {}
+func (c *Context) Abort()                                        {}
+func (c *Context) AbortWithStatusJSON(code int, obj any)         {}
+func (c *Context) IsAborted() bool                               { return false }
+func (c *Context) ShouldBindUri(obj any) error                   { return nil }
+func (c *Context) ShouldBindHeader(obj any) error                { return nil }
+func (c *Context) ShouldBindQuery(obj any) error                 { return nil }
+func (c *Context) ShouldBindJSON(obj any) error                  { return nil }
+
+type IRoutes interface {
+	Handle(httpMethod, relativePath string, handlers ...HandlerFunc) IRoutes
+	GET(relativePath string, handlers ...HandlerFunc) IRoutes
+	POST(relativePath string, handlers ...HandlerFunc) IRoutes
+}
+
+type RouterGroup struct{}
+
+func (g *RouterGroup) Group(relativePath string, handlers ...HandlerFunc) *RouterGroup { return g }
+func (g *RouterGroup) Handle(httpMethod, relativePath string, handlers ...HandlerFunc) IRoutes {
+	return g
+}
+func (g *RouterGroup) Any(relativePath string, handlers ...HandlerFunc) IRoutes    { return g }
+func (g *RouterGroup) GET(relativePath string, handlers ...HandlerFunc) IRoutes    { return g }
+func (g *RouterGroup) POST(relativePath string, handlers ...HandlerFunc) IRoutes   { return g }
+func (g *RouterGroup) PUT(relativePath string, handlers ...HandlerFunc) IRoutes    { return g }
+func (g *RouterGroup) DELETE(relativePath string, handlers ...HandlerFunc) IRoutes { return g }
+
+type Engine struct {
+	RouterGroup
+}
+
+func New() *Engine { return &Engine{} }
@@ -0,0 +1,6 @@
+// Package logrus 测试用的logrus替身, 仅声明gbc分析所需的API
+package logrus
+
+func Info(args ...any)                  {}
+func Fatal(args ...any)                 {}
+func Panicf(format string, args ...any) {}
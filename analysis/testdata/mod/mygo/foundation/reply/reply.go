@@ -0,0 +1,11 @@
+// Package reply 测试用的mygo替身, 仅声明gbc分析所需的API
+package reply
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/kit"
+)
+
+func Success(ctx *gin.Context, data any) {}
+
+func Fail(ctx *gin.Context, code kit.Code) {}
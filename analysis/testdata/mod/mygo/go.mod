@@ -0,0 +1,7 @@
+module github.com/zjutjh/mygo
+
+go 1.24
+
+require github.com/gin-gonic/gin v1.0.0
+
+replace github.com/gin-gonic/gin => ../gin
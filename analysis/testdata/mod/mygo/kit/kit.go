@@ -0,0 +1,11 @@
+// Package kit 测试用的mygo替身, 仅声明gbc分析所需的API
+package kit
+
+type Code struct {
+	Code    int64
+	Message string
+}
+
+func NewCode(code int64, message string) Code {
+	return Code{Code: code, Message: message}
+}
@@ -0,0 +1,8 @@
+// Package swagger 测试用的mygo替身, 仅声明gbc分析所需的API
+package swagger
+
+import "github.com/zjutjh/mygo/kit"
+
+var CM = make(map[string]any)
+
+func MustRegisterBusinessStatusCodes(handler string, codes []kit.Code) {}
@@ -0,0 +1,25 @@
+package user
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/kit"
+
+	"app/comm"
+)
+
+func InfoHandler() gin.HandlerFunc {
+	return hfInfo
+}
+
+type InfoApi struct {
+	Info    struct{} `name:"用户信息"`
+	Request struct {
+		Query struct{}
+	}
+}
+
+func (i *InfoApi) Run(ctx *gin.Context) kit.Code {
+	return comm.CodeOK
+}
+
+func hfInfo(ctx *gin.Context) {}
@@ -0,0 +1,45 @@
+package user
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/kit"
+
+	"app/comm"
+)
+
+func LoginHandler() gin.HandlerFunc {
+	return hfLogin
+}
+
+type LoginApi struct {
+	Info     struct{} `name:"登录" desc:"用户登录"`
+	Request  LoginApiRequest
+	Response LoginApiResponse
+}
+
+type LoginApiRequest struct {
+	Uri struct {
+		ID string `uri:"id"`
+	}
+	Body struct {
+		Username string `json:"username"`
+	}
+}
+
+type LoginApiResponse struct {
+	Token string `json:"token"`
+}
+
+func (l *LoginApi) Run(ctx *gin.Context) kit.Code {
+	return comm.CodeOK
+}
+
+func hfLogin(ctx *gin.Context) {}
+
+// HelperApi 没有Info字段, 不是API
+type HelperApi struct{}
+
+// Worker 存在 Run() 方法但不是定时任务
+type Worker struct{}
+
+func (Worker) Run() {}
@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+func HelloRun(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// helloArgs 签名不符合命令函数
+func helloArgs(cmd *cobra.Command) error {
+	return nil
+}
@@ -0,0 +1,15 @@
+package comm
+
+import "github.com/zjutjh/mygo/kit"
+
+const userBase = 10000
+
+var (
+	CodeOK               = kit.NewCode(0, "ok")
+	CodeParameterInvalid = kit.NewCode(400, "参数错误")
+	CodeUserNotFound     = kit.NewCode(userBase+1, "用户不存在")
+	CodePasswordWrong    = kit.NewCode(userBase+2, "密码错误")
+)
+
+// codeTemp 不是 kit.NewCode 调用, 不属于业务状态码
+var codeTemp = kit.Code{Code: 1}
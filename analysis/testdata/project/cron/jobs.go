@@ -0,0 +1,29 @@
+package cron
+
+type CleanJob struct{}
+
+func (CleanJob) Run() {}
+
+type SyncJob struct {
+	interval int
+}
+
+func NewSyncJob() *SyncJob {
+	return &SyncJob{interval: 1}
+}
+
+func (j *SyncJob) Run() {}
+
+type TickJob struct{}
+
+func (*TickJob) Run() {}
+
+// IdleJob 未在register中注册
+type IdleJob struct{}
+
+func (IdleJob) Run() {}
+
+// BadJob 的Run方法有返回值, 不是定时任务
+type BadJob struct{}
+
+func (BadJob) Run() error { return nil }
@@ -0,0 +1,15 @@
+module app
+
+go 1.24
+
+require (
+	github.com/gin-gonic/gin v1.0.0
+	github.com/spf13/cobra v1.0.0
+	github.com/zjutjh/mygo v1.0.0
+)
+
+replace (
+	github.com/gin-gonic/gin => ../mod/gin
+	github.com/spf13/cobra => ../mod/cobra
+	github.com/zjutjh/mygo => ../mod/mygo
+)
@@ -0,0 +1,29 @@
+package register
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"app/api/user"
+	"app/cron"
+)
+
+var Jobs = []interface{ Run() }{
+	cron.CleanJob{},
+	cron.NewSyncJob(),
+	new(cron.TickJob),
+}
+
+func Route(r *gin.Engine) {
+	r.GET("/ping", func(ctx *gin.Context) {})
+	g := r.Group("/user")
+	g.POST("/login/:id", user.LoginHandler())
+	g.Handle("PUT", "info/", user.InfoHandler())
+	v2 := g.Group("v2")
+	v2.Any("/*rest", func(ctx *gin.Context) {})
+	admin(r.Group("/admin"))
+}
+
+// admin 分组作为参数传递时无法得知前缀
+func admin(r *gin.RouterGroup) {
+	r.DELETE("/user/:id", user.InfoHandler())
+}
@@ -0,0 +1,238 @@
+package analysis
+
+import (
+	"cmp"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"regexp"
+	"slices"
+
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/template"
+)
+
+// TodoEntry 仍保留模板默认内容、尚未实现的制品
+type TodoEntry struct {
+	Kind     string `json:"kind"`     // 制品类型: api、cron、cmd
+	Package  string `json:"package"`  // 包路径
+	Name     string `json:"name"`     // 类型或函数名称
+	Reason   string `json:"reason"`   // 未实现的原因
+	Position string `json:"position"` // 声明位置
+
+	pos token.Position
+}
+
+// scaffold 模板中待实现的函数
+type scaffold struct {
+	body   *ast.BlockStmt
+	params []string // 接收者与参数名称
+}
+
+var placeholderRegexp = regexp.MustCompile(`\{\$(\w+)\}`)
+
+// placeholderValues 解析模板时占位符的取值, 未列出的占位符替换为空
+var placeholderValues = map[string]string{
+	"PackageName": "p",
+	"ModulePath":  "m",
+	"ApiStruct":   "X",
+	"CronName":    "X",
+	"CMDName":     "X",
+	"Receiver":    "x",
+	"ApiInfo":     "Info struct{}",
+}
+
+// parseScaffold 替换模板占位符后解析源码, 返回名为name的函数 (method表示是否为方法)
+func parseScaffold(src, name string, method bool) (*scaffold, error) {
+	src = placeholderRegexp.ReplaceAllStringFunc(src, func(s string) string {
+		return placeholderValues[placeholderRegexp.FindStringSubmatch(s)[1]]
+	})
+	f, err := parser.ParseFile(token.NewFileSet(), "template.go", src, 0)
+	if err != nil {
+		return nil, i18n.Errorf("解析模板失败: %w", err)
+	}
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if ok && fn.Name.Name == name && (fn.Recv != nil) == method {
+			return &scaffold{body: fn.Body, params: funcParams(fn)}, nil
+		}
+	}
+	return nil, i18n.Errorf("模板中不存在函数[%s]", name)
+}
+
+// funcParams 函数的接收者与参数名称, 按声明顺序
+func funcParams(fn *ast.FuncDecl) []string {
+	names := make([]string, 0)
+	fields := fn.Type.Params.List
+	if fn.Recv != nil {
+		fields = slices.Concat(fn.Recv.List, fields)
+	}
+	for _, field := range fields {
+		if len(field.Names) == 0 {
+			names = append(names, "_")
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// unchanged fn的函数体与模板在语法树上是否一致, 忽略注释、格式与接收者/参数的命名
+func (s *scaffold) unchanged(fn *ast.FuncDecl) bool {
+	if fn.Body == nil {
+		return false
+	}
+	rename := make(map[string]string)
+	params := funcParams(fn)
+	if len(params) != len(s.params) {
+		return false
+	}
+	for i, name := range params {
+		if name != "_" {
+			rename[name] = s.params[i]
+		}
+	}
+	return equalAST(reflect.ValueOf(fn.Body), reflect.ValueOf(s.body), rename)
+}
+
+var posType = reflect.TypeFor[token.Pos]()
+
+// equalAST 比较两棵语法树的结构, 忽略位置信息, x中的标识符按rename重命名后比较
+func equalAST(x, y reflect.Value, rename map[string]string) bool {
+	if x.Type() != y.Type() {
+		return false
+	}
+	switch x.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if x.IsNil() || y.IsNil() {
+			return x.IsNil() == y.IsNil()
+		}
+		if id, ok := x.Interface().(*ast.Ident); ok {
+			other, ok := y.Interface().(*ast.Ident)
+			if !ok {
+				return false
+			}
+			return cmp.Or(rename[id.Name], id.Name) == other.Name
+		}
+		return equalAST(x.Elem(), y.Elem(), rename)
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			if x.Type().Field(i).Type == posType {
+				continue
+			}
+			if !equalAST(x.Field(i), y.Field(i), rename) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		if x.Len() != y.Len() {
+			return false
+		}
+		for i := 0; i < x.Len(); i++ {
+			if !equalAST(x.Index(i), y.Index(i), rename) {
+				return false
+			}
+		}
+		return true
+	default:
+		return x.Interface() == y.Interface()
+	}
+}
+
+// Todos 列出仍保留模板默认函数体或默认Info标签的API、定时任务与命令
+func (inv *Inventory) Todos() ([]TodoEntry, error) {
+	apiRun, err := parseScaffold(template.APITemplate, "Run", true)
+	if err != nil {
+		return nil, err
+	}
+	cronRun, err := parseScaffold(template.CronTemplate, "Run", true)
+	if err != nil {
+		return nil, err
+	}
+	cmdRun, err := parseScaffold(template.CMDTemplate, "XRun", false)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ pkg, name string }
+	apis := make(map[key]bool)
+	crons := make(map[key]bool)
+	cmds := make(map[key]bool)
+	for _, api := range inv.APIs {
+		apis[key{api.Package, api.Type}] = true
+	}
+	for _, c := range inv.Crons {
+		crons[key{c.Package, c.Type}] = true
+	}
+	for _, c := range inv.Cmds {
+		cmds[key{c.Package, c.Func}] = true
+	}
+
+	todos := make([]TodoEntry, 0)
+	add := func(kind, pkg, name, reason string, p token.Position) {
+		todos = append(todos, TodoEntry{
+			Kind:     kind,
+			Package:  pkg,
+			Name:     name,
+			Reason:   i18n.T(reason),
+			Position: inv.relPosition(p),
+			pos:      p,
+		})
+	}
+	for _, pkg := range inv.pkgs {
+		for _, f := range pkg.Syntax {
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok {
+					continue
+				}
+				p := pkg.Fset.Position(fn.Name.Pos())
+				if fn.Recv == nil {
+					if cmds[key{pkg.PkgPath, fn.Name.Name}] && cmdRun.unchanged(fn) {
+						add("cmd", pkg.PkgPath, fn.Name.Name, "命令函数仍为模板默认实现", p)
+					}
+					continue
+				}
+				if fn.Name.Name != "Run" {
+					continue
+				}
+				recv := receiverName(fn)
+				switch {
+				case apis[key{pkg.PkgPath, recv}] && apiRun.unchanged(fn):
+					add("api", pkg.PkgPath, recv, "Run仍为模板默认实现", p)
+				case crons[key{pkg.PkgPath, recv}] && cronRun.unchanged(fn):
+					add("cron", pkg.PkgPath, recv, "Run仍为模板默认实现", p)
+				}
+			}
+		}
+		for _, api := range inv.APIs {
+			if api.Package != pkg.PkgPath || (api.Name != template.APIDefaultName && api.Desc != template.APIDefaultDesc) {
+				continue
+			}
+			obj := pkg.Types.Scope().Lookup(api.Type)
+			add("api", api.Package, api.Type, "Info标签仍为模板默认值", pkg.Fset.Position(obj.Pos()))
+		}
+	}
+	slices.SortFunc(todos, func(a, b TodoEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.pos.Filename, b.pos.Filename),
+			cmp.Compare(a.pos.Offset, b.pos.Offset),
+		)
+	})
+	return todos, nil
+}
+
+// receiverName 方法接收者的类型名称
+func receiverName(fn *ast.FuncDecl) string {
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
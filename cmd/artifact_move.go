@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/refactor"
+)
+
+var artifactMoveCmd = &cobra.Command{
+	Use:               "mv {api|cmd|cron} {key} {new-key}",
+	Short:             "重命名或移动API/Command/Cron",
+	Long:              "重命名API/Command/Cron的标识符与文件, 跨包移动时修正导入, 改写router/register中的引用, 并重新生成业务状态码",
+	Example:           "gbc mv api user.login auth.sign_in --dry-run",
+	Args:              cobra.MatchAll(cobra.ExactArgs(3), validArtifactKind),
+	ValidArgsFunction: completeArtifactArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, err := refactor.LoadProject(".")
+		if err != nil {
+			return comm.EnvErrorf("加载项目失败: %w", err)
+		}
+		kind := refactor.Kinds[args[0]]
+		from, err := refactor.ResolveArtifact(kind, project.ModulePath, args[1])
+		if err != nil {
+			return comm.UsageErrorf("解析key失败: %w", err)
+		}
+		to, err := refactor.ResolveArtifact(kind, project.ModulePath, args[2])
+		if err != nil {
+			return comm.UsageErrorf("解析key失败: %w", err)
+		}
+		if err := project.MoveArtifact(from, to); err != nil {
+			return i18n.Errorf("移动%s失败: %w", from, err)
+		}
+		if err := project.Apply(artifactDryRun); err != nil {
+			return comm.IOErrorf("移动%s失败: %w", from, err)
+		}
+		return finishArtifactChange(kind)
+	},
+}
+
+func init() {
+	artifactMoveCmd.Flags().BoolVarP(&artifactDryRun, "dry-run", "n", false, "仅展示将要进行的修改, 不写入文件")
+	artifactMoveCmd.Flags().BoolVarP(&artifactSkipCodegen, "skip-codegen", "", false, "修改完成后不重新生成业务状态码")
+	rootCmd.AddCommand(artifactMoveCmd)
+}
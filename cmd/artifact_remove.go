@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/refactor"
+)
+
+var (
+	artifactDryRun      bool
+	artifactSkipCodegen bool
+)
+
+var artifactRemoveCmd = &cobra.Command{
+	Use:               "rm {api|cmd|cron} {key}",
+	Short:             "删除API/Command/Cron及其注册代码",
+	Long:              "删除API/Command/Cron文件, 移除router/register中的注册调用与不再使用的导入, 并重新生成业务状态码",
+	Example:           "gbc rm api user.login --dry-run",
+	Args:              cobra.MatchAll(cobra.ExactArgs(2), validArtifactKind),
+	ValidArgsFunction: completeArtifactArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, err := refactor.LoadProject(".")
+		if err != nil {
+			return comm.EnvErrorf("加载项目失败: %w", err)
+		}
+		artifact, err := refactor.ResolveArtifact(refactor.Kinds[args[0]], project.ModulePath, args[1])
+		if err != nil {
+			return comm.UsageErrorf("解析key失败: %w", err)
+		}
+		if err := project.RemoveArtifact(artifact); err != nil {
+			return i18n.Errorf("删除%s失败: %w", artifact, err)
+		}
+		if err := project.Apply(artifactDryRun); err != nil {
+			return comm.IOErrorf("删除%s失败: %w", artifact, err)
+		}
+		return finishArtifactChange(artifact.Kind)
+	},
+}
+
+// validArtifactKind 校验第一个参数为支持的制品类型
+func validArtifactKind(cmd *cobra.Command, args []string) error {
+	if _, ok := refactor.Kinds[args[0]]; !ok {
+		return i18n.Errorf("不支持的制品类型[%s], 可选的值有: %s", args[0], strings.Join(refactor.KindNames(), "、"))
+	}
+	return nil
+}
+
+// finishArtifactChange 制品变更后重新生成业务状态码
+func finishArtifactChange(kind refactor.Kind) error {
+	if artifactDryRun {
+		comm.Log.Successf("dry-run模式, 未写入任何修改")
+		return nil
+	}
+	if kind.Name != "api" || artifactSkipCodegen {
+		return nil
+	}
+	if err := runCodegen("."); err != nil {
+		return i18n.Errorf("重新生成业务状态码失败: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	artifactRemoveCmd.Flags().BoolVarP(&artifactDryRun, "dry-run", "n", false, "仅展示将要进行的修改, 不写入文件")
+	artifactRemoveCmd.Flags().BoolVarP(&artifactSkipCodegen, "skip-codegen", "", false, "修改完成后不重新生成业务状态码")
+	rootCmd.AddCommand(artifactRemoveCmd)
+}
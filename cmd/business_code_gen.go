@@ -1,16 +1,16 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
 	"path/filepath"
 
 	"golang.org/x/tools/go/callgraph"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/zjutjh/gbc/analysis"
 	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/release"
 )
 
 var (
@@ -25,56 +25,92 @@ var businessCodeGenCmd = &cobra.Command{
 	Use:   "codegen",
 	Short: "生成业务状态码",
 	Long:  "生成业务状态码",
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := analysis.Init(); err != nil {
-			comm.OutputError("初始化失败: %s", err.Error())
-			os.Exit(1)
-		}
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCodegen(".")
+	},
+}
 
-		analysisInst := new(analysis.Analysis)
-		if err := analysisInst.DoAnalysis(analysis.CallGraphType(callgraphAlgo), buildTags, "", "."); err != nil {
-			comm.OutputError("分析代码失败: %s", err.Error())
-			os.Exit(1)
-		}
+// runCodegen 分析dir目录下的项目并重新生成业务状态码注册文件
+func runCodegen(dir string) error {
+	outDir := filepath.Clean(storeDir)
+	if !filepath.IsAbs(outDir) {
+		outDir = filepath.Join(dir, outDir)
+	}
+	return generateCodes(dir, outDir)
+}
 
-		moduleName := analysisInst.MainPackagePath()
+// generateCodes 分析dir目录下的项目, 将业务状态码注册文件生成到outDir, 生成文件的包名为outDir的最后一级目录名
+func generateCodes(dir, outDir string) error {
+	moduleName, infos, err := analyzeHandlerCodes(dir)
+	if err != nil {
+		return err
+	}
+	if err := analysis.GenerateInitialFiles(moduleName, infos, outDir); err != nil {
+		return comm.IOErrorf("生成业务状态码文件失败: %w", err)
+	}
+	return nil
+}
 
-		ginHandlers := analysis.GetGinHandlers(analysisInst)
+// loadAnalysis 构建dir目录下项目的SSA程序与调用图
+func loadAnalysis(dir string) (*analysis.Analysis, error) {
+	release.WarnIncompatibleMygo(dir, "codegen")
 
-		allHandlers := make(map[*callgraph.Node]struct{})
-		for _, handler := range ginHandlers {
-			allHandlers[handler] = struct{}{}
-		}
+	if err := analysis.Init(); err != nil {
+		return nil, comm.EnvErrorf("初始化失败: %w", err)
+	}
 
-		// 包级变量的业务码映射
-		globalCodeMap := analysis.CollectGlobalCodeVars(analysisInst, "github.com/zjutjh/mygo/kit", "NewCode")
-
-		infos := map[string][]*analysis.GinHandlerInfo{}
-		for _, handler := range ginHandlers {
-			info, err := analysis.ParseGinHandler(analysisInst, skipSyntheticEdges, comm.DebugMode && showReferences, handler, allHandlers, globalCodeMap)
-			if err != nil {
-				comm.OutputError("解析处理器 %v 的状态码失败：%v", handler, err)
-				os.Exit(1)
-			}
-			pkgName := analysis.GetPackageName(handler)
-			infos[pkgName] = append(infos[pkgName], info)
-		}
+	analysisInst := new(analysis.Analysis)
+	if err := analysisInst.DoAnalysis(analysis.CallGraphType(callgraphAlgo), buildTags, dir, "."); err != nil {
+		return nil, comm.AnalysisErrorf("分析代码失败: %w", err)
+	}
+	return analysisInst, nil
+}
 
-		err := analysis.GenerateInitialFiles(moduleName, infos, filepath.Clean(storeDir))
+// analyzeHandlerCodes 构建dir目录下项目的调用图, 分析每个gin处理器可能返回的业务状态码, 结果按处理器所在的包分组
+func analyzeHandlerCodes(dir string) (string, map[string][]*analysis.GinHandlerInfo, error) {
+	analysisInst, err := loadAnalysis(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	moduleName := analysisInst.MainPackagePath()
+
+	ginHandlers := analysis.GetGinHandlers(analysisInst)
+
+	allHandlers := make(map[*callgraph.Node]struct{})
+	for _, handler := range ginHandlers {
+		allHandlers[handler] = struct{}{}
+	}
+
+	// 包级变量的业务码映射
+	globalCodeMap := analysis.CollectGlobalCodeVars(analysisInst, analysis.KitPkgPath, "NewCode")
+
+	infos := map[string][]*analysis.GinHandlerInfo{}
+	for _, handler := range ginHandlers {
+		info, err := analysis.ParseGinHandler(analysisInst, skipSyntheticEdges, comm.DebugMode && showReferences, handler, allHandlers, globalCodeMap)
 		if err != nil {
-			comm.OutputError("%s", err.Error())
-			os.Exit(1)
+			return "", nil, comm.AnalysisErrorf("解析处理器 %v 的状态码失败：%w", handler, err)
 		}
-	},
+		pkgName := analysis.GetPackageName(handler)
+		infos[pkgName] = append(infos[pkgName], info)
+	}
+	return moduleName, infos, nil
+}
+
+// addCallGraphFlags 为需要构建调用图分析业务状态码的命令注册 --algorithm 与 --skip-synthetic-edges, flags为cmd的本地或持久选项
+func addCallGraphFlags(cmd *cobra.Command, flags *pflag.FlagSet) {
+	flags.StringVarP(&callgraphAlgo, "algorithm", "a", string(analysis.CallGraphTypeRta), "要使用的构造函数调用图的算法。可选的值有：static、cha、rta")
+	flags.BoolVarP(&skipSyntheticEdges, "skip-synthetic-edges", "k", true, "是否跳过合成边（synthetic edge，即通过reflect等动态调用方式）")
+	_ = cmd.RegisterFlagCompletionFunc("algorithm", completeAlgorithm)
 }
 
 func init() {
 	businessCodeGenCmd.PersistentFlags().StringVarP(&storeDir, "store-dir", "s", "register/generate", "生成文件存储目录")
-	businessCodeGenCmd.PersistentFlags().StringVarP(&callgraphAlgo, "algorithm", "a", string(analysis.CallGraphTypeRta), fmt.Sprintf("要使用的构造函数调用图的算法。可选的值有：%q、%q、%q",
-		analysis.CallGraphTypeStatic, analysis.CallGraphTypeCha, analysis.CallGraphTypeRta))
-	businessCodeGenCmd.PersistentFlags().BoolVarP(&skipSyntheticEdges, "skip-synthetic-edges", "k", true, "是否跳过合成边（synthetic edge，即通过reflect等动态调用方式）")
+	addCallGraphFlags(businessCodeGenCmd, businessCodeGenCmd.PersistentFlags())
 	businessCodeGenCmd.PersistentFlags().StringArrayVarP(&buildTags, "build-tags", "t", nil, "编译时的build tag")
 	businessCodeGenCmd.PersistentFlags().BoolVarP(&showReferences, "show-references", "r", false, "是否显示最外层接口到状态码的引用关系（仅在调试时使用）")
+	_ = businessCodeGenCmd.RegisterFlagCompletionFunc("build-tags", completeBuildTags)
 
 	rootCmd.AddCommand(businessCodeGenCmd)
 }
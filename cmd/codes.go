@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/codes"
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+var (
+	codesDocFormat    string
+	codesDocGroup     string
+	codesDocRangeSize int64
+	codesDocOutput    string
+)
+
+var codesCmd = &cobra.Command{
+	Use:   "codes",
+	Short: "业务状态码的文档、翻译与生成工具",
+	Args:  cobra.NoArgs,
+}
+
+var codesDocCmd = &cobra.Command{
+	Use:   "doc",
+	Short: "导出业务状态码文档",
+	Long: `导出项目中通过 kit.NewCode 声明的全部业务状态码, 供产品与测试查阅
+
+  - 每个业务状态码包含状态码、变量名、提示信息与声明位置, 以及可能返回它的接口 (与 gbc codegen 使用相同的分析)
+  - 按包 (--group package) 或按数值区间 (--group range, 区间宽度由 --range-size 指定) 分组
+  - HTML格式为单个自包含的文件, 可直接分发或部署为静态页面`,
+	Example: "gbc codes doc > codes.md\ngbc codes doc --format html --group range -o codes.html",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if codesDocFormat != "md" && codesDocFormat != "html" {
+			return comm.UsageErrorf("不支持的输出格式[%s], 可选的值有: md、html", codesDocFormat)
+		}
+		if err := codes.CheckGroup(codesDocGroup, codesDocRangeSize); err != nil {
+			return comm.UsageErrorf("%w", err)
+		}
+		if codesDocOutput == "" {
+			// 过程信息输出到标准错误, 保证标准输出只包含文档
+			comm.Stdout = os.Stderr
+		}
+		inv, err := analysis.LoadInventory(".", buildTags)
+		if err != nil {
+			return comm.AnalysisErrorf("分析代码失败: %w", err)
+		}
+		if len(inv.Codes) == 0 {
+			return comm.AnalysisErrorf("项目中未找到通过 kit.NewCode 声明的业务状态码")
+		}
+		_, infos, err := analyzeHandlerCodes(".")
+		if err != nil {
+			return err
+		}
+		groups, err := codes.Groups(inv.Codes, codes.Usages(inv.Codes, inv.Routes(), infos), codesDocGroup, codesDocRangeSize)
+		if err != nil {
+			return comm.FailureErrorf("导出业务状态码文档失败: %w", err)
+		}
+
+		var w io.Writer = os.Stdout
+		if codesDocOutput != "" {
+			f, err := os.Create(codesDocOutput)
+			if err != nil {
+				return comm.IOErrorf("创建文件[%s]失败: %w", codesDocOutput, err)
+			}
+			defer f.Close()
+			w = f
+		}
+		write := codes.Markdown
+		if codesDocFormat == "html" {
+			write = codes.HTML
+		}
+		if err := write(w, i18n.T("业务状态码"), groups); err != nil {
+			return comm.IOErrorf("导出业务状态码文档失败: %w", err)
+		}
+		if codesDocOutput != "" {
+			comm.Log.Successf("已导出%d个业务状态码到[%s]", len(inv.Codes), codesDocOutput)
+		}
+		return nil
+	},
+}
+
+func init() {
+	codesDocCmd.Flags().StringVarP(&codesDocFormat, "format", "f", "md", "输出格式。可选的值有：md、html")
+	codesDocCmd.Flags().StringVarP(&codesDocGroup, "group", "g", codes.GroupByPackage, "分组方式。可选的值有：package、range")
+	codesDocCmd.Flags().Int64VarP(&codesDocRangeSize, "range-size", "", 10000, "按数值区间分组时每个区间的宽度")
+	codesDocCmd.Flags().StringVarP(&codesDocOutput, "output", "o", "", "输出文件, 默认输出到标准输出")
+	codesDocCmd.Flags().StringArrayVarP(&buildTags, "build-tags", "t", nil, "编译时的build tag")
+	addCallGraphFlags(codesDocCmd, codesDocCmd.Flags())
+	_ = codesDocCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]cobra.Completion{"md", "html"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = codesDocCmd.RegisterFlagCompletionFunc("group", cobra.FixedCompletions([]cobra.Completion{codes.GroupByPackage, codes.GroupByRange}, cobra.ShellCompDirectiveNoFileComp))
+	_ = codesDocCmd.RegisterFlagCompletionFunc("build-tags", completeBuildTags)
+
+	codesCmd.AddCommand(codesDocCmd)
+	rootCmd.AddCommand(codesCmd)
+}
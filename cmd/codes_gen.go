@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/codes"
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/refactor"
+)
+
+// defaultCodesOutput 注册表未指定 output 时生成文件的路径
+const defaultCodesOutput = "comm/codes_generated.go"
+
+var (
+	codesGenFile     string
+	codesGenOutput   string
+	codesGenDryRun   bool
+	codesExportFile  string
+	codesExportForce bool
+)
+
+var codesGenCmd = &cobra.Command{
+	Use:   "gen",
+	Short: "根据注册表生成业务状态码声明",
+	Long: `以注册表 (codes.yaml 或 codes.csv) 作为业务状态码的唯一来源, 生成 kit.NewCode 变量声明
+
+注册表中每个业务状态码包含状态码 (code)、变量名 (name)、提示信息 (message)、建议的HTTP状态码 (http) 与所属模块 (module),
+YAML格式还可以通过 ranges 声明每个模块可用的状态码区间, 通过 output 指定生成文件的路径 (默认为 comm/codes_generated.go)
+
+生成前会检查:
+  - 状态码与变量名均不重复, 变量名为导出的Go标识符, 提示信息不为空
+  - 模块的区间互不重叠, 状态码落在所属模块的区间内且不占用其他模块的区间
+  - 生成文件所在包的其他文件中没有同名声明
+
+可以通过 gbc codes export 由项目中已有的声明生成注册表`,
+	Example: "gbc codes gen\ngbc codes gen -f codes.csv -o comm/codes_generated.go\ngbc codes gen --dry-run",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := codesGenFile
+		if file == "" {
+			for _, candidate := range []string{codes.RegistryFile, codes.RegistryCSVFile} {
+				if fileExists(candidate) {
+					file = candidate
+					break
+				}
+			}
+			if file == "" {
+				return comm.UsageErrorf("未找到注册表 %s 或 %s, 可以先执行 gbc codes export 由已有的声明生成", codes.RegistryFile, codes.RegistryCSVFile)
+			}
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return comm.IOErrorf("读取文件[%s]失败: %w", file, err)
+		}
+		registry, err := codes.LoadRegistry(file, data)
+		if err != nil {
+			return comm.UsageErrorf("%w", err)
+		}
+		if len(registry.Codes) == 0 {
+			return comm.UsageErrorf("注册表[%s]中没有业务状态码", file)
+		}
+		if err := registry.Validate(); err != nil {
+			return reportRegistryErrors(file, err)
+		}
+
+		output := cmp.Or(codesGenOutput, registry.Output, defaultCodesOutput)
+		pkgName, err := checkCodesPackage(output, registry)
+		if err != nil {
+			return err
+		}
+		src, err := registry.Generate(pkgName, file)
+		if err != nil {
+			return comm.FailureErrorf("生成业务状态码声明失败: %w", err)
+		}
+		if codesGenDryRun {
+			_, err := os.Stdout.Write(src)
+			return err
+		}
+		if old, err := os.ReadFile(output); err == nil && bytes.Equal(old, src) {
+			comm.Log.Successf("[%s]已是最新, 共%d个业务状态码", output, len(registry.Codes))
+			return nil
+		}
+		if err := comm.EnsureDir(filepath.Dir(output)); err != nil {
+			return comm.IOErrorf("生成业务状态码声明失败: %w", err)
+		}
+		if err := os.WriteFile(output, src, 0644); err != nil {
+			return comm.IOErrorf("写入文件[%s]失败: %w", output, err)
+		}
+		comm.Log.Successf("已根据[%s]生成%d个业务状态码到[%s]", file, len(registry.Codes), output)
+		return nil
+	},
+}
+
+var codesExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "由项目中已有的业务状态码生成注册表",
+	Long: `收集项目中通过 kit.NewCode 声明的业务状态码, 生成 gbc codes gen 使用的注册表, 格式由输出文件的扩展名 (.yaml、.csv) 决定
+
+  - 模块取变量所在包的包名, 可以按需调整并在YAML格式中补充 ranges
+  - 提示信息不是常量的业务状态码会原样导出, 需要手动补充提示信息
+  - 导出后删除原有的 kit.NewCode 声明, 再执行 gbc codes gen 生成`,
+	Example: "gbc codes export\ngbc codes export -o codes.csv",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if fileExists(codesExportFile) && !codesExportForce {
+			return comm.UsageErrorf("文件[%s]已存在, 使用 --force 覆盖", codesExportFile)
+		}
+		inv, err := analysis.LoadInventory(".", buildTags)
+		if err != nil {
+			return comm.AnalysisErrorf("分析代码失败: %w", err)
+		}
+		if len(inv.Codes) == 0 {
+			return comm.AnalysisErrorf("项目中未找到通过 kit.NewCode 声明的业务状态码")
+		}
+		registry := codes.RegistryFrom(inv.Codes)
+		registry.Output = exportOutput(inv.Codes)
+
+		buf := bytes.Buffer{}
+		if err := codes.WriteRegistry(&buf, codesExportFile, registry); err != nil {
+			return comm.UsageErrorf("%w", err)
+		}
+		if err := os.WriteFile(codesExportFile, buf.Bytes(), 0644); err != nil {
+			return comm.IOErrorf("写入文件[%s]失败: %w", codesExportFile, err)
+		}
+		comm.Log.Successf("已导出%d个业务状态码到[%s]", len(registry.Codes), codesExportFile)
+		if err := registry.Validate(); err != nil {
+			for _, e := range unwrapJoined(err) {
+				comm.Log.Warnf("%v", e)
+			}
+		}
+		comm.Log.Infof("删除原有的 kit.NewCode 声明后执行 gbc codes gen 生成[%s]", registry.Output)
+		return nil
+	},
+}
+
+// reportRegistryErrors 逐条输出注册表中的问题
+func reportRegistryErrors(file string, err error) error {
+	errs := unwrapJoined(err)
+	for _, e := range errs {
+		comm.Log.Errorf("%s: %v", file, e)
+	}
+	return comm.FailureErrorf("注册表[%s]中存在%d个问题", file, len(errs))
+}
+
+func fileExists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
+
+func unwrapJoined(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+// checkCodesPackage 返回生成文件所在包的包名 (目录中没有其他Go文件时取目录名),
+// 并检查包中其他文件是否已声明了注册表中的变量名
+func checkCodesPackage(output string, registry *codes.Registry) (string, error) {
+	dir := filepath.Dir(output)
+	pkgName := comm.ExistingPackageName(dir)
+	if pkgName == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return "", comm.IOErrorf("读取目录[%s]失败: %w", dir, err)
+		}
+		pkgName = strings.NewReplacer("-", "_", ".", "_").Replace(filepath.Base(abs))
+	}
+
+	names := make(map[string]bool, len(registry.Codes))
+	for _, c := range registry.Codes {
+		names[c.Name] = true
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return pkgName, nil
+	}
+	if err != nil {
+		return "", comm.IOErrorf("读取目录[%s]失败: %w", dir, err)
+	}
+	fset := token.NewFileSet()
+	conflicts := 0
+	for _, e := range entries {
+		file := filepath.Join(dir, e.Name())
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || strings.HasSuffix(e.Name(), "_test.go") || filepath.Clean(file) == filepath.Clean(output) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return "", comm.AnalysisErrorf("解析文件[%s]失败: %w", file, err)
+		}
+		if ast.IsGenerated(f) && strings.Contains(f.Comments[0].Text(), codes.RegistryGenerator) {
+			// 更换生成文件路径后遗留的旧生成文件
+			comm.Log.Warnf("[%s]由 %s 生成, 更换生成文件路径后需要删除", file, codes.RegistryGenerator)
+			continue
+		}
+		for _, decl := range f.Decls {
+			for _, ident := range refactor.DeclaredIdents(decl) {
+				if names[ident.Name] {
+					conflicts++
+					comm.Log.With("path", fset.Position(ident.Pos()).String()).Errorf("%s: [%s]已由注册表生成, 需要删除该声明", fset.Position(ident.Pos()), ident.Name)
+				}
+			}
+		}
+	}
+	if conflicts > 0 {
+		return "", comm.FailureErrorf("包[%s]中有%d个声明与注册表中的变量名重复", dir, conflicts)
+	}
+	return pkgName, nil
+}
+
+// exportOutput 导出的注册表中生成文件的路径: 与声明业务状态码最多的包位于同一目录
+func exportOutput(list []analysis.CodeEntry) string {
+	count := make(map[string]int)
+	best := ""
+	for _, c := range list {
+		file, _, _ := strings.Cut(c.Position, ":")
+		dir := path.Dir(file)
+		count[dir]++
+		if best == "" || count[dir] > count[best] {
+			best = dir
+		}
+	}
+	if best == "" {
+		return defaultCodesOutput
+	}
+	return path.Join(best, "codes_generated.go")
+}
+
+func init() {
+	codesGenCmd.Flags().StringVarP(&codesGenFile, "file", "f", "", "注册表文件, 默认依次查找 codes.yaml、codes.csv")
+	codesGenCmd.Flags().StringVarP(&codesGenOutput, "output", "o", "", "生成文件的路径, 优先于注册表中的 output")
+	codesGenCmd.Flags().BoolVarP(&codesGenDryRun, "dry-run", "n", false, "仅将生成的代码输出到标准输出, 不写入文件")
+	_ = codesGenCmd.MarkFlagFilename("file", "yaml", "yml", "csv")
+	_ = codesGenCmd.MarkFlagFilename("output", "go")
+
+	codesExportCmd.Flags().StringVarP(&codesExportFile, "output", "o", codes.RegistryFile, "注册表文件, 格式由扩展名决定: .yaml、.csv")
+	codesExportCmd.Flags().BoolVarP(&codesExportForce, "force", "", false, "注册表文件已存在时覆盖")
+	codesExportCmd.Flags().StringArrayVarP(&buildTags, "build-tags", "t", nil, "编译时的build tag")
+	_ = codesExportCmd.MarkFlagFilename("output", "yaml", "yml", "csv")
+	_ = codesExportCmd.RegisterFlagCompletionFunc("build-tags", completeBuildTags)
+
+	codesCmd.AddCommand(codesGenCmd, codesExportCmd)
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/codes"
+	"github.com/zjutjh/gbc/comm"
+)
+
+var (
+	codesI18nFormat string
+	codesI18nLocale string
+	codesI18nOutput string
+	codesI18nDir    string
+)
+
+var codesI18nCmd = &cobra.Command{
+	Use:   "i18n",
+	Short: "业务状态码提示信息的翻译目录",
+	Args:  cobra.NoArgs,
+}
+
+var codesI18nExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "导出业务状态码的翻译目录",
+	Long: `导出以业务状态码为键的翻译目录, 原文为 kit.NewCode 的第二个参数 (提示信息)
+
+  - json: {"状态码": "译文"}, 未指定 --locale 时值为提示信息本身, 可直接作为前端的语言包
+  - po: gettext PO文件, 以状态码作为 msgctxt, 可使用 Poedit 等工具翻译
+  - csv: code、variable、message、translation 四列, 便于在表格软件中翻译
+
+指定 --locale 且输出文件已存在时, 保留文件中已有的译文, 只追加新的业务状态码并移除已删除的业务状态码
+同一状态码被多个变量声明时, 优先使用当前模块中的声明`,
+	Example: "gbc codes i18n export --format json > i18n/zh.json\ngbc codes i18n export --format po --locale en -o i18n/en.po",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !slices.Contains(codes.Formats, codesI18nFormat) {
+			return comm.UsageErrorf("不支持的翻译目录格式[%s], 可选的值有: %s", codesI18nFormat, strings.Join(codes.Formats, "、"))
+		}
+		existing := make(map[int64]string)
+		if codesI18nOutput != "" && codesI18nLocale != "" {
+			catalog, err := readCatalogFile(codesI18nOutput, codesI18nFormat)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			if catalog != nil {
+				existing = catalog
+			}
+		}
+		if codesI18nOutput == "" {
+			// 过程信息输出到标准错误, 保证标准输出只包含翻译目录
+			comm.Stdout = os.Stderr
+		}
+
+		list, err := analyzeCodes(".")
+		if err != nil {
+			return err
+		}
+		entries := make([]codes.Entry, 0, len(list))
+		translated := 0
+		for _, c := range list {
+			if c.Message == "" {
+				comm.Log.Warnf("业务状态码[%d] %s.%s 的提示信息不是常量, 原文将为空", c.Code, c.Package, c.Var)
+			}
+			e := codes.Entry{CodeEntry: c, Translation: existing[c.Code]}
+			if e.Translation != "" {
+				translated++
+			}
+			entries = append(entries, e)
+		}
+
+		buf := bytes.Buffer{}
+		if err := codes.WriteCatalog(&buf, codesI18nFormat, codesI18nLocale, entries); err != nil {
+			return comm.FailureErrorf("导出翻译目录失败: %w", err)
+		}
+		if codesI18nOutput == "" {
+			if _, err := io.Copy(os.Stdout, &buf); err != nil {
+				return comm.IOErrorf("导出翻译目录失败: %w", err)
+			}
+			return nil
+		}
+		if err := comm.EnsureDir(filepath.Dir(codesI18nOutput)); err != nil {
+			return comm.IOErrorf("导出翻译目录失败: %w", err)
+		}
+		if err := os.WriteFile(codesI18nOutput, buf.Bytes(), 0644); err != nil {
+			return comm.IOErrorf("写入文件[%s]失败: %w", codesI18nOutput, err)
+		}
+		if codesI18nLocale != "" {
+			comm.Log.Successf("已导出%d个业务状态码到[%s], 其中%d个已有译文", len(entries), codesI18nOutput, translated)
+		} else {
+			comm.Log.Successf("已导出%d个业务状态码到[%s]", len(entries), codesI18nOutput)
+		}
+		return nil
+	},
+}
+
+var codesI18nCheckCmd = &cobra.Command{
+	Use:   "check [file...]",
+	Short: "检查翻译目录中缺少译文的业务状态码",
+	Long: `检查每个翻译目录是否包含项目中全部业务状态码的译文, 格式由文件扩展名 (.json、.po、.csv) 决定
+
+  - 未指定文件时检查 --dir 目录下的全部翻译目录
+  - 缺少译文或译文为空的业务状态码视为缺失, PO文件中标记为 fuzzy 的条目同样视为缺失
+  - 翻译目录中已不存在的业务状态码仅给出警告
+
+存在缺失时以非零状态码退出, 可用于CI`,
+	Example: "gbc codes i18n check\ngbc codes i18n check i18n/en.po i18n/ja.json",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files := args
+		if len(files) == 0 {
+			entries, err := os.ReadDir(codesI18nDir)
+			if err != nil {
+				return comm.IOErrorf("读取目录[%s]失败: %w", codesI18nDir, err)
+			}
+			for _, e := range entries {
+				if _, ok := codes.FormatOf(e.Name()); ok && !e.IsDir() {
+					files = append(files, filepath.Join(codesI18nDir, e.Name()))
+				}
+			}
+			if len(files) == 0 {
+				return comm.UsageErrorf("目录[%s]中没有翻译目录, 支持的扩展名有: .json、.po、.csv", codesI18nDir)
+			}
+		}
+		catalogs := make(map[string]map[int64]string, len(files))
+		for _, file := range files {
+			format, ok := codes.FormatOf(file)
+			if !ok {
+				return comm.UsageErrorf("无法根据扩展名判断文件[%s]的格式, 支持的扩展名有: .json、.po、.csv", file)
+			}
+			catalog, err := readCatalogFile(file, format)
+			if err != nil {
+				return err
+			}
+			catalogs[file] = catalog
+		}
+
+		list, err := analyzeCodes(".")
+		if err != nil {
+			return err
+		}
+		known := make(map[int64]bool, len(list))
+		for _, c := range list {
+			known[c.Code] = true
+		}
+		incomplete := 0
+		for _, file := range files {
+			catalog := catalogs[file]
+			missing := 0
+			for _, c := range list {
+				if strings.TrimSpace(catalog[c.Code]) == "" {
+					missing++
+					comm.Log.Errorf("%s: 缺少业务状态码[%d] %s.%s (%s) 的译文", file, c.Code, c.Package, c.Var, c.Message)
+				}
+			}
+			for _, code := range slices.Sorted(maps.Keys(catalog)) {
+				if !known[code] {
+					comm.Log.Warnf("%s: 业务状态码[%d]已不存在, 可以删除", file, code)
+				}
+			}
+			if missing > 0 {
+				incomplete++
+				comm.Log.Warnf("%s: %d/%d 个业务状态码缺少译文", file, missing, len(list))
+			} else {
+				comm.Log.Successf("%s: 全部%d个业务状态码均已翻译", file, len(list))
+			}
+		}
+		if incomplete > 0 {
+			return comm.FailureErrorf("%d个翻译目录缺少译文", incomplete)
+		}
+		return nil
+	},
+}
+
+// analyzeCodes 分析dir目录下的项目中通过 kit.NewCode 声明的业务状态码, 同一状态码只保留一个声明
+func analyzeCodes(dir string) ([]analysis.CodeEntry, error) {
+	analysisInst, err := loadAnalysis(dir)
+	if err != nil {
+		return nil, err
+	}
+	moduleName := analysisInst.MainPackagePath()
+	list := analysis.CodeEntries(analysisInst, analysis.CollectGlobalCodeVars(analysisInst, analysis.KitPkgPath, "NewCode"))
+	if len(list) == 0 {
+		return nil, comm.AnalysisErrorf("项目中未找到通过 kit.NewCode 声明的业务状态码")
+	}
+
+	inModule := func(c analysis.CodeEntry) bool {
+		return c.Package == moduleName || strings.HasPrefix(c.Package, moduleName+"/")
+	}
+	// 同一状态码优先保留当前模块中的声明, 其余声明的提示信息不同时给出警告
+	slices.SortStableFunc(list, func(a, b analysis.CodeEntry) int {
+		if a.Code != b.Code || inModule(a) == inModule(b) {
+			return cmp.Compare(a.Code, b.Code)
+		}
+		if inModule(a) {
+			return -1
+		}
+		return 1
+	})
+	res := make([]analysis.CodeEntry, 0, len(list))
+	for _, c := range list {
+		if n := len(res); n > 0 && res[n-1].Code == c.Code {
+			if kept := res[n-1]; kept.Message != c.Message {
+				comm.Log.Warnf("业务状态码[%d]同时由 %s.%s (%s) 与 %s.%s (%s) 声明, 使用前者", c.Code,
+					kept.Package, kept.Var, kept.Message, c.Package, c.Var, c.Message)
+			}
+			continue
+		}
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+// readCatalogFile 读取翻译目录文件
+func readCatalogFile(file, format string) (map[int64]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, comm.IOErrorf("读取文件[%s]失败: %w", file, err)
+	}
+	defer f.Close()
+	catalog, err := codes.ReadCatalog(f, format)
+	if err != nil {
+		return nil, comm.UsageErrorf("解析翻译目录[%s]失败: %w", file, err)
+	}
+	return catalog, nil
+}
+
+func init() {
+	codesI18nExportCmd.Flags().StringVarP(&codesI18nFormat, "format", "f", codes.FormatJSON, "翻译目录的格式。可选的值有：json、po、csv")
+	codesI18nExportCmd.Flags().StringVarP(&codesI18nLocale, "locale", "l", "", "翻译目录的目标语言, 如 en; 为空时导出源语言目录")
+	codesI18nExportCmd.Flags().StringVarP(&codesI18nOutput, "output", "o", "", "输出文件, 默认输出到标准输出")
+	_ = codesI18nExportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(codes.Formats, cobra.ShellCompDirectiveNoFileComp))
+	_ = codesI18nExportCmd.RegisterFlagCompletionFunc("locale", cobra.NoFileCompletions)
+	codesI18nCheckCmd.Flags().StringVarP(&codesI18nDir, "dir", "", "i18n", "未指定文件时检查的翻译目录所在目录")
+	_ = codesI18nCheckCmd.MarkFlagDirname("dir")
+	for _, c := range []*cobra.Command{codesI18nExportCmd, codesI18nCheckCmd} {
+		c.Flags().StringArrayVarP(&buildTags, "build-tags", "t", nil, "编译时的build tag")
+		addCallGraphFlags(c, c.Flags())
+		_ = c.RegisterFlagCompletionFunc("build-tags", completeBuildTags)
+	}
+
+	codesI18nCmd.AddCommand(codesI18nExportCmd, codesI18nCheckCmd)
+	codesCmd.AddCommand(codesI18nCmd)
+}
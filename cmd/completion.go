@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/refactor"
+)
+
+// 以下补全函数供 gbc completion 生成的 bash/zsh/fish/powershell 脚本在补全时调用
+
+var kindDescriptions = map[string]string{
+	"api":  "API",
+	"cmd":  "命令",
+	"cron": "定时任务",
+}
+
+// keySep 按已输入的内容选择key的路径分隔符
+func keySep(toComplete string) string {
+	if strings.Contains(toComplete, "/") {
+		return "/"
+	}
+	return "."
+}
+
+// filterPrefix 保留以toComplete开头的候选项
+func filterPrefix(candidates []string, toComplete string) []cobra.Completion {
+	comps := make([]cobra.Completion, 0, len(candidates))
+	for _, c := range candidates {
+		if strings.HasPrefix(c, toComplete) {
+			comps = append(comps, c)
+		}
+	}
+	return comps
+}
+
+// completePackageKeys 补全prefix目录下已存在的子包, 用于创建API/Command/Cron时的key, 如 gbc api user.
+func completePackageKeys(prefix string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		keys := comm.PackageKeys(prefix, keySep(toComplete))
+		return filterPrefix(keys, toComplete), cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeArtifactArgs 补全 gbc mv/rm 的参数: 制品类型、已存在的key, 以及 mv 的新key所在的包
+func completeArtifactArgs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		comps := make([]cobra.Completion, 0, len(refactor.KindNames()))
+		for _, name := range refactor.KindNames() {
+			if strings.HasPrefix(name, toComplete) {
+				comps = append(comps, cobra.CompletionWithDesc(name, i18n.T(kindDescriptions[name])))
+			}
+		}
+		return comps, cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		kind, ok := refactor.Kinds[args[0]]
+		if !ok {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		keys := comm.ArtifactKeys(kind.Prefix, keySep(toComplete))
+		return filterPrefix(keys, toComplete), cobra.ShellCompDirectiveNoFileComp
+	case 2:
+		kind, ok := refactor.Kinds[args[0]]
+		if !ok || cmd.Name() != "mv" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		keys := comm.PackageKeys(kind.Prefix, keySep(toComplete))
+		return filterPrefix(keys, toComplete), cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAlgorithm 补全 --algorithm 的可选值
+func completeAlgorithm(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return []cobra.Completion{
+		cobra.CompletionWithDesc(string(analysis.CallGraphTypeStatic), i18n.T("仅使用静态调用边, 速度最快但会遗漏接口与闭包调用")),
+		cobra.CompletionWithDesc(string(analysis.CallGraphTypeCha), i18n.T("类层次分析, 按方法签名解析所有可能的接口调用")),
+		cobra.CompletionWithDesc(string(analysis.CallGraphTypeRta), i18n.T("快速类型分析, 仅考虑程序中实际创建的类型 (默认)")),
+	}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeBuildTags 补全项目中 //go:build 行出现过的build tag
+func completeBuildTags(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return filterPrefix(comm.BuildTags("."), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
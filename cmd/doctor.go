@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/doctor"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+var (
+	doctorFix    bool
+	doctorFormat string
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "检查开发环境与项目的健康状况",
+	Long: `检查开发环境与项目的健康状况
+
+环境检查: git、GitHub SSH访问、Go版本、go install 安装目录是否在PATH中
+项目检查 (在项目目录中执行时): mygo版本、业务状态码注册文件是否存在且与代码一致
+每项检查的结果为 pass、warn 或 fail, 未通过时给出修复建议, 部分检查支持通过 --fix 自动修复
+存在fail时以非零状态码退出`,
+	Example: "gbc doctor\ngbc doctor --fix\ngbc doctor --format json",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if doctorFormat != "text" && doctorFormat != "json" {
+			return comm.UsageErrorf("不支持的输出格式[%s], 可选的值有: text、json", doctorFormat)
+		}
+		if doctorFormat == "json" {
+			// 过程信息输出到标准错误, 保证标准输出为合法的JSON
+			comm.Stdout = os.Stderr
+		}
+
+		results := doctor.Run(&doctor.Env{
+			Dir:         ".",
+			GenerateDir: storeDir,
+			Codegen:     generateCodes,
+		}, doctorFix)
+
+		if doctorFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(results)
+		} else {
+			printDoctorResults(results)
+		}
+		if doctor.Failed(results) {
+			return comm.FailureErrorf("存在未通过的检查")
+		}
+		return nil
+	},
+}
+
+func printDoctorResults(results []doctor.Result) {
+	for _, r := range results {
+		fixed := ""
+		if r.Fixed {
+			fixed = i18n.T(" (已修复)")
+		}
+		switch r.Status {
+		case doctor.StatusPass:
+			comm.Log.Successf("[PASS] %s: %s%s", r.Name, r.Message, fixed)
+		case doctor.StatusSkip:
+			comm.Log.Infof("[SKIP] %s: %s", r.Name, r.Message)
+		case doctor.StatusWarn:
+			comm.Log.Warnf("[WARN] %s: %s", r.Name, r.Message)
+		case doctor.StatusFail:
+			comm.Log.Errorf("[FAIL] %s: %s", r.Name, r.Message)
+		}
+		if r.Hint != "" && r.Status != doctor.StatusPass {
+			hint := r.Hint
+			if r.Fixable {
+				hint += i18n.T(" (支持 --fix)")
+			}
+			comm.Log.Infof("       修复建议: %s", hint)
+		}
+	}
+}
+
+func init() {
+	doctorCmd.Flags().BoolVarP(&doctorFix, "fix", "", false, "自动修复支持修复的问题, 如创建目录、执行codegen")
+	doctorCmd.Flags().StringVarP(&doctorFormat, "format", "f", "text", "输出格式: text、json")
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,15 @@
+// gbc-vet 以 go vet -vettool 的形式执行 gbc lint 的全部检查:
+//
+//	go install github.com/zjutjh/gbc/cmd/gbc-vet@latest
+//	go vet -vettool=$(which gbc-vet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"github.com/zjutjh/gbc/lint"
+)
+
+func main() {
+	unitchecker.Main(lint.Analyzers...)
+}
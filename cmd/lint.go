@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/lint"
+)
+
+var (
+	lintFix    bool
+	lintFormat string
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [packages]",
+	Short: "检查项目代码是否符合gbc模板约定",
+	Long: `检查项目代码是否符合gbc模板约定, 默认检查 ./...
+
+  apiinfo      XxxApi的Info字段须具有非空的name与desc标签
+  coderet      XxxApi.Run须返回已声明的业务状态码变量, 不能返回字面量
+  doublereply  XxxApi.Run自行写入响应后须调用ctx.Abort*再返回
+  cronpanic    XxxJob.Run中不能panic
+  initbind     XxxApi.Init须绑定Request中声明的Uri/Header/Query/Body
+
+部分问题提供修复建议, 可通过 --fix 自动修复
+上述检查同样以 go vet 工具的形式提供: go vet -vettool=$(which gbc-vet) ./...`,
+	Example: "gbc lint\ngbc lint ./api/... --fix\ngbc lint --format json",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if lintFormat != "text" && lintFormat != "json" {
+			return comm.UsageErrorf("不支持的输出格式[%s], 可选的值有: text、json", lintFormat)
+		}
+		if len(args) == 0 {
+			args = []string{"./..."}
+		}
+		findings, err := lint.Run(".", buildTags, args...)
+		if err != nil {
+			return comm.AnalysisErrorf("检查代码失败: %w", err)
+		}
+
+		if lintFix {
+			files, err := lint.ApplyFixes(findings)
+			for _, file := range files {
+				comm.Log.With("path", file).Infof("修复文件[%s]", file)
+			}
+			if err != nil {
+				return comm.IOErrorf("应用修复失败: %w", err)
+			}
+			// 重新检查剩余的问题
+			if len(files) > 0 {
+				if findings, err = lint.Run(".", buildTags, args...); err != nil {
+					return comm.AnalysisErrorf("检查代码失败: %w", err)
+				}
+			}
+		}
+
+		if lintFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(findings)
+		} else {
+			for _, f := range findings {
+				fixable := ""
+				if f.Fixable {
+					fixable = i18n.T(" (支持 --fix)")
+				}
+				comm.Log.With("path", f.Position, "analyzer", f.Analyzer).Warnf("%s: %s [%s]%s", f.Position, f.Message, f.Analyzer, fixable)
+			}
+			if len(findings) == 0 {
+				comm.Log.Successf("未发现问题")
+			}
+		}
+		if len(findings) > 0 {
+			return comm.FailureErrorf("发现%d个问题", len(findings))
+		}
+		return nil
+	},
+}
+
+func init() {
+	lintCmd.Flags().BoolVarP(&lintFix, "fix", "", false, "自动应用修复建议")
+	lintCmd.Flags().StringVarP(&lintFormat, "format", "f", "text", "输出格式: text、json")
+	lintCmd.Flags().StringArrayVarP(&buildTags, "build-tags", "t", nil, "编译时的build tag")
+	_ = lintCmd.RegisterFlagCompletionFunc("build-tags", completeBuildTags)
+	rootCmd.AddCommand(lintCmd)
+}
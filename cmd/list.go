@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/comm"
+)
+
+var listFormat string
+
+var listKinds = []string{"apis", "crons", "cmds", "codes"}
+
+var listCmd = &cobra.Command{
+	Use:       "list [apis|crons|cmds|codes]",
+	Short:     "列出项目中的API、定时任务、命令与业务状态码",
+	Long:      "列出项目中由gbc脚手架生成的API、定时任务、命令与业务状态码",
+	Example:   "gbc list\ngbc list apis --format json",
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: listKinds,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kinds := listKinds
+		if len(args) > 0 {
+			kinds = args
+		}
+		if listFormat != "table" && listFormat != "json" {
+			return comm.UsageErrorf("不支持的输出格式[%s], 可选的值有: table、json", listFormat)
+		}
+
+		inv, err := analysis.LoadInventory(".", buildTags)
+		if err != nil {
+			return comm.AnalysisErrorf("分析代码失败: %w", err)
+		}
+
+		if listFormat == "json" {
+			out := map[string]any{}
+			for _, kind := range kinds {
+				switch kind {
+				case "apis":
+					out[kind] = inv.APIs
+				case "crons":
+					out[kind] = inv.Crons
+				case "cmds":
+					out[kind] = inv.Cmds
+				case "codes":
+					out[kind] = inv.Codes
+				}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(out)
+			return nil
+		}
+
+		for i, kind := range kinds {
+			if i > 0 {
+				fmt.Fprint(os.Stdout, comm.NewLine)
+			}
+			printInventoryTable(os.Stdout, inv, kind)
+		}
+		return nil
+	},
+}
+
+func printInventoryTable(w io.Writer, inv *analysis.Inventory, kind string) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	defer tw.Flush()
+	row := func(cols ...any) {
+		format := strings.Repeat("%v\t", len(cols))
+		fmt.Fprintf(tw, strings.TrimSuffix(format, "\t")+comm.NewLine, cols...)
+	}
+	switch kind {
+	case "apis":
+		row("PACKAGE", "API", "NAME", "DESC", "REQUEST", "POSITION")
+		for _, api := range inv.APIs {
+			row(api.Package, api.Type, api.Name, api.Desc, strings.Join(api.Request, ","), api.Position)
+		}
+	case "crons":
+		row("PACKAGE", "JOB", "REGISTERED", "POSITION")
+		for _, c := range inv.Crons {
+			row(c.Package, c.Type, c.Registered, c.Position)
+		}
+	case "cmds":
+		row("PACKAGE", "FUNC", "POSITION")
+		for _, c := range inv.Cmds {
+			row(c.Package, c.Func, c.Position)
+		}
+	case "codes":
+		row("CODE", "VAR", "MESSAGE", "PACKAGE", "POSITION")
+		for _, c := range inv.Codes {
+			row(c.Code, c.Var, c.Message, c.Package, c.Position)
+		}
+	}
+}
+
+func init() {
+	listCmd.Flags().StringVarP(&listFormat, "format", "f", "table", "输出格式。可选的值有：table、json")
+	listCmd.Flags().StringArrayVarP(&buildTags, "build-tags", "t", nil, "编译时的build tag")
+	_ = listCmd.RegisterFlagCompletionFunc("build-tags", completeBuildTags)
+	rootCmd.AddCommand(listCmd)
+}
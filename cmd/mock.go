@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/mock"
+)
+
+var (
+	mockHost string
+	mockPort int
+)
+
+var mockCmd = &cobra.Command{
+	Use:   "mock",
+	Short: "启动根据API定义生成假数据的本地mock服务",
+	Long: `分析项目中的XxxApi类型与gin路由注册, 为每条路由提供mock响应, 供前端在后端实现前联调
+
+  - 响应数据根据Response结构体的字段名与类型生成, 并以与 reply.Success/reply.Fail 相同的 code/message/data 结构返回
+  - 请求头 X-Mock-Code 或查询参数 _mock_code 可指定返回的业务状态码, 取值为状态码或变量名, 如 20001、CodeUserNotFound
+  - 可指定的业务状态码来自 gbc codegen 生成的注册文件, 未生成时可指定项目中的任意业务状态码
+  - 仅识别以常量路径注册的路由, 分组作为函数参数传递时按空前缀处理`,
+	Example: "gbc mock --port 8080\ncurl -H 'X-Mock-Code: 20001' localhost:8080/user/info/1",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inv, err := analysis.LoadInventory(".", buildTags)
+		if err != nil {
+			return comm.AnalysisErrorf("分析代码失败: %w", err)
+		}
+		routes := inv.Routes()
+		if len(routes) == 0 {
+			return comm.AnalysisErrorf("项目中未找到以常量路径注册的gin路由")
+		}
+		server, errs := mock.NewServer(routes, inv.Codes)
+		for _, err := range errs {
+			comm.Log.Warnf("%s", err)
+		}
+		for _, r := range routes {
+			api := "-"
+			if r.API != nil {
+				api = r.API.Type
+			}
+			comm.Log.Debugf("%-7s %s -> %s (%s)", r.Method, r.Path, api, r.Position)
+		}
+
+		addr := net.JoinHostPort(mockHost, strconv.Itoa(mockPort))
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return comm.EnvErrorf("监听[%s]失败: %w", addr, err)
+		}
+		comm.Log.Successf("mock服务已启动: http://%s, 共%d条路由, 按 Ctrl+C 退出", ln.Addr(), len(routes)-len(errs))
+		if err := http.Serve(ln, server); err != nil {
+			return comm.EnvErrorf("mock服务异常退出: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	mockCmd.Flags().StringVarP(&mockHost, "host", "", "127.0.0.1", "监听的地址")
+	mockCmd.Flags().IntVarP(&mockPort, "port", "p", 8080, "监听的端口")
+	mockCmd.Flags().StringArrayVarP(&buildTags, "build-tags", "t", nil, "编译时的build tag")
+	_ = mockCmd.RegisterFlagCompletionFunc("build-tags", completeBuildTags)
+	rootCmd.AddCommand(mockCmd)
+}
@@ -2,59 +2,132 @@ package cmd
 
 import (
 	"os"
-	"os/exec"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
 
 	"github.com/zjutjh/gbc/comm"
 	"github.com/zjutjh/gbc/config"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/project"
+)
+
+var (
+	templateLocation  string
+	templateRef       string
+	modulePath        string
+	withComponents    []string
+	withoutComponents []string
+	acceptDefaults    bool
+	forceCreate       bool
+	gitInit           bool
+	modTidy           bool
+	firstCodegen      bool
 )
 
 var projectCreateCmd = &cobra.Command{
-	Use:     "new",
-	Short:   "创建新项目模板",
-	Long:    "创建新项目模板",
-	Example: "gbc new {app} [path]",
+	Use:   "new",
+	Short: "创建新项目模板",
+	Long: `创建新项目模板
+
+--template 支持以下模板来源:
+  git仓库地址 (HTTPS/SSH), 可配合 --ref 指定tag/branch/commit
+  本地目录或本地git裸仓库
+  .tar.gz/.tgz/.zip 归档 (本地路径或HTTP地址)
+  embed 使用gbc内置的gbc-template快照, 无需访问模板仓库; 依赖版本由快照中的go.sum固定, 模块本身仍需通过GOPROXY或本地模块缓存获取
+
+--with/--without 与组件询问依赖模板根目录中的 gbc.template.yaml, 没有该文件的模板不支持选择组件
+模板须在其中声明每个可选组件 (如 cmd、cron、mysql、redis、swagger) 独占的文件以及含有其注册片段的文件:
+  components:
+    - name: redis
+      desc: Redis客户端
+      default: true
+      files: [register/redis.go]
+      snippets: [main.go]  # 以 // gbc:begin redis 与 // gbc:end redis 包围的代码
+
+项目在临时目录中创建, 全部步骤成功后才移动到目标目录, 任一步骤失败不会留下残缺的目录
+项目中的 gbc.lock.yaml 记录了所用模板的版本与文件摘要, 供 gbc sync-template 合并后续的模板更新`,
+	Example: "gbc new {app} [path]\ngbc new {app} --module github.com/org/svc\ngbc new {app} --with cron --without cmd\ngbc new {app} --template https://github.com/zjutjh/gbc-template.git --ref v1.0.0\ngbc new {app} --template embed\ngbc new {app} --git-init --tidy --codegen",
 	Args:    cobra.RangeArgs(1, 2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// 设置默认路径
 		path, err := os.Getwd()
 		if err != nil {
-			comm.OutputError("获取当前工作目录失败: %s", err.Error())
-			return
+			return comm.EnvErrorf("获取当前工作目录失败: %w", err)
 		}
 		if len(args) > 1 {
 			path, err = filepath.Abs(args[1])
 			if err != nil {
-				comm.OutputError("获取path[%s]绝对路径失败: %s", args[1], err.Error())
-				return
+				return comm.UsageErrorf("获取path[%s]绝对路径失败: %w", args[1], err)
 			}
 		}
 
 		// 项目创建路径
 		projectPath := filepath.Join(path, args[0])
-		comm.OutputDebug("创建项目[%s]到目录[%s]开始...", args[0], projectPath)
+		comm.Log.Debugf("创建项目[%s]到目录[%s]开始...", args[0], projectPath)
 
-		// 拉取框架模板
-		c := exec.Command("git", "clone", config.GBCGitTemplate, projectPath)
-		if comm.DebugMode {
-			c.Stdout = os.Stdout
-			c.Stderr = os.Stderr
+		// 未指定模板来源时依次尝试 SSH、HTTPS, 未指定ref时均失败后回退到内置快照
+		locations := []string{templateLocation}
+		if !cmd.Flags().Changed("template") {
+			locations = []string{config.GBCGitTemplate, config.GBCGitTemplateHTTPS}
+			if templateRef == "" {
+				locations = append(locations, project.SnapshotSource)
+			}
 		}
-		err = c.Run()
-		if err != nil {
-			comm.OutputError("创建项目[%s]失败: 拉取gbc-template错误: %s", args[0], err.Error())
-			return
+		opts := &project.CreateOptions{
+			AppName:    args[0],
+			Target:     projectPath,
+			Locations:  locations,
+			Ref:        templateRef,
+			ModulePath: modulePath,
+			With:       withComponents,
+			Without:    withoutComponents,
+			Ask:        askComponent,
+			Force:      forceCreate,
+			GitInit:    gitInit,
+			Tidy:       modTidy,
+		}
+		if firstCodegen {
+			opts.Codegen = runCodegen
+		}
+		// project.Create 返回的错误已带有退出码
+		if err := project.Create(opts); err != nil {
+			return err
 		}
 
-		// 删除.git目录
-		os.RemoveAll(filepath.Join(projectPath, ".git"))
-
-		comm.OutputDebug("创建项目[%s]成功", args[0])
+		comm.Log.Debugf("创建项目[%s]成功", args[0])
+		return nil
 	},
 }
 
+// askComponent 询问是否启用模板可选组件
+func askComponent(c project.Component) bool {
+	if acceptDefaults {
+		return c.Default
+	}
+	enabled := c.Default
+	ask := i18n.Sprintf("是否启用组件[%s](%s)? (y(default)|n):", c.Name, c.Desc)
+	defaultAnswer := "y"
+	if !c.Default {
+		ask = i18n.Sprintf("是否启用组件[%s](%s)? (y|n(default)):", c.Name, c.Desc)
+		defaultAnswer = "n"
+	}
+	comm.UI(ask, defaultAnswer, func(b bool) {
+		enabled = b
+	})
+	return enabled
+}
+
 func init() {
+	projectCreateCmd.Flags().StringVarP(&templateLocation, "template", "", config.GBCGitTemplate, "项目模板来源: git仓库地址、本地目录、归档文件或embed")
+	projectCreateCmd.Flags().StringVarP(&templateRef, "ref", "", "", "git模板的tag/branch/commit")
+	projectCreateCmd.Flags().StringSliceVarP(&withComponents, "with", "", nil, "启用的模板可选组件, 如 cmd,cron")
+	projectCreateCmd.Flags().StringSliceVarP(&withoutComponents, "without", "", nil, "移除的模板可选组件, 如 cmd")
+	projectCreateCmd.Flags().BoolVarP(&acceptDefaults, "yes", "y", false, "未通过 --with/--without 指定的组件使用模板默认值, 不再询问")
+	projectCreateCmd.Flags().StringVarP(&modulePath, "module", "m", "", "项目的Go模块路径, 如 github.com/org/svc (默认沿用模板的模块路径)")
+	projectCreateCmd.Flags().BoolVarP(&forceCreate, "force", "f", false, "目标目录已存在且不为空时覆盖 (创建失败时恢复原目录)")
+	projectCreateCmd.Flags().BoolVarP(&gitInit, "git-init", "", false, "创建完成后初始化git仓库并提交初始版本")
+	projectCreateCmd.Flags().BoolVarP(&modTidy, "tidy", "", false, "创建完成后执行 go mod tidy")
+	projectCreateCmd.Flags().BoolVarP(&firstCodegen, "codegen", "", false, "创建完成后执行一次 gbc codegen")
 	rootCmd.AddCommand(projectCreateCmd)
 }
@@ -1,31 +1,142 @@
 package cmd
 
 import (
+	"errors"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/release"
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "gbc",
 	Short: "精弘网络本地开发者工具",
-	Long:  "精弘网络本地开发者工具",
-	Run: func(cmd *cobra.Command, args []string) {
-		comm.OutputInfo("当前gbc工具本地版本号: %s", cmd.Version)
-		_ = cmd.Help()
+	Long: `精弘网络本地开发者工具
+
+退出码:
+  0  执行成功
+  1  执行失败, 如检查未通过、存在合并冲突
+  2  用法错误, 如参数或选项不合法
+  3  环境错误, 如不在项目目录中、缺少git或go、网络不可用
+  4  代码分析错误, 如软件包加载或分析失败
+  5  文件读写错误`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		comm.Log.Infof("当前gbc工具本地版本号: %s", cmd.Version)
+		return cmd.Help()
 	},
-	Version: "v1.3.1",
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		comm.OutputError("执行发生错误: %s", err.Error())
-		os.Exit(1)
+	// 帮助信息在解析参数之前生成, 因此预先从命令行中读取 --lang
+	if l, ok := i18n.Parse(langFromArgs(os.Args[1:])); ok {
+		i18n.SetLocale(l)
+	}
+	localizeCommand(rootCmd)
+	markFailures(rootCmd)
+	cmd, err := rootCmd.ExecuteC()
+	if err == nil {
+		return
+	}
+	// 命令返回的错误均已带有退出码, 其余为cobra解析参数与选项时产生的用法错误
+	var exitErr *comm.ExitError
+	if !errors.As(err, &exitErr) {
+		err = &comm.ExitError{Code: comm.ExitUsage, Err: err}
+	}
+	comm.Log.Errorf("执行发生错误: %s", err.Error())
+	if comm.ExitCode(err) == comm.ExitUsage {
+		comm.Log.Infof("执行 %s --help 查看用法", cmd.CommandPath())
+	}
+	os.Exit(comm.ExitCode(err))
+}
+
+// markFailures 为命令返回的未指定退出码的错误设置 comm.ExitFailure, 以便与cobra的用法错误区分
+func markFailures(cmd *cobra.Command) {
+	for _, c := range cmd.Commands() {
+		markFailures(c)
+	}
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			if err := run(cmd, args); err != nil {
+				return &comm.ExitError{Code: comm.ExitCode(err), Err: err}
+			}
+			return nil
+		}
+	}
+}
+
+// langFromArgs 读取命令行中 --lang 的值
+func langFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if v, ok := strings.CutPrefix(arg, "--lang="); ok {
+			return v
+		}
+		if arg == "--lang" && i+1 < len(args) {
+			return args[i+1]
+		}
 	}
+	return ""
+}
+
+// localizeCommand 将命令及其子命令的帮助信息与选项说明翻译为当前语言
+func localizeCommand(cmd *cobra.Command) {
+	cmd.Short = i18n.T(cmd.Short)
+	cmd.Long = i18n.T(cmd.Long)
+	translate := func(f *pflag.Flag) {
+		f.Usage = i18n.T(f.Usage)
+	}
+	cmd.Flags().VisitAll(translate)
+	cmd.PersistentFlags().VisitAll(translate)
+	for _, c := range cmd.Commands() {
+		localizeCommand(c)
+	}
+}
+
+var (
+	logOptions comm.LogOptions
+	lang       string
+)
+
+// setupOutput 根据全局参数配置输出, -vv 或 --debug 时额外展示子进程输出等调试信息
+func setupOutput(cmd *cobra.Command, args []string) error {
+	if _, ok := i18n.Parse(lang); lang != "" && !ok {
+		return comm.UsageErrorf("不支持的语言[%s], 可选的值有: zh-CN、en", lang)
+	}
+	if comm.DebugMode {
+		logOptions.Verbose = max(logOptions.Verbose, 2)
+	}
+	comm.DebugMode = logOptions.Verbose >= 2
+	return comm.SetupLog(logOptions)
 }
 
 func init() {
-	rootCmd.PersistentFlags().BoolVarP(&comm.DebugMode, "debug", "d", false, "展示更多过程信息进行调试")
+	rootCmd.Version = release.Current().Version
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := setupOutput(cmd, args); err != nil {
+			return err
+		}
+		startUpdateCheck(cmd, args)
+		return nil
+	}
+	rootCmd.PersistentPostRun = notifyUpdate
+	// 自行声明不带简写的 --version, 避免cobra默认的 -v 与 --verbose 冲突
+	rootCmd.Flags().Bool("version", false, "展示gbc的版本号")
+	rootCmd.PersistentFlags().BoolVarP(&comm.DebugMode, "debug", "d", false, "展示更多过程信息进行调试, 等同于 -vv")
+	rootCmd.PersistentFlags().CountVarP(&logOptions.Verbose, "verbose", "v", "展示调试信息, -vv 额外展示子进程输出等更多过程信息")
+	rootCmd.PersistentFlags().BoolVarP(&logOptions.Quiet, "quiet", "q", false, "仅输出警告与错误")
+	rootCmd.PersistentFlags().BoolVarP(&logOptions.NoColor, "no-color", "", false, "禁用颜色, 也可设置环境变量 NO_COLOR")
+	rootCmd.PersistentFlags().StringVarP(&logOptions.Format, "log-format", "", "text", "过程信息的输出格式: text、json (每行一个事件, 输出到标准错误)")
+	rootCmd.PersistentFlags().StringVarP(&lang, "lang", "", "", "界面语言: zh-CN、en (默认根据环境变量 LC_ALL、LC_MESSAGES、LANG 选择)")
+	rootCmd.PersistentFlags().StringVarP(&logOptions.File, "log-file", "", "", "将包括调试信息在内的全部过程信息追加写入该文件")
+	_ = rootCmd.RegisterFlagCompletionFunc("log-format", cobra.FixedCompletions([]cobra.Completion{"text", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = rootCmd.RegisterFlagCompletionFunc("lang", cobra.FixedCompletions([]cobra.Completion{string(i18n.ZhCN), string(i18n.En)}, cobra.ShellCompDirectiveNoFileComp))
 }
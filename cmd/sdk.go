@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/sdk"
+)
+
+var (
+	sdkOut    string
+	sdkClient string
+)
+
+var sdkCmd = &cobra.Command{
+	Use:   "sdk",
+	Short: "根据API定义生成前端SDK",
+	Args:  cobra.NoArgs,
+}
+
+var sdkTSCmd = &cobra.Command{
+	Use:   "ts",
+	Short: "生成TypeScript SDK",
+	Long: `根据项目中的XxxApi类型与gin路由注册生成TypeScript SDK, 供前端直接调用
+
+  - types.ts: 每个API的请求参数 (Uri/Header/Query/Body) 与响应数据的类型
+  - api.ts: 每条路由的请求函数, 路径参数取自请求参数的Uri部分
+  - codes.ts: BusinessCode 枚举与提示信息, 以及每个接口可能返回的业务状态码联合类型, 与 gbc codegen 使用相同的分析
+  - client.ts: 基于 fetch 或 axios 的请求封装
+  - index.ts: 统一导出以上内容
+
+输出目录中的同名文件会被覆盖`,
+	Example: "gbc sdk ts --out ./sdk\ngbc sdk ts --out ../web/src/sdk --client axios",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !slices.Contains(sdk.Clients, sdkClient) {
+			return comm.UsageErrorf("不支持的请求库[%s], 可选的值有: %s", sdkClient, strings.Join(sdk.Clients, ", "))
+		}
+		inv, err := analysis.LoadInventory(".", buildTags)
+		if err != nil {
+			return comm.AnalysisErrorf("分析代码失败: %w", err)
+		}
+		routes := inv.Routes()
+		if len(routes) == 0 {
+			return comm.AnalysisErrorf("项目中未找到以常量路径注册的gin路由")
+		}
+		_, infos, err := analyzeHandlerCodes(".")
+		if err != nil {
+			return err
+		}
+		attachHandlerCodes(routes, inv.Codes, infos)
+
+		files, err := sdk.TypeScript(routes, inv.Codes, sdkClient)
+		if err != nil {
+			return comm.FailureErrorf("生成TypeScript SDK失败: %w", err)
+		}
+		if err := comm.EnsureDir(sdkOut); err != nil {
+			return comm.IOErrorf("生成TypeScript SDK失败: %w", err)
+		}
+		for _, name := range slices.Sorted(maps.Keys(files)) {
+			file := filepath.Join(sdkOut, name)
+			if err := os.WriteFile(file, files[name], 0644); err != nil {
+				return comm.IOErrorf("写入文件[%s]失败: %w", file, err)
+			}
+			comm.Log.Debugf("%s", file)
+		}
+		comm.Log.Successf("已生成TypeScript SDK到[%s], 共%d条路由", sdkOut, len(routes))
+		return nil
+	},
+}
+
+// attachHandlerCodes 以调用图分析的结果作为路由可能返回的业务状态码, 不依赖可能已过期的注册文件
+func attachHandlerCodes(routes []analysis.Route, codes []analysis.CodeEntry, infos map[string][]*analysis.GinHandlerInfo) {
+	handlers := make(map[string][]string)
+	for _, list := range infos {
+		for _, info := range list {
+			handlers[info.HandlerName] = info.StatusCodes
+		}
+	}
+	for i := range routes {
+		if routes[i].API == nil {
+			continue
+		}
+		names, ok := handlers[routes[i].API.HandlerFunc()]
+		if !ok {
+			continue
+		}
+		routes[i].Codes = make([]analysis.CodeEntry, 0, len(names))
+		for _, name := range names {
+			if j := slices.IndexFunc(codes, func(c analysis.CodeEntry) bool { return c.Var == name }); j >= 0 {
+				routes[i].Codes = append(routes[i].Codes, codes[j])
+			}
+		}
+	}
+}
+
+func init() {
+	sdkTSCmd.Flags().StringVarP(&sdkOut, "out", "o", "sdk", "生成文件的输出目录")
+	sdkTSCmd.Flags().StringVarP(&sdkClient, "client", "c", sdk.ClientFetch, "生成的请求函数使用的请求库, 可选的值有: fetch、axios")
+	sdkTSCmd.Flags().StringArrayVarP(&buildTags, "build-tags", "t", nil, "编译时的build tag")
+	addCallGraphFlags(sdkTSCmd, sdkTSCmd.Flags())
+	_ = sdkTSCmd.RegisterFlagCompletionFunc("client", cobra.FixedCompletions(sdk.Clients, cobra.ShellCompDirectiveNoFileComp))
+	_ = sdkTSCmd.RegisterFlagCompletionFunc("build-tags", completeBuildTags)
+	_ = sdkTSCmd.MarkFlagDirname("out")
+
+	sdkCmd.AddCommand(sdkTSCmd)
+	rootCmd.AddCommand(sdkCmd)
+}
@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"errors"
 	"os"
+	"path"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/zjutjh/gbc/analysis"
 	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/release"
 	"github.com/zjutjh/gbc/template"
+	"github.com/zjutjh/gbc/wizard"
 )
 
 var Body bool
@@ -15,14 +20,34 @@ var Query bool
 var Header bool
 var Uri bool
 
+var apiInteractive bool
+
 var apiCreateCmd = &cobra.Command{
-	Use:   "api",
+	Use:   "api <name>",
 	Short: "创建API模版",
-	Long:  `创建API模版`,
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		// 初始化模板
-		apiTemplate := template.APITemplate
+	Long: `创建API模版
+
+使用 -i 启动交互式向导: 选择API所在的包并输入名称, 选择HTTP请求方法与路由路径,
+逐个填写请求参数与响应数据的字段, 选择Run中可能返回的业务状态码, 预览生成的文件后确认写入
+在终端中每一步开始前清屏, 选项列表通过方向键移动、空格勾选、回车确认
+标准输入不是终端时向导逐行读取, 选项以带序号的列表列出, 输入序号或选项文本进行选择, 因此也可以通过管道提供预先编写的输入`,
+	Example:           "gbc api user.login --body\ngbc api -i\ngbc api user.login -i",
+	Args:              apiArgs,
+	ValidArgsFunction: completePackageKeys("./api/"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		release.WarnIncompatibleMygo(".", "api")
+
+		modulePath, err := comm.ModulePath(".")
+		if err != nil {
+			return comm.EnvErrorf("创建API错误: %w", err)
+		}
+		if apiInteractive {
+			key := ""
+			if len(args) > 0 {
+				key = args[0]
+			}
+			return createAPIInteractive(key, modulePath)
+		}
 
 		if !Body {
 			comm.UI("接口是否存在body参数? (y|n(default)):", "n", func(b bool) {
@@ -45,61 +70,87 @@ var apiCreateCmd = &cobra.Command{
 			})
 		}
 
-		if Body {
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestBody}", "\n\tBody struct {}", 1)
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestBodyInit}", "\n\terr = ctx.ShouldBindJSON(&{$Receiver}.Request.Body)\n\tif err != nil {\n\t\treturn err\n\t}", 1)
-		} else {
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestBody}", "", 1)
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestBodyInit}", "", 1)
-		}
-		if Query {
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestQuery}", "\n\tQuery struct {}", 1)
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestQueryInit}", "\n\terr = ctx.ShouldBindQuery(&{$Receiver}.Request.Query)\n\tif err != nil {\n\t\treturn err\n\t}", 1)
-		} else {
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestQuery}", "", 1)
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestQueryInit}", "", 1)
+		path, apiName, packageName, err := comm.ParseKey(args[0], "api", "./api/", ".go")
+		if err != nil {
+			return comm.UsageErrorf("创建API错误: %w", err)
 		}
-		if Header {
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestHeader}", "\n\tHeader struct {}", 1)
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestHeaderInit}", "\n\terr = ctx.ShouldBindHeader(&{$Receiver}.Request.Header)\n\tif err != nil {\n\t\treturn err\n\t}", 1)
-		} else {
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestHeader}", "", 1)
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestHeaderInit}", "", 1)
+		spec := template.APISpec{
+			PackageName: packageName,
+			Struct:      apiName,
+			ModulePath:  modulePath,
+			Request:     make(map[string][]template.APIField),
 		}
-		if Uri {
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestUri}", "\n\tUri struct {}", 1)
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestUriInit}", "\n\terr = ctx.ShouldBindUri(&{$Receiver}.Request.Uri)\n\tif err != nil {\n\t\treturn err\n\t}", 1)
-		} else {
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestUri}", "", 1)
-			apiTemplate = strings.Replace(apiTemplate, "{$RequestUriInit}", "", 1)
+		for part, enabled := range map[string]bool{"Uri": Uri, "Header": Header, "Query": Query, "Body": Body} {
+			if enabled {
+				spec.Request[part] = nil
+			}
 		}
-		path, apiName, packageName, err := comm.ParseKey(args[0], "api", "./api/", ".go")
+		src, err := template.RenderAPI(spec)
 		if err != nil {
-			comm.OutputError("创建API错误: %s", err.Error())
-			return
+			return comm.FailureErrorf("创建API错误: %w", err)
 		}
-		receiverName := strings.ToLower(string(apiName[0]))
-
-		// 替换api模板
-		apiApiContent := strings.ReplaceAll(apiTemplate, "{$PackageName}", packageName)
-		apiApiContent = strings.ReplaceAll(apiApiContent, "{$ApiStruct}", apiName)
-		apiApiContent = strings.ReplaceAll(apiApiContent, "{$Receiver}", receiverName)
-		apiApiContent = strings.ReplaceAll(apiApiContent, "{$ApiInfo}", "Info     struct{}        `name:\"API名称\" desc:\"API描述\"`")
 
 		// 创建api文件
-		err = os.WriteFile(path, []byte(apiApiContent), 0644)
+		err = os.WriteFile(path, src, 0644)
 		if err != nil {
-			comm.OutputError("创建API错误: %s", err.Error())
-		} else {
-			comm.OutputLook("创建API[%s]成功, 请记得前往./router/router.go中进行必要的API注册", path)
+			return comm.IOErrorf("创建API错误: %w", err)
 		}
+		comm.Log.With("key", args[0], "package", packageName, "path", path).Successf("创建API[%s]成功, 请记得前往./router/router.go中进行必要的API注册", path)
+		return nil
 	},
 }
 
+// apiArgs 交互模式下key可以省略
+func apiArgs(cmd *cobra.Command, args []string) error {
+	if apiInteractive {
+		return cobra.MaximumNArgs(1)(cmd, args)
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// createAPIInteractive 通过向导创建API
+func createAPIInteractive(key, modulePath string) error {
+	opts := wizard.APIOptions{Key: key, ModulePath: modulePath}
+	for _, p := range comm.PackageKeys("./api/", ".") {
+		opts.Packages = append(opts.Packages, strings.TrimSuffix(p, "."))
+	}
+	if inv, err := analysis.LoadInventory(".", nil); err != nil {
+		comm.Log.Warnf("读取项目中的业务状态码失败, 将跳过业务状态码的选择: %s", err)
+	} else {
+		for _, c := range inv.Codes {
+			opts.Codes = append(opts.Codes, template.APICode{Ref: path.Base(c.Package) + "." + c.Var, Code: c.Code, Message: c.Message})
+		}
+	}
+
+	steps := 6
+	if key != "" {
+		steps--
+	}
+	res, err := wizard.API(wizard.New(os.Stdin, os.Stdout, steps), opts)
+	if errors.Is(err, wizard.ErrAborted) {
+		return comm.FailureErrorf("%w", err)
+	}
+	if err != nil {
+		return comm.UsageErrorf("创建API错误: %w", err)
+	}
+
+	file, _, _, err := comm.ParseKey(res.Key, "api", "./api/", ".go")
+	if err != nil {
+		return comm.UsageErrorf("创建API错误: %w", err)
+	}
+	if err := os.WriteFile(file, res.Source, 0644); err != nil {
+		return comm.IOErrorf("创建API错误: %w", err)
+	}
+	comm.Log.With("key", res.Key, "package", res.Spec.PackageName, "path", file).Successf("创建API[%s]成功, 请记得前往./router/router.go中进行必要的API注册", file)
+	comm.Log.Infof("参考注册代码: r.%s(%q, %s.%sHandler())", res.Method, res.Path, res.Spec.PackageName, res.Spec.Struct)
+	return nil
+}
+
 func init() {
 	apiCreateCmd.Flags().BoolVarP(&Body, "body", "", false, "With Request Body")
 	apiCreateCmd.Flags().BoolVarP(&Query, "query", "", false, "With Request Query")
 	apiCreateCmd.Flags().BoolVarP(&Header, "header", "", false, "With Request Header")
 	apiCreateCmd.Flags().BoolVarP(&Uri, "uri", "", false, "With Request Uri")
+	apiCreateCmd.Flags().BoolVarP(&apiInteractive, "interactive", "i", false, "使用交互式向导创建API")
 	rootCmd.AddCommand(apiCreateCmd)
 }
@@ -7,21 +7,25 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/release"
 	"github.com/zjutjh/gbc/template"
 )
 
 var createCMDCmd = &cobra.Command{
-	Use:   "cmd",
-	Short: "创建Command模板",
-	Long:  "创建Command模板",
-	Run: func(cmd *cobra.Command, args []string) {
+	Use:               "cmd <name>",
+	Short:             "创建Command模板",
+	Long:              "创建Command模板",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completePackageKeys("./cmd/"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		release.WarnIncompatibleMygo(".", "cmd")
+
 		// 初始化模板
-		cmdTemplate := template.CMDTemplate
+		cmdTemplate := template.Localize(template.CMDTemplate)
 
 		path, cmdName, packageName, err := comm.ParseKey(args[0], "cmd", "./cmd/", ".go")
 		if err != nil {
-			comm.OutputError("创建command错误: %s", err.Error())
-			return
+			return comm.UsageErrorf("创建command错误: %w", err)
 		}
 
 		// 替换cmd模板
@@ -31,11 +35,10 @@ var createCMDCmd = &cobra.Command{
 		// 创建cmd文件
 		err = os.WriteFile(path, []byte(cmdTemplate), 0644)
 		if err != nil {
-			comm.OutputError("创建command错误: %s", err.Error())
-			return
-		} else {
-			comm.OutputLook("创建command[%s]成功, 请记得前往./register/cmd.go中进行必要的命令注册", path)
+			return comm.IOErrorf("创建command错误: %w", err)
 		}
+		comm.Log.With("key", args[0], "package", packageName, "path", path).Successf("创建command[%s]成功, 请记得前往./register/cmd.go中进行必要的命令注册", path)
+		return nil
 	},
 }
 
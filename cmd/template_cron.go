@@ -7,21 +7,25 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/release"
 	"github.com/zjutjh/gbc/template"
 )
 
 var createCronCmd = &cobra.Command{
-	Use:   "cron",
-	Short: "创建Cron模版",
-	Long:  `创建Cron模版`,
-	Run: func(cmd *cobra.Command, args []string) {
+	Use:               "cron <name>",
+	Short:             "创建Cron模版",
+	Long:              `创建Cron模版`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completePackageKeys("./cron/"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		release.WarnIncompatibleMygo(".", "cron")
+
 		// 初始化模板
-		cronTemplate := template.CronTemplate
+		cronTemplate := template.Localize(template.CronTemplate)
 
 		path, cronName, packageName, err := comm.ParseKey(args[0], "cron", "./cron/", ".go")
 		if err != nil {
-			comm.OutputError("创建cron错误: %s", err.Error())
-			return
+			return comm.UsageErrorf("创建cron错误: %w", err)
 		}
 
 		// 替换cron模板
@@ -31,11 +35,10 @@ var createCronCmd = &cobra.Command{
 		// 创建cron文件
 		err = os.WriteFile(path, []byte(cronTemplate), 0644)
 		if err != nil {
-			comm.OutputError("创建cron错误: %s", err.Error())
-			return
-		} else {
-			comm.OutputLook("创建cron[%s]成功, 请记得前往./register/cron.go中进行必要的定时任务注册", path)
+			return comm.IOErrorf("创建cron错误: %w", err)
 		}
+		comm.Log.With("key", args[0], "package", packageName, "path", path).Successf("创建cron[%s]成功, 请记得前往./register/cron.go中进行必要的定时任务注册", path)
+		return nil
 	},
 }
 
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/project"
+)
+
+var (
+	syncTemplateLocation string
+	syncTemplateRef      string
+	syncDryRun           bool
+)
+
+var templateSyncCmd = &cobra.Command{
+	Use:   "sync-template",
+	Short: "将新版本项目模板的改动合并到当前项目",
+	Long: `将新版本项目模板的改动合并到当前项目
+
+以 ` + project.LockFile + ` 中记录的模板版本为基准, 对每个文件在 原模板、当前项目、新模板 之间进行三方合并:
+  项目中未修改的文件直接更新为新模板
+  无冲突的合并直接写入
+  存在冲突的文件写入冲突标记, 无法按文本合并的文件将新模板内容写入 .rej 文件
+同步完成后更新锁文件`,
+	Example: "gbc sync-template\ngbc sync-template --ref v1.2.0 --dry-run",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changes, err := project.Sync(&project.SyncOptions{
+			Dir:      ".",
+			Location: syncTemplateLocation,
+			Ref:      syncTemplateRef,
+			DryRun:   syncDryRun,
+		})
+		if err != nil {
+			return i18n.Errorf("同步模板失败: %w", err)
+		}
+
+		conflicts := 0
+		for _, change := range changes {
+			log := comm.Log.With("path", change.Path, "action", string(change.Action))
+			switch change.Action {
+			case project.SyncConflict, project.SyncReject, project.SyncKeep:
+				log.Warnf("%s %s", change.Action.Label(), change.Path)
+			default:
+				log.Infof("%s %s", change.Action.Label(), change.Path)
+			}
+			if change.Action == project.SyncConflict || change.Action == project.SyncReject {
+				conflicts++
+			}
+		}
+		if len(changes) == 0 {
+			comm.Log.Successf("项目已与模板保持一致")
+		}
+		if syncDryRun {
+			comm.Log.Successf("dry-run模式, 未写入任何修改")
+			return nil
+		}
+		if conflicts > 0 {
+			return comm.FailureErrorf("存在%d个文件需要手动处理冲突标记或 .rej 文件", conflicts)
+		}
+		return nil
+	},
+}
+
+func init() {
+	templateSyncCmd.Flags().StringVarP(&syncTemplateLocation, "template", "", "", "新模板来源 (默认使用锁文件中记录的来源)")
+	templateSyncCmd.Flags().StringVarP(&syncTemplateRef, "ref", "", "", "新模板的git tag/branch/commit (默认使用最新版本)")
+	templateSyncCmd.Flags().BoolVarP(&syncDryRun, "dry-run", "n", false, "仅展示将要进行的修改, 不写入文件")
+	rootCmd.AddCommand(templateSyncCmd)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/comm"
+)
+
+var (
+	todoFail   bool
+	todoFormat string
+)
+
+var todoCmd = &cobra.Command{
+	Use:   "todo",
+	Short: "列出尚未实现的API、定时任务与命令",
+	Long: `列出仍保留gbc模板默认内容的API、定时任务与命令
+
+  - Run方法或命令函数的函数体与模板在语法树上一致 (忽略注释与格式)
+  - API的Info标签仍为模板默认的name/desc
+
+使用 --fail 时存在未实现的制品则以非0状态码退出, 可用于CI`,
+	Example: "gbc todo\ngbc todo --fail\ngbc todo --format json",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if todoFormat != "table" && todoFormat != "json" {
+			return comm.UsageErrorf("不支持的输出格式[%s], 可选的值有: table、json", todoFormat)
+		}
+
+		inv, err := analysis.LoadInventory(".", buildTags)
+		if err != nil {
+			return comm.AnalysisErrorf("分析代码失败: %w", err)
+		}
+		todos, err := inv.Todos()
+		if err != nil {
+			return comm.AnalysisErrorf("分析代码失败: %w", err)
+		}
+
+		if todoFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(todos)
+		} else if len(todos) == 0 {
+			comm.Log.Successf("所有制品均已实现")
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintf(tw, "KIND\tNAME\tREASON\tPOSITION%s", comm.NewLine)
+			for _, t := range todos {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s%s", t.Kind, t.Name, t.Reason, t.Position, comm.NewLine)
+			}
+			_ = tw.Flush()
+		}
+		if todoFail && len(todos) > 0 {
+			return comm.FailureErrorf("存在%d处尚未实现的模板内容", len(todos))
+		}
+		return nil
+	},
+}
+
+func init() {
+	todoCmd.Flags().BoolVarP(&todoFail, "fail", "", false, "存在尚未实现的制品时以非0状态码退出")
+	todoCmd.Flags().StringVarP(&todoFormat, "format", "f", "table", "输出格式。可选的值有：table、json")
+	todoCmd.Flags().StringArrayVarP(&buildTags, "build-tags", "t", nil, "编译时的build tag")
+	_ = todoCmd.RegisterFlagCompletionFunc("build-tags", completeBuildTags)
+	rootCmd.AddCommand(todoCmd)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/config"
+	"github.com/zjutjh/gbc/release"
+)
+
+// updateCheckTimeout 命令结束后等待后台版本检查的最长时间
+const updateCheckTimeout = 2 * time.Second
+
+var updateCheck *release.UpdateCheck
+
+// startUpdateCheck 在后台检查gbc新版本, 每天最多联网检查一次
+// 设置环境变量 GBC_NO_UPDATE_CHECK=1 关闭, 在CI中或输出不是终端时自动关闭
+func startUpdateCheck(cmd *cobra.Command, args []string) {
+	if cmd == upgradeCmd || logOptions.Quiet || !updateCheckEnabled() {
+		return
+	}
+	updateCheck = release.StartUpdateCheck(rootCmd.Version, func() release.Lister {
+		return release.NewProxyLister(config.GBCModulePath, release.GoEnv("GOPROXY", "GONOPROXY", "GOPRIVATE"))
+	})
+}
+
+// notifyUpdate 命令结束后存在新版本时输出一行提示
+func notifyUpdate(cmd *cobra.Command, args []string) {
+	if latest := updateCheck.Newer(updateCheckTimeout); latest != "" {
+		comm.Log.Warnf("发现gbc新版本[%s], 当前版本[%s], 执行 gbc upgrade 升级", latest, rootCmd.Version)
+	}
+}
+
+func updateCheckEnabled() bool {
+	if disabled, _ := strconv.ParseBool(os.Getenv("GBC_NO_UPDATE_CHECK")); disabled {
+		return false
+	}
+	if ci, _ := strconv.ParseBool(os.Getenv("CI")); ci {
+		return false
+	}
+	return comm.IsTerminal(os.Stdout) && comm.IsTerminal(os.Stderr)
+}
@@ -1,73 +1,141 @@
 package cmd
 
 import (
-	"net/http"
+	"context"
 	"os"
 	"os/exec"
+	"strings"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/go-version"
 	"github.com/spf13/cobra"
 
 	"github.com/zjutjh/gbc/comm"
 	"github.com/zjutjh/gbc/config"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/release"
+)
+
+var (
+	upgradeTo         string
+	upgradePrerelease bool
+	upgradeDryRun     bool
+	upgradeGithub     bool
 )
 
 var upgradeCmd = &cobra.Command{
 	Use:   "upgrade",
 	Short: "检查并升级gbc自身版本",
-	Long:  `检查并升级gbc自身版本`,
-	Run: func(cmd *cobra.Command, args []string) {
-		// 获取当前最新版本
-		latestVersion := "v0.0.0"
-		latestVersionC, _ := version.NewVersion(latestVersion)
-		type Tag struct {
-			Name string `json:"name"`
+	Long: `检查并升级gbc自身版本
+
+默认升级到最新的正式版本, 可通过 --to 安装指定版本 (包括回退到旧版本), --prerelease 将 -rc 等预发布版本纳入候选
+版本列表默认通过Go模块代理协议获取, 遵循 GOPROXY、GONOPROXY、GOPRIVATE 配置
+升级前展示当前版本与目标版本之间的发布说明或提交记录, 默认尽力从GitHub获取, 无法访问GitHub时跳过
+使用 --github 改为通过GitHub API获取版本列表, 此时变更记录获取失败会给出警告
+
+执行其他命令时gbc每天最多在后台检查一次新版本, 并在命令结束后提示
+设置环境变量 GBC_NO_UPDATE_CHECK=1 可关闭该检查, 在CI (CI=true) 中或输出不是终端时自动关闭`,
+	Example: "gbc upgrade\ngbc upgrade --to v1.2.0\ngbc upgrade --prerelease --dry-run",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		api := config.GithubRepoAPI
+		if v := os.Getenv("GBC_GITHUB_API"); v != "" {
+			api = v
+		}
+		client := release.NewClient(api)
+		var lister release.Lister = release.NewProxyLister(config.GBCModulePath, release.GoEnv("GOPROXY", "GONOPROXY", "GOPRIVATE"))
+		if upgradeGithub {
+			lister = client
 		}
-		result := make([]Tag, 0)
-		resp, err := resty.New().R().
-			ForceContentType("application/json").
-			SetResult(&result).
-			Get(config.GithubGetTagsAPI)
+
+		tags, err := lister.Tags(cmd.Context())
 		if err != nil {
-			comm.OutputError("读取远程gbc版本错误: %s", err.Error())
+			return comm.EnvErrorf("读取远程gbc版本错误: %w", err)
 		}
-		if resp.StatusCode() != http.StatusOK {
-			comm.OutputError("读取远程gbc版本错误: Status Code[%d|%s]", resp.StatusCode(), resp.Status())
+		target, err := release.Pick(tags, upgradeTo, upgradePrerelease)
+		if err != nil && upgradeTo != "" {
+			return comm.UsageErrorf("选择gbc版本错误: %w", err)
 		}
-		for _, tag := range result {
-			ver, err := version.NewVersion(tag.Name)
-			if err != nil {
-				continue
-			}
-			if ver.GreaterThan(latestVersionC) {
-				latestVersion = tag.Name
-				latestVersionC = ver
-			}
+		if err != nil {
+			return i18n.Errorf("选择gbc版本错误: %w", err)
+		}
+
+		current, err := version.NewVersion(rootCmd.Version)
+		if err != nil {
+			// 未包含版本信息的本地构建, 视为最低版本
+			comm.Log.Warnf("无法识别本地gbc版本[%s], 按 v0.0.0 处理", rootCmd.Version)
+			current = version.Must(version.NewVersion("v0.0.0"))
+		}
+		switch {
+		case target.Version.Equal(current):
+			comm.Log.Successf("当前gbc工具版本[%s]已是目标版本", rootCmd.Version)
+			return nil
+		case upgradeTo == "" && current.GreaterThan(target.Version):
+			comm.Log.Successf("当前gbc工具版本[%s]为最新版本", rootCmd.Version)
+			return nil
 		}
 
-		myVersionC, _ := version.NewVersion(rootCmd.Version)
-		if myVersionC.GreaterThanOrEqual(latestVersionC) {
-			comm.OutputLook("当前gbc工具版本[%s]为最新版本", rootCmd.Version)
-			return
+		action := "升级"
+		if current.GreaterThan(target.Version) {
+			action = "回退"
 		}
+		comm.Log.Successf("gbc工具将从版本[%s]%s到版本[%s]", rootCmd.Version, i18n.T(action), target.Name)
+		printChangelog(cmd.Context(), client, current, target.Version, action, upgradeGithub)
 
-		// 需要版本升级
-		comm.OutputLook("发现gbc工具新版本[%s], 当前本地版本[%s], 开始版本升级...", latestVersion, rootCmd.Version)
-		c := exec.Command("go", "install", config.GBCForGoInstall)
+		install := config.GBCModulePath + "@" + target.Name
+		if upgradeDryRun {
+			comm.Log.Successf("dry-run模式, 将执行: go install %s", install)
+			return nil
+		}
+		c := exec.Command("go", "install", install)
 		if comm.DebugMode {
 			c.Stdout = os.Stdout
 			c.Stderr = os.Stderr
 		}
-		err = c.Run()
-		if err != nil {
-			comm.OutputError("升级gbc工具版本失败: %s", err.Error())
-			return
+		if err := c.Run(); err != nil {
+			return comm.EnvErrorf("%sgbc工具版本失败: %w", i18n.T(action), err)
 		}
-		comm.OutputLook("版本升级完成")
+		comm.Log.Successf("版本%s完成", i18n.T(action))
+		return nil
 	},
 }
 
+// printChangelog 展示版本之间的变更, 获取失败不影响升级
+// 未使用 --github 时GitHub仅作为变更记录的可选来源, 获取失败只输出调试信息
+func printChangelog(ctx context.Context, client *release.Client, from, to *version.Version, action string, required bool) {
+	log, err := client.Changelog(ctx, from, to)
+	if err != nil && required {
+		comm.Log.Warnf("获取版本变更记录失败: %s", err.Error())
+		return
+	}
+	if err != nil {
+		comm.Log.Debugf("无法从GitHub获取版本变更记录, 已跳过: %s", err.Error())
+		return
+	}
+	title := "变更记录:"
+	if action == "回退" {
+		title = "回退将撤销以下变更:"
+	}
+	switch {
+	case len(log.Notes) > 0:
+		comm.Log.Infof("%s", i18n.T(title))
+		for _, note := range log.Notes {
+			comm.Log.Infof("[%s] %s", note.Tag, note.Name)
+			for _, line := range strings.Split(note.Body, "\n") {
+				comm.Log.Infof("  %s", strings.TrimRight(line, "\r"))
+			}
+		}
+	case len(log.Commits) > 0:
+		comm.Log.Infof("%s", i18n.T(title))
+		for _, subject := range log.Commits {
+			comm.Log.Infof("  - %s", subject)
+		}
+	}
+}
+
 func init() {
+	upgradeCmd.Flags().StringVarP(&upgradeTo, "to", "", "", "安装指定版本, 如 v1.2.0 (可低于当前版本以回退)")
+	upgradeCmd.Flags().BoolVarP(&upgradePrerelease, "prerelease", "", false, "将 -rc 等预发布版本纳入候选")
+	upgradeCmd.Flags().BoolVarP(&upgradeDryRun, "dry-run", "n", false, "仅展示目标版本与将执行的安装命令, 可获取时一并展示变更记录, 不执行安装")
+	upgradeCmd.Flags().BoolVarP(&upgradeGithub, "github", "", false, "通过GitHub API而非Go模块代理获取版本列表")
 	rootCmd.AddCommand(upgradeCmd)
 }
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/release"
+)
+
+var versionJSON bool
+
+var versionCmd = &cobra.Command{
+	Use:     "version",
+	Short:   "查看gbc版本、构建信息及与mygo的兼容性",
+	Long:    "查看gbc版本、VCS修订与构建信息, 以及gbc各功能生成代码所用的mygo API; 在项目目录中执行时根据模块缓存中的mygo源码检查项目依赖的mygo是否提供这些API",
+	Example: "gbc version\ngbc version --json",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := release.Current()
+		api, err := release.LoadMygoAPI(".")
+		if err != nil {
+			comm.Log.Warnf("无法检查mygo兼容性: %s", err.Error())
+		}
+		mygo := ""
+		if api != nil {
+			mygo = api.Version
+		}
+
+		type compat struct {
+			release.Feature
+			Compatible *bool    `json:"compatible,omitempty"`
+			Missing    []string `json:"missing,omitempty"`
+		}
+		matrix := make([]compat, 0, len(release.Features))
+		for _, f := range release.Features {
+			c := compat{Feature: f}
+			if api != nil {
+				c.Missing = api.Missing(&f)
+				ok := len(c.Missing) == 0
+				c.Compatible = &ok
+			}
+			matrix = append(matrix, c)
+		}
+
+		if versionJSON {
+			out := struct {
+				*release.BuildInfo
+				ProjectMygo   string   `json:"project_mygo,omitempty"`
+				Compatibility []compat `json:"compatibility"`
+			}{info, mygo, matrix}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(out)
+			return nil
+		}
+
+		fmt.Fprintf(os.Stdout, "gbc %s%s", info.Version, comm.NewLine)
+		if info.Revision != "" {
+			modified := ""
+			if info.Modified {
+				modified = " (modified)"
+			}
+			fmt.Fprintf(os.Stdout, "revision: %s%s%s", info.Revision, modified, comm.NewLine)
+		}
+		if !info.Time.IsZero() {
+			fmt.Fprintf(os.Stdout, "time:     %s%s", info.Time.Format(time.RFC3339), comm.NewLine)
+		}
+		fmt.Fprintf(os.Stdout, "go:       %s%s%s", info.GoVersion, comm.NewLine, comm.NewLine)
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		header := i18n.T("功能\tmygo API")
+		if mygo != "" {
+			header += i18n.Sprintf("\t项目mygo[%s]", mygo)
+		}
+		fmt.Fprint(tw, header+comm.NewLine)
+		for _, c := range matrix {
+			row := fmt.Sprintf("%s\t%s", c.Name, strings.Join(c.API, ", "))
+			if c.Compatible != nil {
+				status := i18n.T("兼容")
+				if !*c.Compatible {
+					status = i18n.Sprintf("需升级, 缺少 %s", strings.Join(c.Missing, ", "))
+				}
+				row += "\t" + status
+			}
+			fmt.Fprint(tw, row+comm.NewLine)
+		}
+		_ = tw.Flush()
+		return nil
+	},
+}
+
+func init() {
+	versionCmd.Flags().BoolVarP(&versionJSON, "json", "", false, "以JSON格式输出")
+	rootCmd.AddCommand(versionCmd)
+}
@@ -0,0 +1,277 @@
+package codes
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// 翻译目录支持的格式
+const (
+	FormatJSON = "json"
+	FormatPO   = "po"
+	FormatCSV  = "csv"
+)
+
+// Formats 全部支持的翻译目录格式
+var Formats = []string{FormatJSON, FormatPO, FormatCSV}
+
+// FormatOf 根据文件扩展名判断翻译目录的格式
+func FormatOf(file string) (string, bool) {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		return FormatJSON, true
+	case ".po", ".pot":
+		return FormatPO, true
+	case ".csv":
+		return FormatCSV, true
+	}
+	return "", false
+}
+
+// Entry 翻译目录中的一个业务状态码
+type Entry struct {
+	analysis.CodeEntry
+	Translation string // 译文, 为空表示尚未翻译
+}
+
+// WriteCatalog 以format格式输出以业务状态码为键的翻译目录
+// locale为空时输出源语言目录: JSON格式的值为提示信息本身, PO与CSV格式的译文留空
+func WriteCatalog(w io.Writer, format, locale string, entries []Entry) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, locale, entries)
+	case FormatPO:
+		return writePO(w, locale, entries)
+	case FormatCSV:
+		return writeCSV(w, entries)
+	}
+	return i18n.Errorf("不支持的翻译目录格式[%s], 可选的值有: %s", format, strings.Join(Formats, "、"))
+}
+
+// ReadCatalog 读取format格式的翻译目录, 返回业务状态码到译文的映射, 未翻译的业务状态码译文为空
+func ReadCatalog(r io.Reader, format string) (map[int64]string, error) {
+	switch format {
+	case FormatJSON:
+		return readJSON(r)
+	case FormatPO:
+		return readPO(r)
+	case FormatCSV:
+		return readCSV(r)
+	}
+	return nil, i18n.Errorf("不支持的翻译目录格式[%s], 可选的值有: %s", format, strings.Join(Formats, "、"))
+}
+
+// writeJSON 输出按业务状态码排序的 {"状态码": "译文"} 对象, 可直接被前端加载
+func writeJSON(w io.Writer, locale string, entries []Entry) error {
+	b := bytes.Buffer{}
+	b.WriteString("{")
+	for i, e := range entries {
+		value := e.Translation
+		if locale == "" {
+			value = e.Message
+		}
+		v, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, "\n  \"%d\": %s", e.Code, v)
+	}
+	if len(entries) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+func readJSON(r io.Reader) (map[int64]string, error) {
+	raw := make(map[string]string)
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, i18n.Errorf("解析JSON失败: %w", err)
+	}
+	res := make(map[int64]string, len(raw))
+	for k, v := range raw {
+		code, err := strconv.ParseInt(k, 10, 64)
+		if err != nil {
+			return nil, i18n.Errorf("键[%s]不是业务状态码", k)
+		}
+		res[code] = v
+	}
+	return res, nil
+}
+
+// writePO 输出gettext PO文件, 以业务状态码作为 msgctxt 区分提示信息相同的业务状态码
+func writePO(w io.Writer, locale string, entries []Entry) error {
+	b := bytes.Buffer{}
+	b.WriteString("msgid \"\"\nmsgstr \"\"\n")
+	b.WriteString("\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+	if locale != "" {
+		fmt.Fprintf(&b, "\"Language: %s\\n\"\n", poEscape(locale))
+	}
+	for _, e := range entries {
+		// 提示信息不是常量时以变量名作为原文, 避免与文件头的空 msgid 混淆
+		msgid := e.Message
+		if msgid == "" {
+			msgid = e.Var
+		}
+		fmt.Fprintf(&b, "\n#. %s.%s\n", e.Package, e.Var)
+		if e.Position != "" {
+			fmt.Fprintf(&b, "#: %s\n", e.Position)
+		}
+		fmt.Fprintf(&b, "msgctxt \"%d\"\nmsgid \"%s\"\nmsgstr \"%s\"\n", e.Code, poEscape(msgid), poEscape(e.Translation))
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+func poEscape(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\r", "\\r", "\t", "\\t").Replace(s)
+}
+
+// readPO 读取PO文件中以业务状态码为 msgctxt 的条目, 标记为 fuzzy 的条目视为未翻译
+func readPO(r io.Reader) (map[int64]string, error) {
+	res := make(map[int64]string)
+	var (
+		ctxt, str   string
+		field       *string
+		fuzzy, used bool
+		lineNo      int
+	)
+	flush := func() error {
+		if used && ctxt != "" {
+			code, err := strconv.ParseInt(ctxt, 10, 64)
+			if err != nil {
+				return i18n.Errorf("第%d行: msgctxt[%s]不是业务状态码", lineNo, ctxt)
+			}
+			if fuzzy {
+				str = ""
+			}
+			res[code] = str
+		}
+		ctxt, str, field, fuzzy, used = "", "", nil, false, false
+		return nil
+	}
+	var discard string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "#,"):
+			fuzzy = strings.Contains(line, "fuzzy")
+		case strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "\""):
+			if field == nil {
+				return nil, i18n.Errorf("第%d行: 无法识别的内容", lineNo)
+			}
+			s, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, i18n.Errorf("第%d行: %w", lineNo, err)
+			}
+			*field += s
+		default:
+			keyword, value, _ := strings.Cut(line, " ")
+			s, err := strconv.Unquote(strings.TrimSpace(value))
+			if err != nil {
+				return nil, i18n.Errorf("第%d行: %w", lineNo, err)
+			}
+			switch keyword {
+			case "msgctxt":
+				// 缺少空行分隔时, 新的 msgctxt 开始一个新条目
+				if used {
+					if err := flush(); err != nil {
+						return nil, err
+					}
+				}
+				ctxt, field = s, &ctxt
+			case "msgstr":
+				str, field = s, &str
+			default:
+				// msgid 等其余字段只需校验格式
+				discard, field = s, &discard
+			}
+			used = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+var csvHeader = []string{"code", "variable", "message", "translation"}
+
+func writeCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := cw.Write([]string{strconv.FormatInt(e.Code, 10), e.Package + "." + e.Var, e.Message, e.Translation}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// readCSV 读取CSV文件, 按表头定位 code 与 translation 两列, 其余列可以任意增删
+func readCSV(r io.Reader) (map[int64]string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, i18n.Errorf("解析CSV失败: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, i18n.Errorf("CSV文件缺少表头")
+	}
+	codeCol, transCol := -1, -1
+	for i, name := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case "code":
+			codeCol = i
+		case "translation":
+			transCol = i
+		}
+	}
+	if codeCol < 0 || transCol < 0 {
+		return nil, i18n.Errorf("CSV表头必须包含 code 与 translation 两列")
+	}
+	res := make(map[int64]string, len(records)-1)
+	for i, rec := range records[1:] {
+		if codeCol >= len(rec) || strings.TrimSpace(rec[codeCol]) == "" {
+			continue
+		}
+		code, err := strconv.ParseInt(strings.TrimSpace(rec[codeCol]), 10, 64)
+		if err != nil {
+			return nil, i18n.Errorf("第%d行: [%s]不是业务状态码", i+2, rec[codeCol])
+		}
+		if transCol < len(rec) {
+			res[code] = rec[transCol]
+		} else {
+			res[code] = ""
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,170 @@
+package codes
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zjutjh/gbc/analysis"
+)
+
+func entry(pkg, name string, code int64, message, translation string) Entry {
+	return Entry{
+		CodeEntry:   analysis.CodeEntry{Package: pkg, Var: name, Code: code, Message: message, Position: "comm/code.go:10"},
+		Translation: translation,
+	}
+}
+
+func TestCatalogRoundTrip(t *testing.T) {
+	entries := []Entry{
+		entry("app/comm", "CodeOK", 0, "成功", "OK"),
+		entry("app/comm", "CodeUserNotFound", 10001, "用户不存在", "User not found"),
+		// 提示信息相同时由 msgctxt 区分
+		entry("app/api/admin", "CodeUserNotFound", 20001, "用户不存在", "Admin not found"),
+		entry("app/comm", "CodeQuote", 10002, `参数"name"不合法`, `invalid "name", see C:\docs`),
+		entry("app/comm", "CodeMultiLine", 10003, "第一行\n第二行", "line 1\n\tline 2\n"),
+		entry("app/comm", "CodeComma", 10004, "用户名, 密码错误", "wrong username, password"),
+		entry("app/comm", "CodeUntranslated", 10005, "未翻译", ""),
+		// 提示信息不是常量
+		entry("app/comm", "CodeDynamic", 10006, "", "dynamic"),
+		entry("app/comm", "CodeNegative", -1, "未知错误", "unknown error"),
+	}
+	want := make(map[int64]string, len(entries))
+	source := make(map[int64]string, len(entries))
+	for _, e := range entries {
+		want[e.Code] = e.Translation
+		source[e.Code] = e.Message
+	}
+
+	for _, format := range Formats {
+		t.Run(format, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			if err := WriteCatalog(&buf, format, "en", entries); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ReadCatalog(bytes.NewReader(buf.Bytes()), format)
+			if err != nil {
+				t.Fatalf("ReadCatalog() error = %v\n%s", err, buf.String())
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ReadCatalog() =\n%q\nwant\n%q\n%s", got, want, buf.String())
+			}
+		})
+	}
+
+	// 源语言JSON目录的值为提示信息本身
+	buf := bytes.Buffer{}
+	if err := WriteCatalog(&buf, FormatJSON, "", entries); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ReadCatalog(&buf, FormatJSON); err != nil || !reflect.DeepEqual(got, source) {
+		t.Errorf("source JSON catalog = %q, %v, want %q", got, err, source)
+	}
+}
+
+func TestReadPO(t *testing.T) {
+	tests := []struct {
+		name    string
+		po      string
+		want    map[int64]string
+		wantErr bool
+	}{
+		{
+			name: "edited by a translation tool",
+			po: `# Translators
+msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: en\n"
+
+#. app/comm.CodeUserNotFound
+#: comm/code.go:10
+msgctxt "10001"
+msgid "用户不存在"
+msgstr ""
+"User "
+"not found"
+
+#, fuzzy
+msgctxt "10002"
+msgid "密码错误"
+msgstr "Wrong password"
+
+#, c-format
+msgctxt ""
+"10003"
+msgid "参数错误"
+msgstr "Invalid\tparameter \"x\""
+msgctxt "10004"
+msgid "未翻译"
+msgstr ""
+
+#~ msgctxt "10005"
+#~ msgid "已删除"
+#~ msgstr "Removed"
+`,
+			want: map[int64]string{10001: "User not found", 10002: "", 10003: "Invalid\tparameter \"x\"", 10004: ""},
+		},
+		{
+			name:    "msgctxt is not a code",
+			po:      "msgctxt \"CodeOK\"\nmsgid \"成功\"\nmsgstr \"OK\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "string without keyword",
+			po:      "\"dangling\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated string",
+			po:      "msgctxt \"1\"\nmsgid \"成功\nmsgstr \"OK\"\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadCatalog(strings.NewReader(tt.po), FormatPO)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadCatalog() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadCatalog() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		want    map[int64]string
+		wantErr bool
+	}{
+		{
+			name: "columns reordered by a spreadsheet",
+			csv:  "\ufeffTranslation,note,Code\nUser not found,checked,10001\n\"a, \"\"b\"\"\",,10002\n,,\n",
+			want: map[int64]string{10001: "User not found", 10002: `a, "b"`},
+		},
+		{
+			name: "short rows",
+			csv:  "code,variable,message,translation\n10001,app/comm.CodeUserNotFound\n",
+			want: map[int64]string{10001: ""},
+		},
+		{name: "empty file", csv: "", wantErr: true},
+		{name: "missing translation column", csv: "code,message\n10001,用户不存在\n", wantErr: true},
+		{name: "invalid code", csv: "code,translation\nCodeOK,OK\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadCatalog(strings.NewReader(tt.csv), FormatCSV)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadCatalog() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadCatalog() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+// Package codes 项目中业务状态码的文档、翻译与生成工具
+package codes
+
+import (
+	"cmp"
+	"fmt"
+	"html/template"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+const (
+	GroupByPackage = "package"
+	GroupByRange   = "range"
+)
+
+// Usage 可能返回业务状态码的一处处理器
+type Usage struct {
+	Handler string `json:"handler"`          // 处理器的完整名称, 如 app/api/user.hfLogin
+	Method  string `json:"method,omitempty"` // 处理器注册的路由, 未找到注册时为空
+	Path    string `json:"path,omitempty"`
+	Name    string `json:"name,omitempty"` // API的 Info name 标签
+}
+
+func (u Usage) String() string {
+	if u.Path == "" {
+		return u.Handler
+	}
+	s := u.Method + " " + u.Path
+	if u.Name != "" {
+		s += " (" + u.Name + ")"
+	}
+	return s
+}
+
+// Code 文档中的一个业务状态码
+type Code struct {
+	analysis.CodeEntry
+	Usages []Usage `json:"usages"`
+}
+
+// Group 文档中的一组业务状态码
+type Group struct {
+	Title string
+	Codes []Code
+}
+
+// Usages 根据 gbc codegen 的分析结果整理每个业务状态码可能由哪些处理器与路由返回, 以 包路径.变量名 为键
+// 分析结果只记录变量名, 同名变量存在于多个包时均视为被返回
+func Usages(codes []analysis.CodeEntry, routes []analysis.Route, infos map[string][]*analysis.GinHandlerInfo) map[string][]Usage {
+	handlerRoutes := make(map[string][]analysis.Route)
+	for _, r := range routes {
+		if r.API != nil {
+			handlerRoutes[r.API.HandlerFunc()] = append(handlerRoutes[r.API.HandlerFunc()], r)
+		}
+	}
+	res := make(map[string][]Usage)
+	for _, list := range infos {
+		for _, info := range list {
+			usages := make([]Usage, 0)
+			for _, r := range handlerRoutes[info.HandlerName] {
+				usages = append(usages, Usage{Handler: info.HandlerName, Method: r.Method, Path: r.Path, Name: r.API.Name})
+			}
+			if len(usages) == 0 {
+				usages = append(usages, Usage{Handler: info.HandlerName})
+			}
+			for _, name := range info.StatusCodes {
+				for _, c := range codes {
+					if c.Var == name {
+						key := c.Package + "." + c.Var
+						res[key] = append(res[key], usages...)
+					}
+				}
+			}
+		}
+	}
+	for key, usages := range res {
+		slices.SortFunc(usages, func(a, b Usage) int {
+			return cmp.Or(strings.Compare(a.Path, b.Path), strings.Compare(a.Method, b.Method), strings.Compare(a.Handler, b.Handler))
+		})
+		res[key] = slices.Compact(usages)
+	}
+	return res
+}
+
+// CheckGroup 检查分组方式与区间宽度
+func CheckGroup(by string, size int64) error {
+	if by != GroupByPackage && by != GroupByRange {
+		return i18n.Errorf("不支持的分组方式[%s], 可选的值有: %s、%s", by, GroupByPackage, GroupByRange)
+	}
+	if by == GroupByRange && size <= 0 {
+		return i18n.Errorf("区间宽度必须大于0")
+	}
+	return nil
+}
+
+// Groups 将业务状态码按包或按数值区间分组, 区间的宽度为size, 如 size为10000时 10001 属于 10000-19999
+func Groups(codes []analysis.CodeEntry, usages map[string][]Usage, by string, size int64) ([]Group, error) {
+	if err := CheckGroup(by, size); err != nil {
+		return nil, err
+	}
+	type keyed struct {
+		key   int64
+		title string
+	}
+	order := make([]keyed, 0)
+	groups := make(map[string]*Group)
+	for _, c := range codes {
+		k := keyed{title: c.Package}
+		if by == GroupByRange {
+			// 向下取整, 使负数状态码也落在正确的区间
+			start := c.Code / size * size
+			if c.Code < 0 && c.Code%size != 0 {
+				start -= size
+			}
+			k = keyed{key: start, title: fmt.Sprintf("%d - %d", start, start+size-1)}
+		}
+		g, ok := groups[k.title]
+		if !ok {
+			g = &Group{Title: k.title}
+			groups[k.title] = g
+			order = append(order, k)
+		}
+		g.Codes = append(g.Codes, Code{CodeEntry: c, Usages: usages[c.Package+"."+c.Var]})
+	}
+	slices.SortFunc(order, func(a, b keyed) int {
+		return cmp.Or(cmp.Compare(a.key, b.key), strings.Compare(a.title, b.title))
+	})
+	res := make([]Group, 0, len(order))
+	for _, k := range order {
+		g := groups[k.title]
+		slices.SortFunc(g.Codes, func(a, b Code) int {
+			return cmp.Or(cmp.Compare(a.Code, b.Code), strings.Compare(a.Var, b.Var))
+		})
+		res = append(res, *g)
+	}
+	return res, nil
+}
+
+// Markdown 输出Markdown格式的业务状态码文档
+func Markdown(w io.Writer, title string, groups []Group) error {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	for _, g := range groups {
+		fmt.Fprintf(&b, "- [%s](#%s)\n", g.Title, anchor(g.Title))
+	}
+	for _, g := range groups {
+		fmt.Fprintf(&b, "\n<a id=\"%s\"></a>\n\n## %s\n\n", anchor(g.Title), markdownCell(g.Title))
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", i18n.T("状态码"), i18n.T("变量"), i18n.T("提示信息"), i18n.T("声明位置"), i18n.T("可能返回的接口"))
+		b.WriteString("| ---: | --- | --- | --- | --- |\n")
+		for _, c := range g.Codes {
+			usages := make([]string, 0, len(c.Usages))
+			for _, u := range c.Usages {
+				usages = append(usages, markdownCell(u.String()))
+			}
+			fmt.Fprintf(&b, "| %d | `%s.%s` | %s | %s | %s |\n", c.Code, path.Base(c.Package), c.Var,
+				markdownCell(c.Message), markdownCell(c.Position), strings.Join(usages, "<br>"))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell 可以放在表格单元格中的文本
+func markdownCell(s string) string {
+	s = strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ", "<", "&lt;", ">", "&gt;").Replace(s)
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// anchor 分组标题对应的锚点
+func anchor(title string) string {
+	return "group-" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, title)
+}
+
+// HTML 输出单个自包含的HTML文件, 样式与搜索脚本均内联在文件中
+func HTML(w io.Writer, title string, groups []Group) error {
+	tmpl, err := template.New("doc").Funcs(template.FuncMap{
+		"T":      i18n.T,
+		"anchor": anchor,
+		"base":   path.Base,
+		"count": func(groups []Group) int {
+			n := 0
+			for _, g := range groups {
+				n += len(g.Codes)
+			}
+			return n
+		},
+	}).Parse(htmlTemplate)
+	if err != nil {
+		return i18n.Errorf("解析模板失败: %w", err)
+	}
+	return tmpl.Execute(w, map[string]any{"Title": title, "Groups": groups})
+}
+
+const htmlTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Title }}</title>
+<style>
+body { margin: 0; font: 14px/1.6 -apple-system, "Segoe UI", "PingFang SC", "Microsoft YaHei", sans-serif; color: #1f2328; }
+header { position: sticky; top: 0; background: #fff; border-bottom: 1px solid #d0d7de; padding: 12px 24px; display: flex; gap: 16px; align-items: center; }
+header h1 { font-size: 20px; margin: 0; }
+header input { flex: 1; max-width: 360px; padding: 6px 10px; border: 1px solid #d0d7de; border-radius: 6px; font-size: 14px; }
+header span { color: #656d76; }
+nav { padding: 12px 24px; display: flex; flex-wrap: wrap; gap: 8px; }
+nav a { padding: 2px 10px; border: 1px solid #d0d7de; border-radius: 12px; color: #0969da; text-decoration: none; }
+main { padding: 0 24px 24px; }
+h2 { font-size: 16px; margin: 24px 0 8px; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #d0d7de; padding: 6px 10px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+td.code { text-align: right; font-weight: 600; white-space: nowrap; }
+code { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 13px; }
+td.usages div { white-space: nowrap; }
+.muted { color: #656d76; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{ .Title }}</h1>
+<input id="search" type="search" placeholder="{{ T "搜索状态码、变量、提示信息或接口" }}" autofocus>
+<span id="count">{{ count .Groups }}</span>
+</header>
+<nav>{{ range .Groups }}<a href="#{{ anchor .Title }}">{{ .Title }}</a>{{ end }}</nav>
+<main>
+{{- range .Groups }}
+<section>
+<h2 id="{{ anchor .Title }}">{{ .Title }}</h2>
+<table>
+<thead><tr><th>{{ T "状态码" }}</th><th>{{ T "变量" }}</th><th>{{ T "提示信息" }}</th><th>{{ T "声明位置" }}</th><th>{{ T "可能返回的接口" }}</th></tr></thead>
+<tbody>
+{{- range .Codes }}
+<tr><td class="code">{{ .Code }}</td><td><code>{{ base .Package }}.{{ .Var }}</code></td><td>{{ .Message }}</td><td class="muted"><code>{{ .Position }}</code></td><td class="usages">{{ range .Usages }}<div>{{ if .Path }}<code>{{ .Method }} {{ .Path }}</code>{{ if .Name }} <span class="muted">{{ .Name }}</span>{{ end }}{{ else }}<code>{{ .Handler }}</code>{{ end }}</div>{{ else }}<span class="muted">-</span>{{ end }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+</section>
+{{- end }}
+</main>
+<script>
+const search = document.getElementById("search")
+const count = document.getElementById("count")
+search.addEventListener("input", () => {
+  const q = search.value.trim().toLowerCase()
+  let n = 0
+  for (const section of document.querySelectorAll("section")) {
+    let visible = 0
+    for (const row of section.querySelectorAll("tbody tr")) {
+      const hit = !q || row.textContent.toLowerCase().includes(q)
+      row.hidden = !hit
+      if (hit) visible++
+    }
+    section.hidden = visible === 0
+    n += visible
+  }
+  count.textContent = n
+})
+</script>
+</body>
+</html>
+`
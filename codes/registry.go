@@ -0,0 +1,301 @@
+package codes
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// 业务状态码注册表的默认文件
+const (
+	RegistryFile    = "codes.yaml"
+	RegistryCSVFile = "codes.csv"
+)
+
+// RegistryGenerator 注册表生成文件的生成者
+const RegistryGenerator = "gbc codes gen"
+
+// Registry 业务状态码注册表, 作为业务状态码的唯一来源生成 kit.NewCode 声明, 如:
+//
+//	output: comm/codes_generated.go
+//	ranges:
+//	  - module: user
+//	    from: 20000
+//	    to: 29999
+//	codes:
+//	  - code: 20001
+//	    name: CodeUserNotFound
+//	    message: 用户不存在
+//	    http: 404
+//	    module: user
+type Registry struct {
+	Output string          `yaml:"output,omitempty"` // 生成文件的路径, 相对于项目目录
+	Ranges []RegistryRange `yaml:"ranges,omitempty"`
+	Codes  []RegistryCode  `yaml:"codes"`
+}
+
+// RegistryRange 模块可以使用的业务状态码区间, 包含两端
+type RegistryRange struct {
+	Module string `yaml:"module"`
+	From   int64  `yaml:"from"`
+	To     int64  `yaml:"to"`
+}
+
+// RegistryCode 注册表中的一个业务状态码
+type RegistryCode struct {
+	Code    int64  `yaml:"code"`
+	Name    string `yaml:"name"`             // 生成的变量名
+	Message string `yaml:"message"`          // 提示信息
+	HTTP    int    `yaml:"http,omitempty"`   // 建议的HTTP状态码, 仅写入注释
+	Module  string `yaml:"module,omitempty"` // 所属模块
+}
+
+// registryCSVHeader CSV格式注册表的表头, CSV格式不支持声明区间
+var registryCSVHeader = []string{"code", "name", "message", "http", "module"}
+
+// LoadRegistry 按扩展名 (.yaml、.yml、.csv) 解析注册表
+func LoadRegistry(file string, data []byte) (*Registry, error) {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		r := &Registry{}
+		if err := yaml.Unmarshal(data, r); err != nil {
+			return nil, i18n.Errorf("解析%s失败: %w", file, err)
+		}
+		return r, nil
+	case ".csv":
+		return readRegistryCSV(data)
+	}
+	return nil, i18n.Errorf("无法根据扩展名判断注册表[%s]的格式, 支持的扩展名有: .yaml、.yml、.csv", file)
+}
+
+func readRegistryCSV(data []byte) (*Registry, error) {
+	cr := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, i18n.Errorf("解析CSV失败: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, i18n.Errorf("CSV文件缺少表头")
+	}
+	cols := make(map[string]int)
+	for i, name := range records[0] {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range registryCSVHeader[:3] {
+		if _, ok := cols[name]; !ok {
+			return nil, i18n.Errorf("CSV表头必须包含 code、name、message 三列")
+		}
+	}
+	cell := func(rec []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+	r := &Registry{}
+	for i, rec := range records[1:] {
+		if strings.TrimSpace(strings.Join(rec, "")) == "" {
+			continue
+		}
+		c := RegistryCode{Name: cell(rec, "name"), Message: cell(rec, "message"), Module: cell(rec, "module")}
+		if c.Code, err = strconv.ParseInt(cell(rec, "code"), 10, 64); err != nil {
+			return nil, i18n.Errorf("第%d行: [%s]不是业务状态码", i+2, cell(rec, "code"))
+		}
+		if s := cell(rec, "http"); s != "" {
+			if c.HTTP, err = strconv.Atoi(s); err != nil {
+				return nil, i18n.Errorf("第%d行: [%s]不是HTTP状态码", i+2, s)
+			}
+		}
+		r.Codes = append(r.Codes, c)
+	}
+	return r, nil
+}
+
+// Validate 检查注册表, 返回发现的全部问题:
+// 变量名须为导出的标识符且不重复, 状态码不重复, 区间互不重叠,
+// 声明了区间的模块的状态码须落在其区间内, 且任何状态码都不能落在其他模块的区间内
+func (r *Registry) Validate() error {
+	errs := make([]error, 0)
+	ranges := make(map[string]RegistryRange, len(r.Ranges))
+	for i, rg := range r.Ranges {
+		if rg.Module == "" {
+			errs = append(errs, i18n.Errorf("第%d个区间未指定模块", i+1))
+			continue
+		}
+		if _, ok := ranges[rg.Module]; ok {
+			errs = append(errs, i18n.Errorf("模块[%s]的区间重复声明", rg.Module))
+			continue
+		}
+		if rg.From > rg.To {
+			errs = append(errs, i18n.Errorf("模块[%s]的区间 %d - %d 起点大于终点", rg.Module, rg.From, rg.To))
+			continue
+		}
+		for _, other := range r.Ranges[:i] {
+			if other.Module != rg.Module && rg.From <= other.To && other.From <= rg.To {
+				errs = append(errs, i18n.Errorf("模块[%s]的区间 %d - %d 与模块[%s]的区间 %d - %d 重叠",
+					rg.Module, rg.From, rg.To, other.Module, other.From, other.To))
+			}
+		}
+		ranges[rg.Module] = rg
+	}
+
+	codes := make(map[int64]string, len(r.Codes))
+	names := make(map[string]int64, len(r.Codes))
+	for _, c := range r.Codes {
+		switch {
+		case c.Name == "":
+			errs = append(errs, i18n.Errorf("业务状态码[%d]未指定变量名", c.Code))
+		case !token.IsIdentifier(c.Name) || !token.IsExported(c.Name):
+			errs = append(errs, i18n.Errorf("业务状态码[%d]的变量名[%s]不是导出的Go标识符", c.Code, c.Name))
+		}
+		if other, ok := codes[c.Code]; ok {
+			errs = append(errs, i18n.Errorf("业务状态码[%d]同时分配给了 %s 与 %s", c.Code, other, c.Name))
+		} else {
+			codes[c.Code] = c.Name
+		}
+		if other, ok := names[c.Name]; ok && c.Name != "" {
+			errs = append(errs, i18n.Errorf("变量名[%s]同时用于业务状态码 %d 与 %d", c.Name, other, c.Code))
+		} else {
+			names[c.Name] = c.Code
+		}
+		if c.Message == "" {
+			errs = append(errs, i18n.Errorf("业务状态码[%d] %s 缺少提示信息", c.Code, c.Name))
+		}
+		if c.HTTP != 0 && (c.HTTP < 100 || c.HTTP > 599) {
+			errs = append(errs, i18n.Errorf("业务状态码[%d] %s 的HTTP状态码[%d]无效", c.Code, c.Name, c.HTTP))
+		}
+		if rg, ok := ranges[c.Module]; ok && (c.Code < rg.From || c.Code > rg.To) {
+			errs = append(errs, i18n.Errorf("业务状态码[%d] %s 不在模块[%s]的区间 %d - %d 内", c.Code, c.Name, c.Module, rg.From, rg.To))
+		}
+		for _, rg := range r.Ranges {
+			if rg.Module != c.Module && c.Code >= rg.From && c.Code <= rg.To {
+				errs = append(errs, i18n.Errorf("业务状态码[%d] %s 属于模块[%s]的区间 %d - %d, 但声明的模块为[%s]",
+					c.Code, c.Name, rg.Module, rg.From, rg.To, c.Module))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Generate 生成包含全部 kit.NewCode 声明的Go源文件, 按模块分组, 组内按状态码排序
+func (r *Registry) Generate(pkgName, source string) ([]byte, error) {
+	type group struct {
+		module string
+		codes  []RegistryCode
+	}
+	groups := make([]*group, 0)
+	index := make(map[string]*group)
+	for _, c := range r.Codes {
+		g, ok := index[c.Module]
+		if !ok {
+			g = &group{module: c.Module}
+			index[c.Module] = g
+			groups = append(groups, g)
+		}
+		g.codes = append(g.codes, c)
+	}
+	for _, g := range groups {
+		slices.SortFunc(g.codes, func(a, b RegistryCode) int { return cmp.Compare(a.Code, b.Code) })
+	}
+	// 各组按组内最小的状态码排序
+	slices.SortFunc(groups, func(a, b *group) int {
+		return cmp.Or(cmp.Compare(a.codes[0].Code, b.codes[0].Code), strings.Compare(a.module, b.module))
+	})
+
+	b := bytes.Buffer{}
+	fmt.Fprintf(&b, "// Code generated by %q from %s. DO NOT EDIT.\n\n", RegistryGenerator, filepath.ToSlash(source))
+	fmt.Fprintf(&b, "package %s\n\nimport %q\n", pkgName, analysis.KitPkgPath)
+	for _, g := range groups {
+		b.WriteString("\n")
+		if g.module != "" {
+			fmt.Fprintf(&b, "// %s", g.module)
+			if rg, ok := r.rangeOf(g.module); ok {
+				fmt.Fprintf(&b, ": %d - %d", rg.From, rg.To)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("var (\n")
+		for _, c := range g.codes {
+			fmt.Fprintf(&b, "\t// %s %s", c.Name, strings.ReplaceAll(c.Message, "\n", " "))
+			if c.HTTP != 0 {
+				fmt.Fprintf(&b, " (HTTP %d)", c.HTTP)
+			}
+			fmt.Fprintf(&b, "\n\t%s = kit.NewCode(%d, %s)\n", c.Name, c.Code, strconv.Quote(c.Message))
+		}
+		b.WriteString(")\n")
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, i18n.Errorf("格式化代码失败: %w", err)
+	}
+	return src, nil
+}
+
+func (r *Registry) rangeOf(module string) (RegistryRange, bool) {
+	for _, rg := range r.Ranges {
+		if rg.Module == module {
+			return rg, true
+		}
+	}
+	return RegistryRange{}, false
+}
+
+// WriteRegistry 按扩展名输出注册表, CSV格式不包含区间与生成文件路径
+func WriteRegistry(w io.Writer, file string, r *Registry) error {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+		return enc.Close()
+	case ".csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(registryCSVHeader); err != nil {
+			return err
+		}
+		for _, c := range r.Codes {
+			status := ""
+			if c.HTTP != 0 {
+				status = strconv.Itoa(c.HTTP)
+			}
+			if err := cw.Write([]string{strconv.FormatInt(c.Code, 10), c.Name, c.Message, status, c.Module}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return i18n.Errorf("无法根据扩展名判断注册表[%s]的格式, 支持的扩展名有: .yaml、.yml、.csv", file)
+}
+
+// RegistryFrom 由项目中已有的业务状态码构建注册表, 模块取变量所在包的包名
+func RegistryFrom(codes []analysis.CodeEntry) *Registry {
+	r := &Registry{Codes: make([]RegistryCode, 0, len(codes))}
+	for _, c := range codes {
+		r.Codes = append(r.Codes, RegistryCode{
+			Code:    c.Code,
+			Name:    c.Var,
+			Message: c.Message,
+			Module:  path.Base(c.Package),
+		})
+	}
+	return r
+}
@@ -0,0 +1,213 @@
+package codes
+
+import (
+	"go/format"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+func TestRegistryValidate(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	i18n.SetLocale(i18n.ZhCN)
+
+	user := RegistryRange{Module: "user", From: 20000, To: 29999}
+	order := RegistryRange{Module: "order", From: 30000, To: 39999}
+	tests := []struct {
+		name   string
+		r      Registry
+		errors []string
+	}{
+		{
+			name: "valid",
+			r: Registry{
+				Ranges: []RegistryRange{user, order},
+				Codes: []RegistryCode{
+					{Code: 0, Name: "CodeOK", Message: "成功"},
+					{Code: 20001, Name: "CodeUserNotFound", Message: "用户不存在", HTTP: 404, Module: "user"},
+					{Code: 30001, Name: "CodeOrderNotFound", Message: "订单不存在", Module: "order"},
+					// 未声明区间的模块不受限制
+					{Code: 40001, Name: "CodePayFailed", Message: "支付失败", Module: "pay"},
+				},
+			},
+		},
+		{
+			name: "duplicate code",
+			r: Registry{Codes: []RegistryCode{
+				{Code: 10001, Name: "CodeA", Message: "a"},
+				{Code: 10001, Name: "CodeB", Message: "b"},
+			}},
+			errors: []string{"业务状态码[10001]同时分配给了 CodeA 与 CodeB"},
+		},
+		{
+			name: "duplicate name",
+			r: Registry{Codes: []RegistryCode{
+				{Code: 10001, Name: "CodeA", Message: "a"},
+				{Code: 10002, Name: "CodeA", Message: "b"},
+			}},
+			errors: []string{"变量名[CodeA]同时用于业务状态码 10001 与 10002"},
+		},
+		{
+			name: "invalid name",
+			r: Registry{Codes: []RegistryCode{
+				{Code: 10001, Message: "a"},
+				{Code: 10002, Name: "codeB", Message: "b"},
+				{Code: 10003, Name: "Code-C", Message: "c"},
+			}},
+			errors: []string{
+				"业务状态码[10001]未指定变量名",
+				"业务状态码[10002]的变量名[codeB]不是导出的Go标识符",
+				"业务状态码[10003]的变量名[Code-C]不是导出的Go标识符",
+			},
+		},
+		{
+			name: "missing message and invalid http",
+			r: Registry{Codes: []RegistryCode{
+				{Code: 10001, Name: "CodeA", HTTP: 600},
+			}},
+			errors: []string{"业务状态码[10001] CodeA 缺少提示信息", "业务状态码[10001] CodeA 的HTTP状态码[600]无效"},
+		},
+		{
+			name: "overlapping ranges",
+			r: Registry{Ranges: []RegistryRange{
+				user,
+				{Module: "admin", From: 29000, To: 30000},
+				{Module: "user", From: 1, To: 2},
+				{From: 1, To: 2},
+				{Module: "pay", From: 50000, To: 40000},
+			}},
+			errors: []string{
+				"模块[admin]的区间 29000 - 30000 与模块[user]的区间 20000 - 29999 重叠",
+				"模块[user]的区间重复声明",
+				"第4个区间未指定模块",
+				"模块[pay]的区间 50000 - 40000 起点大于终点",
+			},
+		},
+		{
+			name: "out of range",
+			r: Registry{
+				Ranges: []RegistryRange{user, order},
+				Codes: []RegistryCode{
+					{Code: 10001, Name: "CodeA", Message: "a", Module: "user"},
+					{Code: 30001, Name: "CodeB", Message: "b", Module: "user"},
+					{Code: 20001, Name: "CodeC", Message: "c"},
+				},
+			},
+			errors: []string{
+				"业务状态码[10001] CodeA 不在模块[user]的区间 20000 - 29999 内",
+				"业务状态码[30001] CodeB 不在模块[user]的区间 20000 - 29999 内",
+				"业务状态码[30001] CodeB 属于模块[order]的区间 30000 - 39999, 但声明的模块为[user]",
+				"业务状态码[20001] CodeC 属于模块[user]的区间 20000 - 29999, 但声明的模块为[]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.r.Validate()
+			got := []string(nil)
+			if err != nil {
+				got = strings.Split(err.Error(), "\n")
+			}
+			if !reflect.DeepEqual(got, tt.errors) {
+				t.Errorf("Validate() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.errors, "\n"))
+			}
+		})
+	}
+}
+
+func TestRegistryGenerate(t *testing.T) {
+	r := Registry{
+		Ranges: []RegistryRange{{Module: "user", From: 20000, To: 29999}},
+		Codes: []RegistryCode{
+			{Code: 20002, Name: "CodePasswordWrong", Message: "密码错误", Module: "user"},
+			{Code: 30001, Name: "CodeOrderNotFound", Message: "订单\"不存在\"\n请重试", HTTP: 404, Module: "order"},
+			{Code: 20001, Name: "CodeUserNotFound", Message: "用户不存在", HTTP: 404, Module: "user"},
+			{Code: 0, Name: "CodeOK", Message: "成功"},
+		},
+	}
+	got, err := r.Generate("comm", "comm/codes.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `// Code generated by "gbc codes gen" from comm/codes.yaml. DO NOT EDIT.
+
+package comm
+
+import "github.com/zjutjh/mygo/kit"
+
+var (
+	// CodeOK 成功
+	CodeOK = kit.NewCode(0, "成功")
+)
+
+// user: 20000 - 29999
+var (
+	// CodeUserNotFound 用户不存在 (HTTP 404)
+	CodeUserNotFound = kit.NewCode(20001, "用户不存在")
+	// CodePasswordWrong 密码错误
+	CodePasswordWrong = kit.NewCode(20002, "密码错误")
+)
+
+// order
+var (
+	// CodeOrderNotFound 订单"不存在" 请重试 (HTTP 404)
+	CodeOrderNotFound = kit.NewCode(30001, "订单\"不存在\"\n请重试")
+)
+`
+	if string(got) != want {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, want)
+	}
+	if formatted, err := format.Source(got); err != nil || string(formatted) != string(got) {
+		t.Errorf("Generate() output is not gofmt'd: %v", err)
+	}
+}
+
+func TestLoadRegistryCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		want    []RegistryCode
+		wantErr bool
+	}{
+		{
+			name: "full header",
+			csv:  "code,name,message,http,module\n20001,CodeUserNotFound,用户不存在,404,user\n0,CodeOK,\"成功, 无需处理\",,\n",
+			want: []RegistryCode{
+				{Code: 20001, Name: "CodeUserNotFound", Message: "用户不存在", HTTP: 404, Module: "user"},
+				{Code: 0, Name: "CodeOK", Message: "成功, 无需处理"},
+			},
+		},
+		{
+			name: "reordered header with BOM and blank rows",
+			csv:  "\ufeff Message ,NAME,note,Code\n用户不存在,CodeUserNotFound,x,20001\n,,,\n",
+			want: []RegistryCode{
+				{Code: 20001, Name: "CodeUserNotFound", Message: "用户不存在"},
+			},
+		},
+		{
+			name: "optional columns omitted",
+			csv:  "message,name,code\n用户不存在,CodeUserNotFound, 20001 \n,,\n",
+			want: []RegistryCode{
+				{Code: 20001, Name: "CodeUserNotFound", Message: "用户不存在"},
+			},
+		},
+		{name: "empty file", csv: "", wantErr: true},
+		{name: "missing message column", csv: "code,name\n1,CodeA\n", wantErr: true},
+		{name: "invalid code", csv: "code,name,message\nA,CodeA,a\n", wantErr: true},
+		{name: "row without code", csv: "code,name,message\n,CodeA,a\n", wantErr: true},
+		{name: "invalid http", csv: "code,name,message,http\n1,CodeA,a,Not Found\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := LoadRegistry("codes.csv", []byte(tt.csv))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadRegistry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(r.Codes, tt.want) {
+				t.Errorf("LoadRegistry() = %+v, want %+v", r.Codes, tt.want)
+			}
+		})
+	}
+}
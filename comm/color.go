@@ -1,13 +1,12 @@
 package comm
 
 import (
-	"fmt"
 	"io"
 	"os"
 )
 
 const (
-	Black   = "\033[1:30m"
+	Black   = "\033[1;30m"
 	Red     = "\033[1;31m"
 	Green   = "\033[1;32m"
 	Yellow  = "\033[1;33m"
@@ -22,33 +21,26 @@ const (
 const (
 	Debug = "\u001B[3;34m"
 	Info  = "\u001B[22;37m"
+	Warn  = "\u001B[22;33m"
 	Error = "\u001B[7;31m"
 	// Look          = "\u001B[4;32m"
 	Look          = "\u001B[22;32m"
 	UserInterface = "\u001B[4;37m"
 )
 
-func Fprintf(w io.Writer, c, format string, a ...any) {
-	format = fmt.Sprintf("%s %s %s%s", c, format, Reset, NewLine)
-	fmt.Fprintf(w, format, a...)
-}
-
-func OutputDebug(format string, a ...any) {
-	Fprintf(os.Stdout, Debug, format, a...)
-}
-
-func OutputInfo(format string, a ...any) {
-	Fprintf(os.Stdout, Info, format, a...)
-}
-
-func OutputError(format string, a ...any) {
-	Fprintf(os.Stderr, Error, format, a...)
-}
+// NoColor 是否禁用颜色, 由环境变量 NO_COLOR 或 --no-color 开启
+var NoColor = os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb"
 
-func OutputLook(format string, a ...any) {
-	Fprintf(os.Stdout, Look, format, a...)
+// ColorEnabled 向w输出时是否使用颜色: 未禁用颜色且w为终端
+func ColorEnabled(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return !NoColor && ok && IsTerminal(f)
 }
 
-func OutputUI(w io.Writer, format string, a ...any) {
-	Fprintf(w, UserInterface, format, a...)
+// Paint 需要时为文本添加颜色, 不使用颜色时原样返回
+func Paint(w io.Writer, c, text string) string {
+	if !ColorEnabled(w) {
+		return text
+	}
+	return c + " " + text + " " + Reset
 }
@@ -0,0 +1,120 @@
+package comm
+
+import (
+	"go/build/constraint"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// skipDir 扫描项目时跳过的目录: 隐藏目录、vendor与testdata
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata"
+}
+
+// PackageKeys 列出prefix目录下已存在的子包, 以key前缀的形式返回, 如 user.、user.admin.
+// sep 为key的路径分隔符 (. 或 /)
+func PackageKeys(prefix, sep string) []string {
+	root := filepath.Clean(prefix)
+	keys := make([]string, 0)
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || path == root {
+			return nil
+		}
+		if skipDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		rel, _ := filepath.Rel(root, path)
+		keys = append(keys, strings.ReplaceAll(filepath.ToSlash(rel), "/", sep)+sep)
+		return nil
+	})
+	slices.Sort(keys)
+	return keys
+}
+
+// ArtifactKeys 列出prefix目录下已存在的Go文件对应的key, 如 user.login
+// sep 为key的路径分隔符 (. 或 /)
+func ArtifactKeys(prefix, sep string) []string {
+	root := filepath.Clean(prefix)
+	keys := make([]string, 0)
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".go") || strings.HasSuffix(d.Name(), "_test.go") {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, strings.TrimSuffix(path, ".go"))
+		keys = append(keys, strings.ReplaceAll(filepath.ToSlash(rel), "/", sep))
+		return nil
+	})
+	slices.Sort(keys)
+	return keys
+}
+
+// BuildTags 收集root下所有Go文件 //go:build 行中出现的build tag
+func BuildTags(root string) []string {
+	set := make(map[string]struct{})
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".go") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		// //go:build 只能出现在package声明之前的注释中
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if constraint.IsGoBuild(line) {
+				if expr, err := constraint.Parse(line); err == nil {
+					collectTags(expr, set)
+				}
+				break
+			}
+			if line != "" && !strings.HasPrefix(line, "//") {
+				break
+			}
+		}
+		return nil
+	})
+	tags := make([]string, 0, len(set))
+	for tag := range set {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// collectTags 收集表达式中出现的全部tag
+func collectTags(expr constraint.Expr, set map[string]struct{}) {
+	switch e := expr.(type) {
+	case *constraint.TagExpr:
+		set[e.Tag] = struct{}{}
+	case *constraint.NotExpr:
+		collectTags(e.X, set)
+	case *constraint.AndExpr:
+		collectTags(e.X, set)
+		collectTags(e.Y, set)
+	case *constraint.OrExpr:
+		collectTags(e.X, set)
+		collectTags(e.Y, set)
+	}
+}
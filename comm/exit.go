@@ -0,0 +1,72 @@
+package comm
+
+import (
+	"errors"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// gbc命令的退出码, 供脚本与Makefile判断失败原因
+const (
+	ExitOK       = 0 // 执行成功
+	ExitFailure  = 1 // 执行失败, 如检查未通过、存在合并冲突
+	ExitUsage    = 2 // 用法错误, 如参数或选项不合法
+	ExitEnv      = 3 // 环境错误, 如不在项目目录中、缺少git或go、网络不可用
+	ExitAnalysis = 4 // 代码分析错误, 如软件包加载或分析失败
+	ExitIO       = 5 // 文件读写错误
+)
+
+// ExitError 带有退出码的错误
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+func exitErrorf(code int, format string, a ...any) error {
+	return &ExitError{Code: code, Err: i18n.Errorf(format, a...)}
+}
+
+// FailureErrorf 执行失败, 退出码为 ExitFailure
+func FailureErrorf(format string, a ...any) error {
+	return exitErrorf(ExitFailure, format, a...)
+}
+
+// UsageErrorf 用法错误, 退出码为 ExitUsage
+func UsageErrorf(format string, a ...any) error {
+	return exitErrorf(ExitUsage, format, a...)
+}
+
+// EnvErrorf 环境错误, 退出码为 ExitEnv
+func EnvErrorf(format string, a ...any) error {
+	return exitErrorf(ExitEnv, format, a...)
+}
+
+// AnalysisErrorf 代码分析错误, 退出码为 ExitAnalysis
+func AnalysisErrorf(format string, a ...any) error {
+	return exitErrorf(ExitAnalysis, format, a...)
+}
+
+// IOErrorf 文件读写错误, 退出码为 ExitIO
+func IOErrorf(format string, a ...any) error {
+	return exitErrorf(ExitIO, format, a...)
+}
+
+// ExitCode 错误对应的退出码, 未指定退出码的错误视为 ExitFailure
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
@@ -0,0 +1,36 @@
+package comm
+
+import (
+	"os"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// ReadGoMod 读取并解析dir下的go.mod文件
+func ReadGoMod(dir string) (*modfile.File, error) {
+	file := filepath.Join(dir, "go.mod")
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, i18n.Errorf("读取go.mod失败: %w", err)
+	}
+	f, err := modfile.Parse(file, data, nil)
+	if err != nil {
+		return nil, i18n.Errorf("解析go.mod失败: %w", err)
+	}
+	return f, nil
+}
+
+// ModulePath 读取dir下go.mod中声明的模块路径
+func ModulePath(dir string) (string, error) {
+	f, err := ReadGoMod(dir)
+	if err != nil {
+		return "", err
+	}
+	if f.Module == nil {
+		return "", i18n.Errorf("go.mod中缺少module声明")
+	}
+	return f.Module.Mod.Path, nil
+}
@@ -0,0 +1,235 @@
+package comm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// Level 输出事件的级别
+type Level int8
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelSuccess
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{
+	LevelDebug:   "debug",
+	LevelInfo:    "info",
+	LevelSuccess: "success",
+	LevelWarn:    "warn",
+	LevelError:   "error",
+}
+
+var levelColors = map[Level]string{
+	LevelDebug:   Debug,
+	LevelInfo:    Info,
+	LevelSuccess: Look,
+	LevelWarn:    Warn,
+	LevelError:   Error,
+}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// Event 一条输出事件
+type Event struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  []any // 键值对: key1, value1, key2, value2...
+}
+
+// Handler 输出事件的处理器, 决定事件的格式与去向
+type Handler interface {
+	Enabled(level Level) bool
+	Handle(e Event) error
+}
+
+// Logger gbc命令行的输出接口, 所有过程信息均通过它输出
+type Logger interface {
+	Debugf(format string, a ...any)
+	Infof(format string, a ...any)
+	Successf(format string, a ...any)
+	Warnf(format string, a ...any)
+	Errorf(format string, a ...any)
+	// With 返回附加了键值对字段的Logger
+	With(fields ...any) Logger
+}
+
+// Log 当前使用的Logger, 命令执行前由 SetupLog 根据命令行参数配置
+var Log Logger = NewLogger(&TextHandler{Level: LevelInfo})
+
+// Stdout 调试、信息与成功事件在文本格式下的输出位置, 命令以JSON等机器可读格式输出结果时可改为os.Stderr
+var Stdout io.Writer = os.Stdout
+
+// Stderr 警告与错误事件, 以及JSON格式事件的输出位置
+var Stderr io.Writer = os.Stderr
+
+type logger struct {
+	handlers []Handler
+	fields   []any
+}
+
+// NewLogger 创建将事件分发给handlers的Logger
+func NewLogger(handlers ...Handler) Logger {
+	return &logger{handlers: handlers}
+}
+
+func (l *logger) log(level Level, format string, a []any) {
+	var e *Event
+	for _, h := range l.handlers {
+		if !h.Enabled(level) {
+			continue
+		}
+		if e == nil {
+			e = &Event{Time: time.Now(), Level: level, Message: i18n.Sprintf(format, a...), Fields: l.fields}
+		}
+		_ = h.Handle(*e)
+	}
+}
+
+func (l *logger) Debugf(format string, a ...any)   { l.log(LevelDebug, format, a) }
+func (l *logger) Infof(format string, a ...any)    { l.log(LevelInfo, format, a) }
+func (l *logger) Successf(format string, a ...any) { l.log(LevelSuccess, format, a) }
+func (l *logger) Warnf(format string, a ...any)    { l.log(LevelWarn, format, a) }
+func (l *logger) Errorf(format string, a ...any)   { l.log(LevelError, format, a) }
+
+func (l *logger) With(fields ...any) Logger {
+	return &logger{handlers: l.handlers, fields: append(l.fields[:len(l.fields):len(l.fields)], fields...)}
+}
+
+// TextHandler 面向人阅读的文本格式, 输出到终端时使用颜色
+type TextHandler struct {
+	Level Level
+	// Writer 事件的输出位置, 为空时调试、信息与成功事件输出到Stdout, 警告与错误事件输出到Stderr
+	Writer io.Writer
+	// Time 是否输出事件时间
+	Time bool
+
+	mu sync.Mutex
+}
+
+func (h *TextHandler) Enabled(level Level) bool {
+	return level >= h.Level
+}
+
+func (h *TextHandler) Handle(e Event) error {
+	w := h.Writer
+	if w == nil {
+		w = Stdout
+		if e.Level >= LevelWarn {
+			w = Stderr
+		}
+	}
+	b := strings.Builder{}
+	if h.Time {
+		fmt.Fprintf(&b, "%s %-7s ", e.Time.Format(time.DateTime), strings.ToUpper(e.Level.String()))
+	}
+	b.WriteString(e.Message)
+	for i := 0; i+1 < len(e.Fields); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", e.Fields[i], e.Fields[i+1])
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := fmt.Fprint(w, Paint(w, levelColors[e.Level], b.String())+NewLine)
+	return err
+}
+
+// JSONHandler 每个事件输出一行JSON, 包含 time、level、msg 与附加字段
+type JSONHandler struct {
+	Level Level
+	// Writer 事件的输出位置, 为空时输出到Stderr
+	Writer io.Writer
+
+	mu sync.Mutex
+}
+
+func (h *JSONHandler) Enabled(level Level) bool {
+	return level >= h.Level
+}
+
+func (h *JSONHandler) Handle(e Event) error {
+	w := h.Writer
+	if w == nil {
+		w = Stderr
+	}
+	m := map[string]any{
+		"time":  e.Time.Format(time.RFC3339),
+		"level": e.Level.String(),
+		"msg":   e.Message,
+	}
+	for i := 0; i+1 < len(e.Fields); i += 2 {
+		key := fmt.Sprint(e.Fields[i])
+		if _, ok := m[key]; ok {
+			key = "field." + key
+		}
+		value := e.Fields[i+1]
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		m[key] = value
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// LogOptions 输出配置
+type LogOptions struct {
+	Format  string // 输出格式: text、json
+	Quiet   bool   // 仅输出警告与错误
+	Verbose int    // 大于0时输出调试信息
+	NoColor bool   // 禁用颜色
+	File    string // 额外将包括调试信息在内的全部事件追加到该文件
+}
+
+// SetupLog 根据配置替换Log, 日志文件在进程退出时关闭
+func SetupLog(opts LogOptions) error {
+	if opts.NoColor {
+		NoColor = true
+	}
+	level := LevelInfo
+	switch {
+	case opts.Quiet:
+		level = LevelWarn
+	case opts.Verbose > 0:
+		level = LevelDebug
+	}
+
+	handlers := make([]Handler, 0, 2)
+	switch opts.Format {
+	case "", "text":
+		handlers = append(handlers, &TextHandler{Level: level})
+	case "json":
+		handlers = append(handlers, &JSONHandler{Level: level})
+	default:
+		return UsageErrorf("不支持的日志格式[%s], 可选的值有: text、json", opts.Format)
+	}
+
+	if opts.File != "" {
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return IOErrorf("打开日志文件失败: %w", err)
+		}
+		handlers = append(handlers, &TextHandler{Level: LevelDebug, Writer: f, Time: true})
+	}
+	Log = NewLogger(handlers...)
+	return nil
+}
@@ -0,0 +1,46 @@
+package comm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoggerWithFields(t *testing.T) {
+	buf := bytes.Buffer{}
+	log := NewLogger(&JSONHandler{Level: LevelInfo, Writer: &buf})
+	file := log.With("path", "api/user/login.go")
+	file.With("key", "user.login", "msg", "shadowed").Infof("done")
+	file.With("error", errors.New("boom")).Warnf("failed")
+	log.Debugf("hidden")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d events, want 2:\n%s", len(lines), buf.String())
+	}
+	events := make([]map[string]any, 0, len(lines))
+	for _, line := range lines {
+		e := make(map[string]any)
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, e)
+	}
+	want := []map[string]any{
+		{"level": "info", "msg": "done", "path": "api/user/login.go", "key": "user.login", "field.msg": "shadowed"},
+		// 派生的Logger不影响父Logger的字段
+		{"level": "warn", "msg": "failed", "path": "api/user/login.go", "error": "boom"},
+	}
+	for i, fields := range want {
+		for key, value := range fields {
+			if events[i][key] != value {
+				t.Errorf("event %d: %s = %v, want %v", i, key, events[i][key], value)
+			}
+		}
+		if _, ok := events[i]["key"]; ok && i == 1 {
+			t.Errorf("event %d: unexpected field key", i)
+		}
+	}
+}
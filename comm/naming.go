@@ -0,0 +1,61 @@
+package comm
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// commonInitialisms golint 风格的常见缩写, 标识符中出现时整体大写
+var commonInitialisms = map[string]struct{}{
+	"ACL": {}, "API": {}, "ASCII": {}, "CPU": {}, "CSS": {}, "DNS": {}, "EOF": {}, "GUID": {},
+	"HTML": {}, "HTTP": {}, "HTTPS": {}, "ID": {}, "IP": {}, "JSON": {}, "LHS": {}, "QPS": {},
+	"RAM": {}, "RHS": {}, "RPC": {}, "SLA": {}, "SMTP": {}, "SQL": {}, "SSH": {}, "TCP": {},
+	"TLS": {}, "TTL": {}, "UDP": {}, "UI": {}, "UID": {}, "UUID": {}, "URI": {}, "URL": {},
+	"UTF8": {}, "VM": {}, "XML": {}, "XMPP": {}, "XSRF": {}, "XSS": {},
+}
+
+// ToCamel 将 snake_case / kebab-case 名称转换为导出的驼峰标识符, 并应用常见缩写
+func ToCamel(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' })
+	b := strings.Builder{}
+	for _, w := range words {
+		if _, ok := commonInitialisms[strings.ToUpper(w)]; ok {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		rs := []rune(w)
+		b.WriteString(strings.ToUpper(string(rs[0])) + string(rs[1:]))
+	}
+	return b.String()
+}
+
+// ToPackageName 将目录名转换为包名: 小写并去除 _ -
+func ToPackageName(dir string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, dir)
+}
+
+// ReceiverName 模板中方法接收者的名称: 标识符的首个字符转为小写
+func ReceiverName(ident string) string {
+	r, _ := utf8.DecodeRuneInString(ident)
+	if r == utf8.RuneError {
+		return ""
+	}
+	return string(unicode.ToLower(r))
+}
+
+// IsIdentifier 是否为合法的Go标识符 (非关键字)
+func IsIdentifier(name string) bool {
+	return token.IsIdentifier(name)
+}
+
+// IsPackageName 是否为合法的Go包名
+func IsPackageName(name string) bool {
+	return token.IsIdentifier(name) && name != "_" && name != "main"
+}
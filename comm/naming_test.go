@@ -0,0 +1,85 @@
+package comm
+
+import "testing"
+
+func TestToCamel(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"login", "Login"},
+		{"sign_in", "SignIn"},
+		{"sign-in", "SignIn"},
+		{"user_id", "UserID"},
+		{"api-url", "APIURL"},
+		{"http_server", "HTTPServer"},
+		{"get_user_uuid", "GetUserUUID"},
+		{"userId", "UserId"},
+		{"__login__", "Login"},
+		{"1login", "1login"},
+		{"用户", "用户"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ToCamel(tt.name); got != tt.want {
+			t.Errorf("ToCamel(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestToPackageName(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{"user", "user"},
+		{"user_info", "userinfo"},
+		{"user-info", "userinfo"},
+		{"UserInfo", "userinfo"},
+		{"v2", "v2"},
+	}
+	for _, tt := range tests {
+		if got := ToPackageName(tt.dir); got != tt.want {
+			t.Errorf("ToPackageName(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestReceiverName(t *testing.T) {
+	tests := []struct {
+		ident string
+		want  string
+	}{
+		{"Login", "l"},
+		{"UserID", "u"},
+		{"用户", "用"},
+		{"Ärger", "ä"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ReceiverName(tt.ident); got != tt.want {
+			t.Errorf("ReceiverName(%q) = %q, want %q", tt.ident, got, tt.want)
+		}
+	}
+}
+
+func TestIsPackageName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"user", true},
+		{"v2", true},
+		{"main", false},
+		{"_", false},
+		{"type", false},
+		{"func", false},
+		{"2user", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsPackageName(tt.name); got != tt.want {
+			t.Errorf("IsPackageName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
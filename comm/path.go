@@ -1,35 +1,138 @@
 package comm
 
 import (
-	"errors"
-	"fmt"
+	"go/parser"
+	"go/token"
 	"os"
+	"path/filepath"
 	"strings"
+	"unicode"
+
+	"github.com/zjutjh/gbc/i18n"
 )
 
-func ParseKey(key, pkgName, prefix, suffix string) (string, string, string, error) {
-	ks := strings.Split(key, ".")
-	path := strings.Join(ks, "/")
-	name := ks[len(ks)-1]
-	if name == "" {
-		return "", "", "", fmt.Errorf("key不能以[.]结尾")
+// Key 解析后的脚手架key
+type Key struct {
+	Raw         string   // 原始key
+	Dirs        []string // 目录段 (不含前缀)
+	Name        string   // 最后一段原始名称
+	FileName    string   // 文件名 (不含目录与后缀)
+	Path        string   // 完整文件路径
+	Dir         string   // 文件所在目录
+	Ident       string   // 导出标识符名称 (如 UserID)
+	PackageName string   // 包名
+}
+
+// NormalizeKey 解析key但不产生任何文件系统副作用
+// key支持 . / 作为路径分隔符, 名称中支持 _ - 作为单词分隔符
+// 例如: user.login、user/login、user.sign-in、a.b.c.user_id
+func NormalizeKey(key, pkgName, prefix, suffix string) (*Key, error) {
+	raw := key
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, i18n.Errorf("key不能为空")
+	}
+	if strings.HasSuffix(key, ".") || strings.HasSuffix(key, "/") {
+		return nil, i18n.Errorf("key不能以[.]或[/]结尾")
+	}
+	segments := strings.FieldsFunc(key, func(r rune) bool { return r == '.' || r == '/' })
+	if len(segments) != strings.Count(key, ".")+strings.Count(key, "/")+1 {
+		return nil, i18n.Errorf("key[%s]中存在空的路径段", raw)
+	}
+	for _, s := range segments {
+		if s == ".." {
+			return nil, i18n.Errorf("key[%s]中不允许出现[..]", raw)
+		}
+		if strings.ContainsFunc(s, func(r rune) bool {
+			return !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-')
+		}) {
+			return nil, i18n.Errorf("key[%s]的路径段[%s]中存在非法字符", raw, s)
+		}
+	}
+
+	name := segments[len(segments)-1]
+	ident := ToCamel(name)
+	if ident == "" {
+		return nil, i18n.Errorf("key[%s]无法生成有效的名称", raw)
 	}
-	ns := strings.Split(name, "_")
-	for k, v := range ns {
-		ns[k] = strings.ToUpper(v[:1]) + v[1:]
+	if !IsIdentifier(ident) {
+		return nil, i18n.Errorf("名称[%s]不是合法的Go标识符", ident)
 	}
-	structName := strings.Join(ns, "")
+
 	packageName := pkgName
-	if len(ks) > 1 {
-		packageName = ks[len(ks)-2]
-		dir := prefix + strings.Join(ks[:len(ks)-1], "/")
-		fi, err := os.Stat(dir)
-		if os.IsNotExist(err) {
-			// 需要创建目录
-			os.Mkdir(dir, 0755)
-		} else if !fi.IsDir() {
-			return "", "", "", errors.New("路径[" + dir + "]已存在且不是一个目录")
+	dirs := segments[:len(segments)-1]
+	if len(dirs) > 0 {
+		packageName = ToPackageName(dirs[len(dirs)-1])
+	}
+	if !IsPackageName(packageName) {
+		return nil, i18n.Errorf("包名[%s]不是合法的Go包名", packageName)
+	}
+
+	fileName := strings.ReplaceAll(name, "-", "_")
+	dir := filepath.Clean(prefix + strings.Join(dirs, "/"))
+	return &Key{
+		Raw:         raw,
+		Dirs:        dirs,
+		Name:        name,
+		FileName:    fileName,
+		Path:        filepath.Join(dir, fileName+suffix),
+		Dir:         dir,
+		Ident:       ident,
+		PackageName: packageName,
+	}, nil
+}
+
+// ParseKey 解析key并确保目标目录存在
+// 返回 文件路径, 结构体名称, 包名
+func ParseKey(key, pkgName, prefix, suffix string) (string, string, string, error) {
+	k, err := NormalizeKey(key, pkgName, prefix, suffix)
+	if err != nil {
+		return "", "", "", UsageErrorf("%w", err)
+	}
+	if err := EnsureDir(k.Dir); err != nil {
+		return "", "", "", IOErrorf("%w", err)
+	}
+	if existing := ExistingPackageName(k.Dir); existing != "" && existing != k.PackageName {
+		Log.Warnf("目录[%s]中已有文件声明为包[%s], 与生成的包名[%s]不一致", k.Dir, existing, k.PackageName)
+	}
+	return k.Path, k.Ident, k.PackageName, nil
+}
+
+// EnsureDir 递归创建目录, 若路径已存在且不是目录则报错
+func EnsureDir(dir string) error {
+	fi, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		// 需要创建目录
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return i18n.Errorf("创建目录[%s]失败: %w", dir, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return i18n.Errorf("路径[%s]已存在且不是一个目录", dir)
+	}
+	return nil
+}
+
+// ExistingPackageName 读取目录中已有Go文件声明的包名, 忽略_test包, 不存在时返回空字符串
+func ExistingPackageName(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, e.Name()), nil, parser.PackageClauseOnly)
+		if err != nil {
+			continue
 		}
+		return f.Name.Name
 	}
-	return prefix + path + suffix, structName, packageName, nil
+	return ""
 }
@@ -0,0 +1,76 @@
+package comm
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestNormalizeKey(t *testing.T) {
+	tests := []struct {
+		key         string
+		path        string
+		ident       string
+		packageName string
+		dirs        []string
+	}{
+		{"login", "api/login.go", "Login", "api", nil},
+		{"user.login", "api/user/login.go", "Login", "user", []string{"user"}},
+		{"user/login", "api/user/login.go", "Login", "user", []string{"user"}},
+		{"user.sign-in", "api/user/sign_in.go", "SignIn", "user", []string{"user"}},
+		{"a.b.c.user_id", "api/a/b/c/user_id.go", "UserID", "c", []string{"a", "b", "c"}},
+		{"user-info.get_url", "api/user-info/get_url.go", "GetURL", "userinfo", []string{"user-info"}},
+		{" user.login ", "api/user/login.go", "Login", "user", []string{"user"}},
+		// 名称为关键字时转换为首字母大写的标识符, 仍然合法
+		{"user.func", "api/user/func.go", "Func", "user", []string{"user"}},
+	}
+	for _, tt := range tests {
+		k, err := NormalizeKey(tt.key, "api", "api/", ".go")
+		if err != nil {
+			t.Errorf("NormalizeKey(%q) error: %v", tt.key, err)
+			continue
+		}
+		if k.Path != filepath.FromSlash(tt.path) || k.Ident != tt.ident || k.PackageName != tt.packageName || !slices.Equal(k.Dirs, tt.dirs) {
+			t.Errorf("NormalizeKey(%q) = {Path: %q, Ident: %q, PackageName: %q, Dirs: %q}, want {%q, %q, %q, %q}",
+				tt.key, k.Path, k.Ident, k.PackageName, k.Dirs, tt.path, tt.ident, tt.packageName, tt.dirs)
+		}
+	}
+}
+
+func TestNormalizeKeyInvalid(t *testing.T) {
+	keys := []string{
+		"",
+		"   ",
+		"user.",
+		"user/",
+		"user..login",
+		".login",
+		"../login",
+		"user/../login",
+		"user.log in",
+		"user.login!",
+		// 以数字开头的名称不是合法的标识符
+		"user.1login",
+		// 以数字开头或为关键字的目录不是合法的包名
+		"1user.login",
+		"type.login",
+		"main.login",
+		"user.___",
+	}
+	for _, key := range keys {
+		if k, err := NormalizeKey(key, "api", "api/", ".go"); err == nil {
+			t.Errorf("NormalizeKey(%q) = %+v, want error", key, k)
+		}
+	}
+}
+
+func TestNormalizeKeyPackageName(t *testing.T) {
+	// 没有目录段时使用传入的包名, 传入的包名同样需要合法
+	if _, err := NormalizeKey("login", "func", "api/", ".go"); err == nil {
+		t.Errorf("NormalizeKey with keyword package name: want error")
+	}
+	k, err := NormalizeKey("login", "cron", "cron/", ".go")
+	if err != nil || k.PackageName != "cron" {
+		t.Errorf("NormalizeKey(login, cron) = %+v, %v", k, err)
+	}
+}
@@ -0,0 +1,9 @@
+package comm
+
+import "os"
+
+// IsTerminal 文件是否为终端 (字符设备)
+func IsTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
@@ -1,9 +1,13 @@
 package comm
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"slices"
+
+	"github.com/zjutjh/gbc/i18n"
 )
 
 var yesList = []string{
@@ -25,11 +29,16 @@ var noList = []string{
 func UI(ask string, defaultAnswer string, runner func(bool)) {
 	answer := defaultAnswer
 	for {
-		OutputUI(os.Stdout, ask)
+		Prompt(os.Stdout, i18n.T(ask))
 		c, err := fmt.Scanln(&answer)
+		if errors.Is(err, io.EOF) {
+			// 输入已关闭 (如非交互环境), 使用默认值
+			answer = defaultAnswer
+			break
+		}
 		if err != nil {
 			if err.Error() != "unexpected newline" {
-				OutputError("输入发生错误, 请重新输入", err.Error())
+				Log.Errorf("输入发生错误: %s, 请重新输入", err.Error())
 				continue
 			}
 		}
@@ -37,7 +46,7 @@ func UI(ask string, defaultAnswer string, runner func(bool)) {
 			answer = defaultAnswer
 		}
 		if !slices.Contains(yesList, answer) && !slices.Contains(noList, answer) {
-			OutputError("输入不符合期望, 请重新输入")
+			Log.Errorf("输入不符合期望, 请重新输入")
 			continue
 		}
 		break
@@ -48,3 +57,8 @@ func UI(ask string, defaultAnswer string, runner func(bool)) {
 		runner(false)
 	}
 }
+
+// Prompt 输出交互提示, 不受日志级别与格式影响
+func Prompt(w io.Writer, text string) {
+	fmt.Fprint(w, Paint(w, UserInterface, text)+NewLine)
+}
@@ -1,8 +1,10 @@
 package config
 
 var (
-	GBCGitTemplate  = "git@github.com:zjutjh/gbc-template.git"
-	GBCForGoInstall = "github.com/zjutjh/gbc@latest"
+	GBCGitTemplate      = "git@github.com:zjutjh/gbc-template.git"
+	GBCGitTemplateHTTPS = "https://github.com/zjutjh/gbc-template.git"
+	GBCModulePath       = "github.com/zjutjh/gbc"
 
-	GithubGetTagsAPI = "https://api.github.com/repos/zjutjh/gbc/tags"
+	// GithubRepoAPI gbc仓库的GitHub API地址, 可通过环境变量 GBC_GITHUB_API 替换
+	GithubRepoAPI = "https://api.github.com/repos/zjutjh/gbc"
 )
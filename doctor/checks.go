@@ -0,0 +1,177 @@
+package doctor
+
+import (
+	"bytes"
+	"fmt"
+	goversion "go/version"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/release"
+)
+
+// defaultGoVersion 不在项目目录中时要求的最低Go版本, 与gbc-template保持一致
+const defaultGoVersion = "1.24.7"
+
+func init() {
+	Register(&Check{Name: "git", Desc: "已安装git", Run: checkGit})
+	Register(&Check{Name: "github-ssh", Desc: "可通过SSH访问GitHub", Run: checkGithubSSH})
+	Register(&Check{Name: "go-version", Desc: "Go版本满足项目要求", Run: checkGoVersion})
+	Register(&Check{Name: "go-bin-path", Desc: "go install 的安装目录在PATH中", Run: checkGoBinPath})
+	Register(&Check{Name: "mygo-version", Desc: "项目依赖的mygo提供gbc生成代码所需的API", Project: true, Run: checkMygoVersion})
+	Register(&Check{Name: "generate-dir", Desc: "项目中存在业务状态码注册文件", Project: true, Run: checkGenerateDir, Fix: fixCodegen})
+	Register(&Check{Name: "generate-fresh", Desc: "业务状态码注册文件与代码一致", Project: true, Run: checkGenerateFresh, Fix: fixCodegen})
+}
+
+func pass(format string, a ...any) Result {
+	return Result{Status: StatusPass, Message: i18n.Sprintf(format, a...)}
+}
+
+func warn(hint, format string, a ...any) Result {
+	return Result{Status: StatusWarn, Message: i18n.Sprintf(format, a...), Hint: i18n.T(hint)}
+}
+
+func fail(hint, format string, a ...any) Result {
+	return Result{Status: StatusFail, Message: i18n.Sprintf(format, a...), Hint: i18n.T(hint)}
+}
+
+func output(name string, args ...string) (string, error) {
+	return outputEnv(nil, name, args...)
+}
+
+// outputEnv 在当前环境变量的基础上追加env执行命令
+func outputEnv(env []string, name string, args ...string) (string, error) {
+	c := exec.Command(name, args...)
+	if len(env) > 0 {
+		c.Env = append(os.Environ(), env...)
+	}
+	out, err := c.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+func checkGit(env *Env) Result {
+	out, err := output("git", "--version")
+	if err != nil {
+		return fail("安装git: https://git-scm.com/downloads", "未找到git")
+	}
+	return pass("%s", out)
+}
+
+func checkGithubSSH(env *Env) Result {
+	c := exec.Command("ssh", "-T", "-o", "BatchMode=yes", "-o", "ConnectTimeout=5", "git@github.com")
+	// 认证成功时GitHub返回非零退出码并提示 successfully authenticated
+	out, _ := c.CombinedOutput()
+	if bytes.Contains(out, []byte("successfully authenticated")) {
+		return pass("SSH认证成功")
+	}
+	return warn("执行 ssh-keygen -t ed25519 生成密钥并将公钥添加到 https://github.com/settings/keys; 无法使用SSH时 gbc new 会回退到HTTPS或内置模板",
+		"无法通过SSH访问GitHub")
+}
+
+func checkGoVersion(env *Env) Result {
+	// 禁止按go.mod自动切换工具链, 取本地安装的Go版本
+	have, err := outputEnv([]string{"GOTOOLCHAIN=local"}, "go", "env", "GOVERSION")
+	if err != nil {
+		return fail("安装Go: https://go.dev/dl/", "未找到go命令")
+	}
+	want := "go" + defaultGoVersion
+	if f := env.GoMod(); f != nil && f.Go != nil {
+		want = "go" + f.Go.Version
+	}
+	if goversion.Compare(have, want) >= 0 {
+		return pass("%s (要求 >= %s)", have, want)
+	}
+	toolchain, _ := output("go", "env", "GOTOOLCHAIN")
+	if toolchain != "local" && env.GoMod() != nil {
+		return warn(i18n.Sprintf("升级Go到%s以上, 避免每次构建自动下载工具链", want),
+			"本地Go版本%s低于项目要求的%s, 将由GOTOOLCHAIN=%s自动下载", have, want, toolchain)
+	}
+	return fail(i18n.Sprintf("升级Go到%s以上: https://go.dev/dl/", want), "本地Go版本%s低于要求的%s", have, want)
+}
+
+func checkGoBinPath(env *Env) Result {
+	bin, err := output("go", "env", "GOBIN")
+	if err != nil {
+		return fail("安装Go: https://go.dev/dl/", "未找到go命令")
+	}
+	if bin == "" {
+		gopath, _ := output("go", "env", "GOPATH")
+		paths := filepath.SplitList(gopath)
+		if len(paths) == 0 {
+			return warn("通过 go env -w GOBIN=<目录> 指定安装目录", "GOBIN与GOPATH均未设置, 无法确定 go install 的安装目录")
+		}
+		bin = filepath.Join(paths[0], "bin")
+	}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if filepath.Clean(dir) == filepath.Clean(bin) {
+			return pass("%s", bin)
+		}
+	}
+	return warn(i18n.Sprintf("将 export PATH=\"$PATH:%s\" 添加到shell配置文件中", bin),
+		"%s 不在PATH中, go install 安装的gbc无法直接执行", bin)
+}
+
+func checkMygoVersion(env *Env) Result {
+	api, err := release.LoadMygoAPI(env.Dir)
+	if err != nil {
+		return warn("执行 go mod download 下载依赖", "无法读取项目依赖的mygo源码: %s", err.Error())
+	}
+	outdated := make([]string, 0)
+	for _, f := range release.Features {
+		if missing := api.Missing(&f); len(missing) > 0 {
+			outdated = append(outdated, fmt.Sprintf("%s(%s)", f.Name, strings.Join(missing, ", ")))
+		}
+	}
+	if len(outdated) > 0 {
+		return warn(i18n.Sprintf("执行 go get %s@latest 升级", release.MygoModulePath),
+			"mygo %s 缺少以下功能所需的API: %s", api.Version, strings.Join(outdated, "; "))
+	}
+	return pass("mygo %s", api.Version)
+}
+
+func checkGenerateDir(env *Env) Result {
+	path := filepath.Join(env.generatePath(), analysis.GeneratedFileName)
+	if _, err := os.Stat(path); err != nil {
+		return fail("执行 gbc codegen 生成", "缺少 %s", filepath.Join(env.GenerateDir, analysis.GeneratedFileName))
+	}
+	return pass("%s", filepath.Join(env.GenerateDir, analysis.GeneratedFileName))
+}
+
+func checkGenerateFresh(env *Env) Result {
+	current, err := os.ReadFile(filepath.Join(env.generatePath(), analysis.GeneratedFileName))
+	if err != nil {
+		return Result{Status: StatusSkip, Message: i18n.T("业务状态码注册文件不存在")}
+	}
+	// 生成到临时目录中与现有文件比较, 临时目录的最后一级与注册目录同名以保证包名一致
+	tmp, err := os.MkdirTemp("", "gbc-doctor-*")
+	if err != nil {
+		return warn("", "创建临时目录失败: %s", err.Error())
+	}
+	defer os.RemoveAll(tmp)
+	outDir := filepath.Join(tmp, filepath.Base(env.generatePath()))
+	if err := os.Mkdir(outDir, 0755); err != nil {
+		return warn("", "创建临时目录失败: %s", err.Error())
+	}
+	if err := env.Codegen(env.Dir, outDir); err != nil {
+		return warn("修复项目中的编译错误后重试", "分析代码失败: %s", err.Error())
+	}
+	fresh, err := os.ReadFile(filepath.Join(outDir, analysis.GeneratedFileName))
+	if err != nil {
+		return warn("", "读取生成结果失败: %s", err.Error())
+	}
+	if !bytes.Equal(current, fresh) {
+		return warn("执行 gbc codegen 重新生成", "业务状态码注册文件已过期")
+	}
+	return pass("与当前代码一致")
+}
+
+func fixCodegen(env *Env) error {
+	if err := os.MkdirAll(env.generatePath(), 0755); err != nil {
+		return err
+	}
+	return env.Codegen(env.Dir, env.generatePath())
+}
@@ -0,0 +1,38 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckGoBinPathWithoutGOPATH(t *testing.T) {
+	// 不读取用户的 go env -w 配置, 且无法从HOME推断默认的GOPATH
+	t.Setenv("GOENV", "off")
+	t.Setenv("GOBIN", "")
+	t.Setenv("GOPATH", "")
+	t.Setenv("HOME", "")
+	if r := checkGoBinPath(&Env{}); r.Status != StatusWarn {
+		t.Errorf("checkGoBinPath() = %+v, want warn", r)
+	}
+}
+
+func TestCheckGoVersionUsesLocalToolchain(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module app\n\ngo 1.999\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	// 工具链无法下载, 若按go.mod切换工具链则无法取得版本
+	t.Setenv("GOTOOLCHAIN", "auto")
+	t.Setenv("GOPROXY", "off")
+	local, err := outputEnv([]string{"GOTOOLCHAIN=local"}, "go", "env", "GOVERSION")
+	if err != nil {
+		t.Skipf("go not available: %v", err)
+	}
+	r := checkGoVersion(&Env{Dir: dir})
+	if r.Status == StatusPass || !strings.Contains(r.Message, local) || !strings.Contains(r.Message, "go1.999") {
+		t.Errorf("checkGoVersion() = %+v, want local %s below go1.999", r, local)
+	}
+}
@@ -0,0 +1,126 @@
+package doctor
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/release"
+)
+
+// Status 检查结果
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip" // 不适用, 如不在项目目录中时的项目检查
+)
+
+// Result 单项检查的结果
+type Result struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`    // 修复建议
+	Fixable bool   `json:"fixable,omitempty"` // 可通过 --fix 自动修复
+	Fixed   bool   `json:"fixed,omitempty"`   // 已通过 --fix 修复
+}
+
+// Check 一项可插拔的检查
+type Check struct {
+	Name    string
+	Desc    string
+	Project bool // 仅在项目目录中执行
+	Run     func(env *Env) Result
+	Fix     func(env *Env) error // 可选, 自动修复
+}
+
+// Env 检查的执行环境
+type Env struct {
+	Dir         string
+	GenerateDir string // 业务状态码注册文件所在目录, 相对于Dir
+	// Codegen 分析Dir下的项目并将业务状态码注册文件生成到outDir
+	Codegen func(dir, outDir string) error
+
+	goMod *modfile.File
+}
+
+// IsProject Dir是否为基于mygo的项目
+func (e *Env) IsProject() bool {
+	mygo, err := release.ProjectMygoVersion(e.Dir)
+	return err == nil && mygo != ""
+}
+
+// GoMod 项目的go.mod, 不存在时返回nil
+func (e *Env) GoMod() *modfile.File {
+	if e.goMod == nil {
+		e.goMod, _ = comm.ReadGoMod(e.Dir)
+	}
+	return e.goMod
+}
+
+func (e *Env) generatePath() string {
+	return filepath.Join(e.Dir, e.GenerateDir)
+}
+
+var checks []*Check
+
+// Register 注册检查项, 按注册顺序执行
+func Register(c *Check) {
+	for _, exist := range checks {
+		if exist.Name == c.Name {
+			panic(fmt.Sprintf("doctor: 检查项[%s]重复注册", c.Name))
+		}
+	}
+	checks = append(checks, c)
+}
+
+// Checks 已注册的全部检查项
+func Checks() []*Check {
+	return checks
+}
+
+// Run 执行全部检查, fix为true时对未通过且支持修复的检查项进行修复并重新检查
+func Run(env *Env, fix bool) []Result {
+	project := env.IsProject()
+	results := make([]Result, 0, len(checks))
+	for _, c := range checks {
+		if c.Project && !project {
+			results = append(results, Result{Name: c.Name, Status: StatusSkip, Message: i18n.T("当前目录不是mygo项目")})
+			continue
+		}
+		r := runCheck(c, env)
+		if fix && r.Fixable && (r.Status == StatusWarn || r.Status == StatusFail) {
+			if err := c.Fix(env); err != nil {
+				r.Message = i18n.Sprintf("%s (自动修复失败: %s)", r.Message, err.Error())
+			} else {
+				r = runCheck(c, env)
+				r.Fixed = r.Status == StatusPass
+			}
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func runCheck(c *Check, env *Env) Result {
+	r := c.Run(env)
+	r.Name = c.Name
+	r.Fixable = c.Fix != nil && r.Status != StatusPass && r.Status != StatusSkip
+	return r
+}
+
+// Failed 是否存在未通过的检查
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,508 @@
+package i18n
+
+// en 英文消息目录
+var en = map[string]string{
+	"读取go.mod失败: %w":                       "failed to read go.mod: %w",
+	"解析go.mod失败: %w":                       "failed to parse go.mod: %w",
+	"go.mod中缺少module声明":                    "go.mod is missing the module directive",
+	"不支持的日志格式[%s], 可选的值有: text、json":       "unsupported log format [%s], valid values: text, json",
+	"打开日志文件失败: %w":                         "failed to open log file: %w",
+	"key不能为空":                              "key must not be empty",
+	"key不能以[.]或[/]结尾":                      "key must not end with [.] or [/]",
+	"key[%s]中存在空的路径段":                      "key [%s] contains an empty path segment",
+	"key[%s]中不允许出现[..]":                    "key [%s] must not contain [..]",
+	"key[%s]的路径段[%s]中存在非法字符":               "path segment [%[2]s] of key [%[1]s] contains illegal characters",
+	"key[%s]无法生成有效的名称":                     "key [%s] does not produce a valid name",
+	"名称[%s]不是合法的Go标识符":                     "name [%s] is not a valid Go identifier",
+	"包名[%s]不是合法的Go包名":                      "package name [%s] is not a valid Go package name",
+	"目录[%s]中已有文件声明为包[%s], 与生成的包名[%s]不一致":   "files in directory [%s] already declare package [%s], which differs from the generated package name [%s]",
+	"创建目录[%s]失败: %w":                       "failed to create directory [%s]: %w",
+	"路径[%s]已存在且不是一个目录":                     "path [%s] already exists and is not a directory",
+	"输入发生错误: %s, 请重新输入":                    "input error: %s, please try again",
+	"输入不符合期望, 请重新输入":                       "unexpected input, please try again",
+	"开始分析":                                 "analysis started",
+	"结束分析":                                 "analysis finished",
+	"加载软件包":                                "loading packages",
+	"软件包中存在错误":                             "packages contain errors",
+	"成功加载 %d 个起始软件包，开始构建程序":                "loaded %d initial packages, building program",
+	"构建完成，计算函数调用图（算法：%s）":                  "build finished, computing call graph (algorithm: %s)",
+	"无效的分析调用图算法类型：%s":                      "invalid call graph algorithm: %s",
+	"调用图中存在 %d 个节点":                        "call graph has %d nodes",
+	"开始生成 status_codes_generated.go 文件":    "generating status_codes_generated.go",
+	"生成文件失败: %w":                           "failed to generate file: %w",
+	"格式化代码失败: %w":                          "failed to format code: %w",
+	"文件路径：%s":                              "file path: %s",
+	"打开文件失败: %w":                           "failed to open file: %w",
+	"写入文件失败: %w":                           "failed to write file: %w",
+	"结束生成文件":                               "file generation finished",
+	"查找所有 gin HTTP 处理器":                    "finding all gin HTTP handlers",
+	"找到 %d 个 gin HTTP 处理器":                 "found %d gin HTTP handlers",
+	"处理器 %s.%s 引用的状态码：%v":                  "status codes referenced by handler %s.%s: %v",
+	"解析模板失败: %w":                           "failed to parse template: %w",
+	"模板中不存在函数[%s]":                         "function [%s] does not exist in the template",
+	"命令函数仍为模板默认实现":                         "command function still has the template default implementation",
+	"Run仍为模板默认实现":                          "Run still has the template default implementation",
+	"Info标签仍为模板默认值":                        "Info tags still have the template default values",
+	"模块代理中不存在该模块":                          "module not found on the module proxy",
+	"GOPROXY=off, 禁止访问模块代理":                "GOPROXY=off, module proxy access is disabled",
+	"解析@latest响应失败: %w":                    "failed to parse @latest response: %w",
+	"读取仓库tag失败: %w":                        "failed to read repository tags: %w",
+	"版本号[%s]不合法: %w":                       "invalid version [%s]: %w",
+	"版本[%s]不存在":                            "version [%s] does not exist",
+	"未找到可用的版本":                             "no available version found",
+	"已安装git":                               "git is installed",
+	"可通过SSH访问GitHub":                       "GitHub is reachable over SSH",
+	"Go版本满足项目要求":                           "Go version satisfies the project requirement",
+	"go install 的安装目录在PATH中":               "go install directory is in PATH",
+	"项目中存在业务状态码注册文件":                       "project has a business status code registry file",
+	"业务状态码注册文件与代码一致":                       "business status code registry file matches the code",
+	"安装git: https://git-scm.com/downloads": "install git: https://git-scm.com/downloads",
+	"未找到git":                               "git not found",
+	"SSH认证成功":                              "SSH authentication succeeded",
+	"执行 ssh-keygen -t ed25519 生成密钥并将公钥添加到 https://github.com/settings/keys; 无法使用SSH时 gbc new 会回退到HTTPS或内置模板": "run ssh-keygen -t ed25519 to generate a key and add the public key at https://github.com/settings/keys; without SSH, gbc new falls back to HTTPS or the embedded template",
+	"无法通过SSH访问GitHub":          "cannot reach GitHub over SSH",
+	"安装Go: https://go.dev/dl/": "install Go: https://go.dev/dl/",
+	"未找到go命令":                  "go command not found",
+	"%s (要求 >= %s)":            "%s (requires >= %s)",
+	"升级Go到%s以上, 避免每次构建自动下载工具链":                                      "upgrade Go to %s or later to avoid downloading a toolchain on every build",
+	"本地Go版本%s低于项目要求的%s, 将由GOTOOLCHAIN=%s自动下载":                       "local Go version %s is lower than the project's required %s; GOTOOLCHAIN=%s will download it automatically",
+	"升级Go到%s以上: https://go.dev/dl/":                                 "upgrade Go to %s or later: https://go.dev/dl/",
+	"本地Go版本%s低于要求的%s":                                               "local Go version %s is lower than the required %s",
+	"将 export PATH=\"$PATH:%s\" 添加到shell配置文件中":                      "add export PATH=\"$PATH:%s\" to your shell profile",
+	"%s 不在PATH中, go install 安装的gbc无法直接执行":                           "%s is not in PATH, gbc installed by go install cannot be run directly",
+	"执行 go get %s@latest 升级":                                        "run go get %s@latest to upgrade",
+	"执行 gbc codegen 生成":                                             "run gbc codegen to generate it",
+	"缺少 %s":                                                         "missing %s",
+	"业务状态码注册文件不存在":                                                  "business status code registry file does not exist",
+	"创建临时目录失败: %s":                                                  "failed to create temporary directory: %s",
+	"修复项目中的编译错误后重试":                                                 "fix the compile errors in the project and retry",
+	"分析代码失败: %s":                                                    "failed to analyze code: %s",
+	"读取生成结果失败: %s":                                                  "failed to read generated output: %s",
+	"执行 gbc codegen 重新生成":                                           "run gbc codegen to regenerate it",
+	"业务状态码注册文件已过期":                                                  "business status code registry file is out of date",
+	"与当前代码一致":                                                       "up to date with the current code",
+	"doctor: 检查项[%s]重复注册":                                           "doctor: check [%s] registered twice",
+	"当前目录不是mygo项目":                                                  "current directory is not a mygo project",
+	"%s (自动修复失败: %s)":                                               "%s (automatic fix failed: %s)",
+	"检查XxxApi的Info字段是否具有非空的name与desc标签":                             "check that the Info field of XxxApi has non-empty name and desc tags",
+	"%s缺少Info字段, 应声明 Info struct{} `name:\"API名称\" desc:\"API描述\"`": "%s is missing the Info field, declare Info struct{} `name:\"API名称\" desc:\"API描述\"`",
+	"%s的Info字段缺少非空的%s标签":                                            "the Info field of %s is missing a non-empty %s tag",
+	"收集包级 kit.NewCode 业务状态码变量":                                      "collect package-level kit.NewCode business status code variables",
+	"检查XxxApi.Run返回已声明的业务状态码变量而非kit.Code字面量":                        "check that XxxApi.Run returns declared business status code variables instead of kit.Code literals",
+	"Run应返回已声明的业务状态码变量, 而不是kit.Code字面量":                             "Run should return a declared business status code variable, not a kit.Code literal",
+	"Run中不应临时构造业务状态码, 请在comm中声明后返回该变量":                              "Run should not construct business status codes ad hoc; declare it in comm and return that variable",
+	"替换为 %s": "replace with %s",
+	"检查XxxJob.Run中未被recover的panic与Fatal/Panic日志调用":       "check XxxJob.Run for unrecovered panics and Fatal/Panic log calls",
+	"定时任务%s.Run中不应panic, 请记录日志后返回":                       "cron job %s.Run should not panic; log the error and return",
+	"定时任务%s.Run中不应调用%s, 该调用会panic或退出进程":                  "cron job %s.Run should not call %s, which panics or exits the process",
+	"检查XxxApi.Init是否绑定了Request中声明的Uri/Header/Query/Body": "check that XxxApi.Init binds the Uri/Header/Query/Body declared in Request",
+	"%s.Init未绑定Request中声明的%s":                            "%s.Init does not bind %s declared in Request",
+	"绑定%s":                                               "bind %s",
+	"检查XxxApi.Run写入响应后未调用ctx.Abort*便返回":                  "check XxxApi.Run for returning without calling ctx.Abort* after writing the response",
+	"Run已在第%d行自行写入响应, 未中止请求时入口函数会再次写入响应, 请在返回前调用 ctx.Abort() 或改用 ctx.Abort* 系列方法写入": "Run already writes the response at line %d; unless the request is aborted the handler writes the response again, call ctx.Abort() before returning or write it with the ctx.Abort* methods",
+	"读取归档失败: %w": "failed to read archive: %w",
+	"目录[%s]已存在且不为空, 如需覆盖请使用 --force":                  "directory [%s] already exists and is not empty, use --force to overwrite",
+	"创建临时目录失败: %w":                                    "failed to create temporary directory: %w",
+	"清理临时目录[%s]失败: %s":                                "failed to clean up temporary directory [%s]: %s",
+	"使用模板来源[%s]":                                      "using template source [%s]",
+	"获取模板失败: %w":                                      "failed to fetch template: %w",
+	"获取模板失败: %s, 尝试使用[%s]":                            "failed to fetch template: %s, trying [%s]",
+	"未指定模板来源":                                         "no template source specified",
+	"执行 go mod tidy":                                  "running go mod tidy",
+	"执行go mod tidy失败: %w":                             "go mod tidy failed: %w",
+	"执行 gbc codegen":                                  "running gbc codegen",
+	"执行gbc codegen失败: %w":                             "gbc codegen failed: %w",
+	"初始化git仓库":                                        "initializing git repository",
+	"执行git %s失败 (请确认已配置git user.name/user.email): %w": "git %s failed (make sure git user.name/user.email are configured): %w",
+	"移动已存在的目录[%s]失败: %w":                              "failed to move existing directory [%s]: %w",
+	"移动项目到目录[%s]失败: %w":                               "failed to move project to directory [%s]: %w",
+	"# 由gbc生成, 记录项目所基于的模板版本, 供 gbc sync-template 使用, 请勿手动修改\n": "# Generated by gbc. Records the template version the project is based on, used by gbc sync-template. Do not edit.\n",
+	"项目中不存在%s, 仅支持同步由 gbc new 创建并保留了锁文件的项目":                    "%s does not exist in the project; only projects created by gbc new that kept the lock file can be synced",
+	"解析%s失败: %w":                       "failed to parse %s: %w",
+	"%s中缺少模板来源":                        "%s is missing the template source",
+	"%s中存在未命名的组件":                      "%s contains an unnamed component",
+	"%s中组件[%s]重复声明":                    "component [%[2]s] is declared more than once in %[1]s",
+	"模板中不存在组件[%s], 可选的组件有: %s":         "component [%s] does not exist in the template, available components: %s",
+	"组件[%s]同时出现在 --with 与 --without 中": "component [%s] appears in both --with and --without",
+	"移除组件[%s]":                         "removing component [%s]",
+	"处理文件[%s]中的组件片段失败: %w":             "failed to process component fragments in file [%s]: %w",
+	"组件[%s]的片段缺少结束标记":                  "fragment of component [%s] is missing the end marker",
+	"改写模块路径[%s]为[%s]":                  "rewriting module path [%s] to [%s]",
+	"模块路径[%s]不合法: %w":                  "invalid module path [%s]: %w",
+	"解析文件[%s]失败: %w":                   "failed to parse file [%s]: %w",
+	"格式化文件[%s]失败: %w":                  "failed to format file [%s]: %w",
+	"改写配置文件[%s]中的应用名称":                 "rewriting the application name in config file [%s]",
+	"无法识别的模板来源[%s]: %w":                "unrecognized template source [%s]: %w",
+	"无法识别的模板来源[%s]: 不是目录或归档文件":         "unrecognized template source [%s]: not a directory or archive",
+	"模板来源[%s]不是git仓库, 不支持指定ref":        "template source [%s] is not a git repository, ref is not supported",
+	"不支持的模板来源类型: %s":                   "unsupported template source type: %s",
+	"拉取模板仓库[%s]":                       "cloning template repository [%s]",
+	"拉取模板仓库[%s]错误: %w":                 "failed to clone template repository [%s]: %w",
+	"切换模板版本[%s]":                       "checking out template version [%s]",
+	"切换模板版本[%s]错误: %w":                 "failed to check out template version [%s]: %w",
+	"获取模板版本错误: %w":                     "failed to get template version: %w",
+	"下载模板归档[%s]":                       "downloading template archive [%s]",
+	"下载模板归档[%s]错误: %w":                 "failed to download template archive [%s]: %w",
+	"下载模板归档[%s]错误: Status Code[%d|%s]": "failed to download template archive [%s]: Status Code[%d|%s]",
+	"更新":            "update",
+	"新增":            "add",
+	"删除":            "delete",
+	"合并":            "merge",
+	"冲突":            "conflict",
+	"拒绝":            "reject",
+	"保留":            "keep",
+	"获取基准模板[%s@%s]": "fetching base template [%s@%s]",
+	"获取基准模板失败, 将按两方比较处理: %s": "failed to fetch base template, falling back to two-way comparison: %s",
+	"获取新模板[%s]":      "fetching new template [%s]",
+	"同步文件[%s]失败: %w": "failed to sync file [%s]: %w",
+	"基准模板中的文件[%s]与锁文件记录不一致, 不作为合并基准": "file [%s] in the base template does not match the lock file, not using it as merge base",
+	"当前项目":                   "current project",
+	"原模板":                    "base template",
+	"新模板":                    "new template",
+	"执行git merge-file失败: %w": "git merge-file failed: %w",
+	"%s对应的文件[%s]不存在":         "file [%[2]s] for %[1]s does not exist",
+	"%s对应的文件[%s]已存在":         "file [%[2]s] for %[1]s already exists",
+	"%s 仍引用了 %s, 请手动处理":      "%s still references %s, please fix it manually",
+	"改写文件[%s]中对%s的引用":        "rewriting references to %[2]s in file [%[1]s]",
+	"删除文件: %s":               "delete file: %s",
+	"写入文件: %s":               "write file: %s",
+	"写入文件[%s]失败: %w":         "failed to write file [%s]: %w",
+	"删除文件[%s]失败: %w":         "failed to delete file [%s]: %w",
+	"移除文件[%s]中 %d 处对%s的引用":   "removing %[2]d references to %[3]s in file [%[1]s]",
+	"%s 仍引用了 %s.%s, 请手动处理":   "%s still references %s.%s, please fix it manually",
+	"重命名或移动API/Command/Cron": "Rename or move an API/Command/Cron",
+	"重命名API/Command/Cron的标识符与文件, 跨包移动时修正导入, 改写router/register中的引用, 并重新生成业务状态码": "rename the identifier and file of an API/Command/Cron, fix imports when moving across packages, rewrite references in router/register, and regenerate business status codes",
+	"加载项目失败: %w":               "failed to load project: %w",
+	"解析key失败: %w":              "failed to parse key: %w",
+	"移动%s失败: %w":               "failed to move %s: %w",
+	"仅展示将要进行的修改, 不写入文件":        "only show the changes to be made, do not write files",
+	"修改完成后不重新生成业务状态码":          "do not regenerate business status codes after the change",
+	"删除API/Command/Cron及其注册代码": "Delete an API/Command/Cron and its registration code",
+	"删除API/Command/Cron文件, 移除router/register中的注册调用与不再使用的导入, 并重新生成业务状态码": "delete the file of an API/Command/Cron, remove its registration calls and unused imports in router/register, and regenerate business status codes",
+	"删除%s失败: %w":         "failed to delete %s: %w",
+	"dry-run模式, 未写入任何修改": "dry-run mode, no changes written",
+	"重新生成业务状态码失败: %w":    "failed to regenerate business status codes: %w",
+	"生成业务状态码":            "Generate business status codes",
+	"初始化失败: %w":          "initialization failed: %w",
+	"分析代码失败: %w":         "failed to analyze code: %w",
+	"解析处理器 %v 的状态码失败：%w": "failed to parse status codes of handler %v: %w",
+	"生成业务状态码文件失败: %w":    "failed to generate business status code file: %w",
+	"生成文件存储目录":           "output directory for generated files",
+	"要使用的构造函数调用图的算法。可选的值有：static、cha、rta":       "call graph algorithm used to construct the call graph. Valid values: static, cha, rta",
+	"是否跳过合成边（synthetic edge，即通过reflect等动态调用方式）": "whether to skip synthetic edges (dynamic calls such as reflect)",
+	"编译时的build tag": "build tags used for compilation",
+	"是否显示最外层接口到状态码的引用关系（仅在调试时使用）": "show references from top-level handlers to status codes (debug only)",
+	"检查开发环境与项目的健康状况":              "Check the health of the development environment and the project",
+	"检查开发环境与项目的健康状况\n\n环境检查: git、GitHub SSH访问、Go版本、go install 安装目录是否在PATH中\n项目检查 (在项目目录中执行时): mygo版本、业务状态码注册文件是否存在且与代码一致\n每项检查的结果为 pass、warn 或 fail, 未通过时给出修复建议, 部分检查支持通过 --fix 自动修复\n存在fail时以非零状态码退出": "Check the health of the development environment and the project\n\nEnvironment checks: git, GitHub SSH access, Go version, whether the go install directory is in PATH\nProject checks (when run in a project directory): mygo version, whether the business status code registry file exists and matches the code\nEach check results in pass, warn or fail, with a suggested fix when it does not pass; some checks can be fixed automatically with --fix\nExits with a non-zero status when any check fails",
+	"不支持的输出格式[%s], 可选的值有: text、json": "unsupported output format [%s], valid values: text, json",
+	"存在未通过的检查":                       "some checks did not pass",
+	" (已修复)":                         " (fixed)",
+	" (支持 --fix)":                    " (--fix supported)",
+	"       修复建议: %s":                "       suggested fix: %s",
+	"自动修复支持修复的问题, 如创建目录、执行codegen": "automatically fix supported problems, such as creating directories and running codegen",
+	"输出格式: text、json":   "output format: text, json",
+	"检查项目代码是否符合gbc模板约定": "Check that project code follows gbc template conventions",
+	"检查项目代码是否符合gbc模板约定, 默认检查 ./...\n\n  apiinfo      XxxApi的Info字段须具有非空的name与desc标签\n  coderet      XxxApi.Run须返回已声明的业务状态码变量, 不能返回字面量\n  doublereply  XxxApi.Run自行写入响应后须调用ctx.Abort*再返回\n  cronpanic    XxxJob.Run中不能panic\n  initbind     XxxApi.Init须绑定Request中声明的Uri/Header/Query/Body\n\n部分问题提供修复建议, 可通过 --fix 自动修复\n上述检查同样以 go vet 工具的形式提供: go vet -vettool=$(which gbc-vet) ./...": "Check that project code follows gbc template conventions, checks ./... by default\n\n  apiinfo      the Info field of XxxApi must have non-empty name and desc tags\n  coderet      XxxApi.Run must return declared business status code variables, not literals\n  doublereply  XxxApi.Run must call ctx.Abort* before returning after writing the response itself\n  cronpanic    XxxJob.Run must not panic\n  initbind     XxxApi.Init must bind the Uri/Header/Query/Body declared in Request\n\nSome problems come with suggested fixes that can be applied with --fix\nThe same checks are available as a go vet tool: go vet -vettool=$(which gbc-vet) ./...",
+	"检查代码失败: %w": "failed to check code: %w",
+	"修复文件[%s]":   "fixing file [%s]",
+	"应用修复失败: %w": "failed to apply fixes: %w",
+	"未发现问题":      "no problems found",
+	"发现%d个问题":    "found %d problems",
+	"自动应用修复建议":   "automatically apply suggested fixes",
+	"列出项目中的API、定时任务、命令与业务状态码":          "List APIs, cron jobs, commands and business status codes in the project",
+	"列出项目中由gbc脚手架生成的API、定时任务、命令与业务状态码": "list APIs, cron jobs, commands and business status codes generated by gbc scaffolding in the project",
+	"不支持的输出格式[%s], 可选的值有: table、json":  "unsupported output format [%s], valid values: table, json",
+	"输出格式。可选的值有：table、json":            "output format. Valid values: table, json",
+	"创建新项目模板": "Create a new project from a template",
+	"创建新项目模板\n\n--template 支持以下模板来源:\n  git仓库地址 (HTTPS/SSH), 可配合 --ref 指定tag/branch/commit\n  本地目录或本地git裸仓库\n  .tar.gz/.tgz/.zip 归档 (本地路径或HTTP地址)\n  embed 使用gbc内置的gbc-template快照, 无需访问模板仓库; 依赖版本由快照中的go.sum固定, 模块本身仍需通过GOPROXY或本地模块缓存获取\n\n--with/--without 与组件询问依赖模板根目录中的 gbc.template.yaml, 没有该文件的模板不支持选择组件\n模板须在其中声明每个可选组件 (如 cmd、cron、mysql、redis、swagger) 独占的文件以及含有其注册片段的文件:\n  components:\n    - name: redis\n      desc: Redis客户端\n      default: true\n      files: [register/redis.go]\n      snippets: [main.go]  # 以 // gbc:begin redis 与 // gbc:end redis 包围的代码\n\n项目在临时目录中创建, 全部步骤成功后才移动到目标目录, 任一步骤失败不会留下残缺的目录\n项目中的 gbc.lock.yaml 记录了所用模板的版本与文件摘要, 供 gbc sync-template 合并后续的模板更新": "Create a new project from a template\n\n--template supports the following template sources:\n  git repository URL (HTTPS/SSH), optionally with --ref for a tag/branch/commit\n  local directory or local bare git repository\n  .tar.gz/.tgz/.zip archive (local path or HTTP URL)\n  embed, the gbc-template snapshot built into gbc; no access to the template repository is needed; dependency versions are pinned by the go.sum in the snapshot, but the modules themselves still come from GOPROXY or the local module cache\n\n--with/--without and the component prompts rely on gbc.template.yaml in the template root; templates without it do not support selecting components\nThe template must declare in it the files owned by each optional component (such as cmd, cron, mysql, redis, swagger) and the files containing its registration snippets:\n  components:\n    - name: redis\n      desc: Redis client\n      default: true\n      files: [register/redis.go]\n      snippets: [main.go]  # code enclosed by // gbc:begin redis and // gbc:end redis\n\nThe project is created in a temporary directory and moved to the target directory only after all steps succeed; a failed step never leaves a partial directory behind\ngbc.lock.yaml in the project records the template version and file digests, used by gbc sync-template to merge later template updates",
+	"获取当前工作目录失败: %w":                                "failed to get current working directory: %w",
+	"获取path[%s]绝对路径失败: %w":                          "failed to get absolute path of [%s]: %w",
+	"创建项目[%s]到目录[%s]开始...":                          "creating project [%s] in directory [%s]...",
+	"创建项目[%s]成功":                                    "project [%s] created",
+	"是否启用组件[%s](%s)? (y(default)|n):":               "enable component [%s] (%s)? (y(default)|n):",
+	"是否启用组件[%s](%s)? (y|n(default)):":               "enable component [%s] (%s)? (y|n(default)):",
+	"项目模板来源: git仓库地址、本地目录、归档文件或embed":               "project template source: git repository URL, local directory, archive file or embed",
+	"git模板的tag/branch/commit":                       "tag/branch/commit of the git template",
+	"启用的模板可选组件, 如 cmd,cron":                         "optional template components to enable, e.g. cmd,cron",
+	"移除的模板可选组件, 如 cmd":                              "optional template components to remove, e.g. cmd",
+	"未通过 --with/--without 指定的组件使用模板默认值, 不再询问":       "use template defaults for components not given via --with/--without, without asking",
+	"项目的Go模块路径, 如 github.com/org/svc (默认沿用模板的模块路径)": "Go module path of the project, e.g. github.com/org/svc (defaults to the template's module path)",
+	"目标目录已存在且不为空时覆盖 (创建失败时恢复原目录)":                   "overwrite the target directory if it exists and is not empty (restored if creation fails)",
+	"创建完成后初始化git仓库并提交初始版本":                          "initialize a git repository and commit the initial version after creation",
+	"创建完成后执行 go mod tidy":                           "run go mod tidy after creation",
+	"创建完成后执行一次 gbc codegen":                         "run gbc codegen once after creation",
+	"精弘网络本地开发者工具":                                   "JH local developer tool",
+	"精弘网络本地开发者工具\n\n退出码:\n  0  执行成功\n  1  执行失败, 如检查未通过、存在合并冲突\n  2  用法错误, 如参数或选项不合法\n  3  环境错误, 如不在项目目录中、缺少git或go、网络不可用\n  4  代码分析错误, 如软件包加载或分析失败\n  5  文件读写错误": "JH local developer tool\n\nExit codes:\n  0  success\n  1  failure, e.g. checks did not pass or merge conflicts exist\n  2  usage error, e.g. invalid arguments or flags\n  3  environment error, e.g. not in a project directory, git or go missing, network unavailable\n  4  code analysis error, e.g. package loading or analysis failed\n  5  file I/O error",
+	"当前gbc工具本地版本号: %s":                                     "local gbc version: %s",
+	"执行发生错误: %s":                                           "error: %s",
+	"执行 %s --help 查看用法":                                    "run %s --help for usage",
+	"不支持的语言[%s], 可选的值有: zh-CN、en":                          "unsupported language [%s], valid values: zh-CN, en",
+	"展示更多过程信息进行调试, 等同于 -vv":                                "show more progress information for debugging, same as -vv",
+	"展示调试信息, -vv 额外展示子进程输出等更多过程信息":                         "show debug information, -vv additionally shows subprocess output and other details",
+	"展示gbc的版本号":                                            "show the gbc version",
+	"仅输出警告与错误":                                             "only output warnings and errors",
+	"禁用颜色, 也可设置环境变量 NO_COLOR":                              "disable colors, can also be set via the NO_COLOR environment variable",
+	"过程信息的输出格式: text、json (每行一个事件, 输出到标准错误)":               "progress output format: text, json (one event per line, written to standard error)",
+	"界面语言: zh-CN、en (默认根据环境变量 LC_ALL、LC_MESSAGES、LANG 选择)": "interface language: zh-CN, en (chosen from LC_ALL, LC_MESSAGES, LANG by default)",
+	"将包括调试信息在内的全部过程信息追加写入该文件":                              "append all progress information, including debug information, to this file",
+	"创建API模版": "Create an API from the template",
+	"接口是否存在body参数? (y|n(default)):":                     "does the API have body parameters? (y|n(default)):",
+	"接口是否存在query参数? (y|n(default)):":                    "does the API have query parameters? (y|n(default)):",
+	"接口是否存在header参数? (y|n(default)):":                   "does the API have header parameters? (y|n(default)):",
+	"接口是否存在uri参数? (y|n(default)):":                      "does the API have uri parameters? (y|n(default)):",
+	"创建API错误: %w":                                       "failed to create API: %w",
+	"创建API[%s]成功, 请记得前往./router/router.go中进行必要的API注册":   "API [%s] created, remember to register it in ./router/router.go",
+	"创建Command模板":                                       "Create a Command from the template",
+	"创建command错误: %w":                                   "failed to create command: %w",
+	"创建command[%s]成功, 请记得前往./register/cmd.go中进行必要的命令注册": "command [%s] created, remember to register it in ./register/cmd.go",
+	"创建Cron模版":                                          "Create a Cron from the template",
+	"创建cron错误: %w":                                      "failed to create cron: %w",
+	"创建cron[%s]成功, 请记得前往./register/cron.go中进行必要的定时任务注册": "cron [%s] created, remember to register it in ./register/cron.go",
+	"将新版本项目模板的改动合并到当前项目":                                "Merge changes from a newer project template into the current project",
+	"将新版本项目模板的改动合并到当前项目\n\n以 gbc.lock.yaml 中记录的模板版本为基准, 对每个文件在 原模板、当前项目、新模板 之间进行三方合并:\n  项目中未修改的文件直接更新为新模板\n  无冲突的合并直接写入\n  存在冲突的文件写入冲突标记, 无法按文本合并的文件将新模板内容写入 .rej 文件\n同步完成后更新锁文件": "Merge changes from a newer project template into the current project\n\nUsing the template version recorded in gbc.lock.yaml as the base, each file is merged three ways between the base template, the current project and the new template:\n  files not modified in the project are updated to the new template\n  merges without conflicts are written directly\n  files with conflicts get conflict markers; for files that cannot be merged as text, the new template content is written to a .rej file\nThe lock file is updated after syncing",
+	"同步模板失败: %w":                           "failed to sync template: %w",
+	"项目已与模板保持一致":                           "project is already in sync with the template",
+	"存在%d个文件需要手动处理冲突标记或 .rej 文件":           "%d files need manual resolution of conflict markers or .rej files",
+	"新模板来源 (默认使用锁文件中记录的来源)":                "new template source (defaults to the source recorded in the lock file)",
+	"新模板的git tag/branch/commit (默认使用最新版本)": "git tag/branch/commit of the new template (defaults to the latest version)",
+	"列出尚未实现的API、定时任务与命令":                   "List APIs, cron jobs and commands not yet implemented",
+	"列出仍保留gbc模板默认内容的API、定时任务与命令\n\n  - Run方法或命令函数的函数体与模板在语法树上一致 (忽略注释与格式)\n  - API的Info标签仍为模板默认的name/desc\n\n使用 --fail 时存在未实现的制品则以非0状态码退出, 可用于CI": "List APIs, cron jobs and commands that still contain gbc template defaults\n\n  - the body of the Run method or command function is syntactically identical to the template (ignoring comments and formatting)\n  - the Info tags of the API are still the template default name/desc\n\nWith --fail, exits with a non-zero status if anything is unimplemented, for use in CI",
+	"所有制品均已实现":                                  "all artifacts are implemented",
+	"存在%d处尚未实现的模板内容":                            "%d template scaffolds are not yet implemented",
+	"存在尚未实现的制品时以非0状态码退出":                        "exit with a non-zero status if anything is unimplemented",
+	"发现gbc新版本[%s], 当前版本[%s], 执行 gbc upgrade 升级": "new gbc version [%s] available, current version [%s], run gbc upgrade to upgrade",
+	"检查并升级gbc自身版本":                              "Check and upgrade gbc itself",
+	"检查并升级gbc自身版本\n\n默认升级到最新的正式版本, 可通过 --to 安装指定版本 (包括回退到旧版本), --prerelease 将 -rc 等预发布版本纳入候选\n版本列表默认通过Go模块代理协议获取, 遵循 GOPROXY、GONOPROXY、GOPRIVATE 配置\n升级前展示当前版本与目标版本之间的发布说明或提交记录, 默认尽力从GitHub获取, 无法访问GitHub时跳过\n使用 --github 改为通过GitHub API获取版本列表, 此时变更记录获取失败会给出警告\n\n执行其他命令时gbc每天最多在后台检查一次新版本, 并在命令结束后提示\n设置环境变量 GBC_NO_UPDATE_CHECK=1 可关闭该检查, 在CI (CI=true) 中或输出不是终端时自动关闭": "Check and upgrade gbc itself\n\nUpgrades to the latest stable version by default; use --to to install a specific version (including downgrading), --prerelease to include pre-release versions such as -rc\n\nThe version list is fetched via the Go module proxy protocol by default, honoring GOPROXY, GONOPROXY and GOPRIVATE\nBefore upgrading, the release notes or commits between the current and target versions are shown; they are fetched from GitHub on a best-effort basis and skipped when GitHub is unreachable\nUse --github to fetch the version list via the GitHub API instead; a failure to fetch the changelog is then reported as a warning\n\nWhen running other commands gbc checks for a new version in the background at most once a day and reports it after the command finishes\nSet GBC_NO_UPDATE_CHECK=1 to disable the check; it is disabled automatically in CI (CI=true) or when output is not a terminal",
+	"读取远程gbc版本错误: %w":              "failed to read remote gbc versions: %w",
+	"选择gbc版本错误: %w":                "failed to select gbc version: %w",
+	"无法识别本地gbc版本[%s], 按 v0.0.0 处理": "unrecognized local gbc version [%s], treating it as v0.0.0",
+	"当前gbc工具版本[%s]已是目标版本":          "gbc is already at the target version [%s]",
+	"当前gbc工具版本[%s]为最新版本":           "gbc version [%s] is the latest",
+	"升级": "upgrade",
+	"回退": "downgrade",
+	"gbc工具将从版本[%s]%s到版本[%s]":        "gbc will %[2]s from version [%[1]s] to version [%[3]s]",
+	"dry-run模式, 将执行: go install %s": "dry-run mode, would run: go install %s",
+	"%sgbc工具版本失败: %w":               "failed to %s gbc: %w",
+	"版本%s完成":                        "%s finished",
+	"获取版本变更记录失败: %s":                "failed to get changelog: %s",
+	"变更记录:":                         "Changes:",
+	"回退将撤销以下变更:":                    "Downgrading will revert the following changes:",
+	"安装指定版本, 如 v1.2.0 (可低于当前版本以回退)": "install a specific version, e.g. v1.2.0 (may be lower than the current version to downgrade)",
+	"将 -rc 等预发布版本纳入候选":              "include pre-release versions such as -rc",
+	"仅展示目标版本与将执行的安装命令, 可获取时一并展示变更记录, 不执行安装": "only show the target version, the install command and, when available, the changelog; do not install",
+	"通过GitHub API而非Go模块代理获取版本列表":            "fetch the version list via the GitHub API instead of the Go module proxy",
+	"查看gbc版本、构建信息及与mygo的兼容性":                "Show gbc version, build info and mygo compatibility",
+	"\t项目mygo[%s]": "\tPROJECT MYGO[%s]",
+	"兼容":           "compatible",
+	"以JSON格式输出":    "output as JSON",
+	"{$ApiStruct}Handler API router注册点": "{$ApiStruct}Handler API router registration point",
+	"API请求参数 (Uri/Header/Query/Body)":   "API request parameters (Uri/Header/Query/Body)",
+	"API响应数据 (Body中的Data部分)":            "API response data (the Data part of the Body)",
+	"Run Api业务逻辑执行点":                    "Run API business logic entry point",
+	"TODO: 在此处编写接口业务逻辑":                 "TODO: write the API business logic here",
+	"Init Api初始化 进行参数校验和绑定":             "Init API initialization, validates and binds parameters",
+	"hf{$ApiStruct} API执行入口":            "hf{$ApiStruct} API execution entry",
+	"TODO: 在此处编写命令业务逻辑":                 "TODO: write the command business logic here",
+	"TODO: 在此处编写定时任务业务逻辑":               "TODO: write the cron job business logic here",
+	"命令":   "command",
+	"定时任务": "cron job",
+	"仅使用静态调用边, 速度最快但会遗漏接口与闭包调用":  "static call edges only, fastest but misses interface and closure calls",
+	"类层次分析, 按方法签名解析所有可能的接口调用":    "class hierarchy analysis, resolves every possible interface call by method signature",
+	"快速类型分析, 仅考虑程序中实际创建的类型 (默认)": "rapid type analysis, only considers types actually created by the program (default)",
+	"不支持的制品类型[%s], 可选的值有: %s":    "unsupported artifact kind [%s], valid values: %s",
+	"预览":       "Preview",
+	"确认生成的文件":  "Confirm the generated file",
+	"写入文件":     "write file",
+	"重新填写":     "start over",
+	"取消":       "cancel",
+	"请求方法与路径":  "Method and path",
+	"HTTP请求方法": "HTTP method",
+	"路由路径, 可包含 :id 等路径参数": "route path, may contain path parameters such as :id",
+	"请求参数": "Request parameters",
+	"响应数据": "Response data",
+	"逐个填写响应数据 (Body中的Data部分) 的字段, 参数名称留空结束": "enter the fields of the response data (the Data part of the Body) one by one, leave the parameter name empty to finish",
+	"业务状态码":                   "Business status codes",
+	"API所在的包与名称":              "Package and name of the API",
+	"<新建包>":                   "<new package>",
+	"<api根目录>":                "<api root directory>",
+	"选择API所在的包":               "choose the package of the API",
+	"新包的路径, 如 user.admin":     "path of the new package, e.g. user.admin",
+	"包的路径不能为空":                "package path must not be empty",
+	"API名称, 如 login、get_info": "API name, e.g. login, get_info",
+	"文件[%s]已存在":               "file [%s] already exists",
+	"路由路径中的参数 %s 已加入Uri":      "path parameters %s were added to Uri",
+	"API需要哪些请求参数?":            "which request parameters does the API need?",
+	"逐个填写%s中的字段, 参数名称留空结束":    "enter the fields of %s one by one, leave the parameter name empty to finish",
+	"第%d个字段的参数名称 (%s标签)":      "parameter name of field %d (%s tag)",
+	"类型":    "type",
+	"是否必填?": "required?",
+	"项目中未找到业务状态码, 跳过":          "no business status codes found in the project, skipping",
+	"Run中可能返回哪些业务状态码?":         "which business status codes may Run return?",
+	"路由路径[%s]应以/开头且不能包含空白、?与#": "route path [%s] must start with / and must not contain whitespace, ? or #",
+	"参数名称[%s]中不能包含空白、引号与反引号":   "parameter name [%s] must not contain whitespace, quotes or backquotes",
+	"参数名称[%s]无法生成合法的Go字段名":     "parameter name [%s] does not produce a valid Go field name",
+	"字段[%s]已存在":                "field [%s] already exists",
+	"[%s]不是合法的Go类型":            "[%s] is not a valid Go type",
+	"向导已取消":                    "wizard canceled",
+	"请输入序号 [%d]:":              "enter a number [%d]:",
+	"请输入序号, 多个以逗号分隔 (直接回车不选):": "enter numbers separated by commas (press Enter to select none):",
+	"选项[%s]不存在, 请重新输入":         "option [%s] does not exist, please try again",
+	"可能返回的业务状态码:":              "business status codes that may be returned:",
+	"创建API模版\n\n使用 -i 启动交互式向导: 选择API所在的包并输入名称, 选择HTTP请求方法与路由路径,\n逐个填写请求参数与响应数据的字段, 选择Run中可能返回的业务状态码, 预览生成的文件后确认写入\n在终端中每一步开始前清屏, 选项列表通过方向键移动、空格勾选、回车确认\n标准输入不是终端时向导逐行读取, 选项以带序号的列表列出, 输入序号或选项文本进行选择, 因此也可以通过管道提供预先编写的输入": "Create an API from the template\n\nUse -i to start an interactive wizard: choose the package and enter the name of the API, choose the HTTP method and route path,\nenter the fields of the request parameters and response data one by one, choose the business status codes Run may return, then preview the generated file and confirm writing it\nIn a terminal the screen is cleared before each step, and option lists are navigated with the arrow keys, toggled with space and confirmed with enter\nWhen standard input is not a terminal the wizard reads it line by line and shows options as numbered lists to choose by number or option text, so prepared answers can also be piped in",
+	"读取项目中的业务状态码失败, 将跳过业务状态码的选择: %s":    "failed to read business status codes of the project, skipping their selection: %s",
+	"参考注册代码: r.%s(%q, %s.%sHandler())":  "suggested registration: r.%s(%q, %s.%sHandler())",
+	"使用交互式向导创建API":                      "create the API with an interactive wizard",
+	"注册路由[%s %s]失败: %s":                 "failed to register route [%s %s]: %s",
+	"路由[%s %s]不会返回业务状态码[%s], 可选的值有: %s": "route [%s %s] never returns business status code [%s], available values: %s",
+	"业务状态码[%s]不存在":                      "business status code [%s] does not exist",
+	"启动根据API定义生成假数据的本地mock服务":           "Start a local mock server that serves fake data generated from API definitions",
+	"分析项目中的XxxApi类型与gin路由注册, 为每条路由提供mock响应, 供前端在后端实现前联调\n\n  - 响应数据根据Response结构体的字段名与类型生成, 并以与 reply.Success/reply.Fail 相同的 code/message/data 结构返回\n  - 请求头 X-Mock-Code 或查询参数 _mock_code 可指定返回的业务状态码, 取值为状态码或变量名, 如 20001、CodeUserNotFound\n  - 可指定的业务状态码来自 gbc codegen 生成的注册文件, 未生成时可指定项目中的任意业务状态码\n  - 仅识别以常量路径注册的路由, 分组作为函数参数传递时按空前缀处理": "Analyze XxxApi types and gin route registrations in the project and serve a mock response for every route, so the frontend can integrate before the backend is implemented\n\n  - Response data is generated from the field names and types of the Response struct and returned in the same code/message/data shape as reply.Success/reply.Fail\n  - The X-Mock-Code header or the _mock_code query parameter selects the business status code to return, given as a code or a variable name, e.g. 20001, CodeUserNotFound\n  - Selectable business status codes come from the registration file generated by gbc codegen; if it has not been generated, any business status code in the project may be selected\n  - Only routes registered with constant paths are recognized; groups passed as function parameters are treated as having an empty prefix",
+	"项目中未找到以常量路径注册的gin路由":                       "no gin routes registered with constant paths were found in the project",
+	"监听[%s]失败: %w":                              "failed to listen on [%s]: %w",
+	"mock服务已启动: http://%s, 共%d条路由, 按 Ctrl+C 退出": "mock server started: http://%s, %d routes, press Ctrl+C to exit",
+	"mock服务异常退出: %w":                            "mock server exited unexpectedly: %w",
+	"监听的地址":                                     "address to listen on",
+	"监听的端口":                                     "port to listen on",
+	"不支持的请求库[%s], 可选的值有: %s":                    "unsupported client library [%s], available values: %s",
+	"业务状态码对应的提示信息":                              "Messages of business status codes",
+	"请求成功时的响应":                                  "Response of a successful request",
+	"请求失败时的响应, code为接口可能返回的业务状态码":               "Response of a failed request; code is one of the business status codes the API may return",
+	"接口的响应, 判断code后即可得到data或失败的业务状态码":           "Response of an API; check code to narrow it to data or the failed business status code",
+	"响应是否成功":                                    "Whether the response is successful",
+	"%s 可能返回的业务状态码":                             "Business status codes %s may return",
+	"根据API定义生成前端SDK":                            "Generate frontend SDKs from API definitions",
+	"生成TypeScript SDK":                          "Generate a TypeScript SDK",
+	"根据项目中的XxxApi类型与gin路由注册生成TypeScript SDK, 供前端直接调用\n\n  - types.ts: 每个API的请求参数 (Uri/Header/Query/Body) 与响应数据的类型\n  - api.ts: 每条路由的请求函数, 路径参数取自请求参数的Uri部分\n  - codes.ts: BusinessCode 枚举与提示信息, 以及每个接口可能返回的业务状态码联合类型, 与 gbc codegen 使用相同的分析\n  - client.ts: 基于 fetch 或 axios 的请求封装\n  - index.ts: 统一导出以上内容\n\n输出目录中的同名文件会被覆盖": "Generate a TypeScript SDK for the frontend from XxxApi types and gin route registrations in the project\n\n  - types.ts: request parts (Uri/Header/Query/Body) and response data types of each API\n  - api.ts: a request function per route, with path parameters taken from the Uri part of the request\n  - codes.ts: the BusinessCode enum with messages, plus a union of the business status codes each API may return, using the same analysis as gbc codegen\n  - client.ts: request wrapper based on fetch or axios\n  - index.ts: re-exports everything above\n\nFiles with the same names in the output directory are overwritten",
+	"生成TypeScript SDK失败: %w":            "failed to generate TypeScript SDK: %w",
+	"已生成TypeScript SDK到[%s], 共%d条路由":    "generated TypeScript SDK into [%s], %d routes",
+	"生成文件的输出目录":                         "output directory of generated files",
+	"生成的请求函数使用的请求库, 可选的值有: fetch、axios": "client library used by generated request functions, available values: fetch, axios",
+	"HTTP状态码不是2xx时抛出的错误":                "Error thrown when the HTTP status is not 2xx",
+	"使用的 fetch 实现, 默认为全局的 fetch":        "fetch implementation to use, defaults to the global fetch",
+	"修改请求配置":                            "Update the request configuration",
+	"发送请求使用的 axios 实例, 可通过 setClient 替换为配置了 baseURL 与拦截器的实例": "axios instance used to send requests; replace it via setClient with one configured with baseURL and interceptors",
+	"发送请求并解析JSON响应":                                "Send a request and parse the JSON response",
+	"发送请求并返回响应数据":                                  "Send a request and return the response data",
+	"接口地址前缀, 如 https://api.example.com":            "API base URL, e.g. https://api.example.com",
+	"数组参数按 ids=1&ids=2 的形式编码, 与gin的绑定规则一致":         "encode arrays as ids=1&ids=2 to match gin binding",
+	"替换发送请求使用的 axios 实例":                           "Replace the axios instance used to send requests",
+	"每个请求都会带上的请求头, 如 Authorization":                "Headers sent with every request, e.g. Authorization",
+	"编码路径参数":                                       "Encode a path parameter",
+	"编码通配路径参数, 保留其中的 /":                            "Encode a catch-all path parameter, keeping its /",
+	"请求参数的各个部分, 与API定义中的 Uri/Header/Query/Body 对应": "Parts of a request, matching Uri/Header/Query/Body in the API definition",
+	"不支持的分组方式[%s], 可选的值有: %s、%s":                   "unsupported grouping [%s], available values: %s, %s",
+	"区间宽度必须大于0":                                    "range size must be greater than 0",
+	"状态码":                                          "Code",
+	"变量":                                           "Variable",
+	"提示信息":                                         "Message",
+	"声明位置":                                         "Declared at",
+	"可能返回的接口":                                      "Returned by",
+	"搜索状态码、变量、提示信息或接口":                             "Search codes, variables, messages or APIs",
+	"业务状态码的文档、翻译与生成工具":                             "Documentation, translation and generation tools for business status codes",
+	"导出业务状态码文档":                                    "Export business status code documentation",
+	"导出项目中通过 kit.NewCode 声明的全部业务状态码, 供产品与测试查阅\n\n  - 每个业务状态码包含状态码、变量名、提示信息与声明位置, 以及可能返回它的接口 (与 gbc codegen 使用相同的分析)\n  - 按包 (--group package) 或按数值区间 (--group range, 区间宽度由 --range-size 指定) 分组\n  - HTML格式为单个自包含的文件, 可直接分发或部署为静态页面": "Export every business status code declared via kit.NewCode in the project for product and QA\n\n  - each code lists its number, variable name, message and declaring position, plus the APIs that may return it (using the same analysis as gbc codegen)\n  - codes are grouped by package (--group package) or numeric range (--group range, width set by --range-size)\n  - the HTML format is a single self-contained file that can be shared directly or deployed as a static page",
+	"不支持的输出格式[%s], 可选的值有: md、html":  "unsupported output format [%s], available values: md, html",
+	"项目中未找到通过 kit.NewCode 声明的业务状态码": "no business status codes declared via kit.NewCode were found in the project",
+	"导出业务状态码文档失败: %w":               "failed to export business status code documentation: %w",
+	"创建文件[%s]失败: %w":                "failed to create file [%s]: %w",
+	"已导出%d个业务状态码到[%s]":              "exported %d business status codes to [%s]",
+	"输出格式。可选的值有：md、html":            "output format. Valid values: md, html",
+	"分组方式。可选的值有：package、range":      "grouping. Valid values: package, range",
+	"按数值区间分组时每个区间的宽度":               "width of each range when grouping by numeric range",
+	"输出文件, 默认输出到标准输出":               "output file, defaults to standard output",
+	"业务状态码提示信息的翻译目录":                "Translation catalogs for business code messages",
+	"导出业务状态码的翻译目录":                  "Export a translation catalog of business codes",
+	"导出以业务状态码为键的翻译目录, 原文为 kit.NewCode 的第二个参数 (提示信息)\n\n  - json: {\"状态码\": \"译文\"}, 未指定 --locale 时值为提示信息本身, 可直接作为前端的语言包\n  - po: gettext PO文件, 以状态码作为 msgctxt, 可使用 Poedit 等工具翻译\n  - csv: code、variable、message、translation 四列, 便于在表格软件中翻译\n\n指定 --locale 且输出文件已存在时, 保留文件中已有的译文, 只追加新的业务状态码并移除已删除的业务状态码\n同一状态码被多个变量声明时, 优先使用当前模块中的声明": "Export a translation catalog keyed by business code; the source text is the second argument of kit.NewCode (the message)\n\n  - json: {\"code\": \"translation\"}; without --locale the values are the messages themselves, usable directly as a frontend language pack\n  - po: gettext PO file with the code as msgctxt, translatable with tools such as Poedit\n  - csv: four columns code, variable, message and translation, convenient for spreadsheets\n\nWith --locale and an existing output file, existing translations are kept; new codes are appended and removed codes are dropped\nWhen a code is declared by several variables, the declaration in the current module is preferred",
+	"不支持的翻译目录格式[%s], 可选的值有: %s":        "unsupported catalog format [%s], valid values: %s",
+	"业务状态码[%d] %s.%s 的提示信息不是常量, 原文将为空": "the message of business code [%d] %s.%s is not a constant, its source text will be empty",
+	"导出翻译目录失败: %w":                     "failed to export translation catalog: %w",
+	"已导出%d个业务状态码到[%s], 其中%d个已有译文":      "Exported %d business codes to [%s], %d of them already translated",
+	"检查翻译目录中缺少译文的业务状态码":                "Check translation catalogs for business codes missing translations",
+	"检查每个翻译目录是否包含项目中全部业务状态码的译文, 格式由文件扩展名 (.json、.po、.csv) 决定\n\n  - 未指定文件时检查 --dir 目录下的全部翻译目录\n  - 缺少译文或译文为空的业务状态码视为缺失, PO文件中标记为 fuzzy 的条目同样视为缺失\n  - 翻译目录中已不存在的业务状态码仅给出警告\n\n存在缺失时以非零状态码退出, 可用于CI": "Check that every translation catalog translates all business codes of the project; the format is determined by the file extension (.json, .po, .csv)\n\n  - Without files, all catalogs in the --dir directory are checked\n  - Codes with no or an empty translation are missing; entries marked fuzzy in PO files are missing too\n  - Codes that no longer exist in the project only produce a warning\n\nExits with a non-zero status when anything is missing, suitable for CI",
+	"读取目录[%s]失败: %w": "failed to read directory [%s]: %w",
+	"目录[%s]中没有翻译目录, 支持的扩展名有: .json、.po、.csv":        "no translation catalog in directory [%s], supported extensions: .json, .po, .csv",
+	"无法根据扩展名判断文件[%s]的格式, 支持的扩展名有: .json、.po、.csv":   "cannot determine the format of file [%s] from its extension, supported extensions: .json, .po, .csv",
+	"%s: 缺少业务状态码[%d] %s.%s (%s) 的译文":                "%s: missing translation for business code [%d] %s.%s (%s)",
+	"%s: 业务状态码[%d]已不存在, 可以删除":                       "%s: business code [%d] no longer exists and can be removed",
+	"%s: %d/%d 个业务状态码缺少译文":                          "%s: %d/%d business codes are missing translations",
+	"%s: 全部%d个业务状态码均已翻译":                            "%s: all %d business codes are translated",
+	"%d个翻译目录缺少译文":                                   "%d translation catalogs are missing translations",
+	"业务状态码[%d]同时由 %s.%s (%s) 与 %s.%s (%s) 声明, 使用前者": "business code [%d] is declared by both %s.%s (%s) and %s.%s (%s), using the former",
+	"读取文件[%s]失败: %w":                                "failed to read file [%s]: %w",
+	"解析翻译目录[%s]失败: %w":                              "failed to parse translation catalog [%s]: %w",
+	"翻译目录的格式。可选的值有：json、po、csv":                     "Catalog format. Valid values: json, po, csv",
+	"翻译目录的目标语言, 如 en; 为空时导出源语言目录":                   "Target language of the catalog, e.g. en; exports the source-language catalog when empty",
+	"未指定文件时检查的翻译目录所在目录":                             "Directory containing the catalogs to check when no files are given",
+	"解析JSON失败: %w":                                  "failed to parse JSON: %w",
+	"键[%s]不是业务状态码":                                  "key [%s] is not a business code",
+	"第%d行: msgctxt[%s]不是业务状态码":                      "line %d: msgctxt [%s] is not a business code",
+	"第%d行: 无法识别的内容":                                 "line %d: unrecognized content",
+	"第%d行: %w":                                      "line %d: %w",
+	"解析CSV失败: %w":                                   "failed to parse CSV: %w",
+	"CSV文件缺少表头":                                     "CSV file is missing the header row",
+	"CSV表头必须包含 code 与 translation 两列":               "CSV header must contain the columns code and translation",
+	"第%d行: [%s]不是业务状态码":                             "line %d: [%s] is not a business code",
+	"根据注册表生成业务状态码声明":                                "Generate business code declarations from the registry",
+	"以注册表 (codes.yaml 或 codes.csv) 作为业务状态码的唯一来源, 生成 kit.NewCode 变量声明\n\n注册表中每个业务状态码包含状态码 (code)、变量名 (name)、提示信息 (message)、建议的HTTP状态码 (http) 与所属模块 (module),\nYAML格式还可以通过 ranges 声明每个模块可用的状态码区间, 通过 output 指定生成文件的路径 (默认为 comm/codes_generated.go)\n\n生成前会检查:\n  - 状态码与变量名均不重复, 变量名为导出的Go标识符, 提示信息不为空\n  - 模块的区间互不重叠, 状态码落在所属模块的区间内且不占用其他模块的区间\n  - 生成文件所在包的其他文件中没有同名声明\n\n可以通过 gbc codes export 由项目中已有的声明生成注册表": "Generate kit.NewCode variable declarations from the registry (codes.yaml or codes.csv), the single source of truth for business codes\n\nEach business code in the registry has a code (code), a variable name (name), a message (message), a suggested HTTP status (http) and an owning module (module);\nthe YAML format can also declare each module's code range with ranges, and the generated file path with output (defaults to comm/codes_generated.go)\n\nBefore generating, it checks that:\n  - codes and variable names are unique, variable names are exported Go identifiers and messages are not empty\n  - module ranges do not overlap, and each code lies in its module's range without using another module's range\n  - no other file in the generated file's package declares the same names\n\nUse gbc codes export to create the registry from existing declarations",
+	"未找到注册表 %s 或 %s, 可以先执行 gbc codes export 由已有的声明生成": "registry %s or %s not found, run gbc codes export first to create it from existing declarations",
+	"注册表[%s]中没有业务状态码":                                 "registry [%s] contains no business codes",
+	"生成业务状态码声明失败: %w":                                 "failed to generate business code declarations: %w",
+	"[%s]已是最新, 共%d个业务状态码":                             "[%s] is up to date, %d business codes",
+	"已根据[%s]生成%d个业务状态码到[%s]":                          "Generated %d business codes from [%s] into [%s]",
+	"由项目中已有的业务状态码生成注册表":                               "Create the registry from existing business codes in the project",
+	"收集项目中通过 kit.NewCode 声明的业务状态码, 生成 gbc codes gen 使用的注册表, 格式由输出文件的扩展名 (.yaml、.csv) 决定\n\n  - 模块取变量所在包的包名, 可以按需调整并在YAML格式中补充 ranges\n  - 提示信息不是常量的业务状态码会原样导出, 需要手动补充提示信息\n  - 导出后删除原有的 kit.NewCode 声明, 再执行 gbc codes gen 生成": "Collect the business codes declared with kit.NewCode in the project and write the registry used by gbc codes gen; the format is determined by the output file extension (.yaml, .csv)\n\n  - The module is the name of the package declaring the variable; adjust as needed and add ranges in the YAML format\n  - Codes whose message is not a constant are exported as is, fill in their messages manually\n  - After exporting, delete the original kit.NewCode declarations and run gbc codes gen",
+	"文件[%s]已存在, 使用 --force 覆盖":                       "file [%s] already exists, use --force to overwrite",
+	"删除原有的 kit.NewCode 声明后执行 gbc codes gen 生成[%s]":   "Delete the original kit.NewCode declarations, then run gbc codes gen to generate [%s]",
+	"注册表[%s]中存在%d个问题":                                "registry [%s] has %d problems",
+	"[%s]由 %s 生成, 更换生成文件路径后需要删除":                     "[%s] was generated by %s and should be deleted after changing the generated file path",
+	"%s: [%s]已由注册表生成, 需要删除该声明":                       "%s: [%s] is generated from the registry, delete this declaration",
+	"包[%s]中有%d个声明与注册表中的变量名重复":                        "package [%s] has %d declarations that duplicate variable names in the registry",
+	"注册表文件, 默认依次查找 codes.yaml、codes.csv":             "Registry file; looks for codes.yaml, then codes.csv by default",
+	"生成文件的路径, 优先于注册表中的 output":                       "Path of the generated file, takes precedence over output in the registry",
+	"仅将生成的代码输出到标准输出, 不写入文件":                          "Only print the generated code to standard output without writing files",
+	"注册表文件, 格式由扩展名决定: .yaml、.csv":                    "Registry file; the format is determined by the extension: .yaml, .csv",
+	"注册表文件已存在时覆盖":                                    "Overwrite the registry file if it already exists",
+	"无法根据扩展名判断注册表[%s]的格式, 支持的扩展名有: .yaml、.yml、.csv":  "cannot determine the format of registry [%s] from its extension, supported extensions: .yaml, .yml, .csv",
+	"CSV表头必须包含 code、name、message 三列":                 "CSV header must contain the columns code, name and message",
+	"第%d行: [%s]不是HTTP状态码":                            "line %d: [%s] is not an HTTP status code",
+	"第%d个区间未指定模块":                                    "range #%d has no module",
+	"模块[%s]的区间重复声明":                                  "range of module [%s] is declared more than once",
+	"模块[%s]的区间 %d - %d 起点大于终点":                       "range %[2]d - %[3]d of module [%[1]s] starts after it ends",
+	"模块[%s]的区间 %d - %d 与模块[%s]的区间 %d - %d 重叠":        "range %[2]d - %[3]d of module [%[1]s] overlaps range %[5]d - %[6]d of module [%[4]s]",
+	"业务状态码[%d]未指定变量名":                                "business code [%d] has no variable name",
+	"业务状态码[%d]的变量名[%s]不是导出的Go标识符":                    "variable name [%[2]s] of business code [%[1]d] is not an exported Go identifier",
+	"业务状态码[%d]同时分配给了 %s 与 %s":                        "business code [%d] is assigned to both %s and %s",
+	"变量名[%s]同时用于业务状态码 %d 与 %d":                       "variable name [%s] is used by both business codes %d and %d",
+	"业务状态码[%d] %s 缺少提示信息":                            "business code [%d] %s has no message",
+	"业务状态码[%d] %s 的HTTP状态码[%d]无效":                    "business code [%d] %s has an invalid HTTP status [%d]",
+	"业务状态码[%d] %s 不在模块[%s]的区间 %d - %d 内":             "business code [%d] %s is outside range %[4]d - %[5]d of module [%[3]s]",
+	"业务状态码[%d] %s 属于模块[%s]的区间 %d - %d, 但声明的模块为[%s]":  "business code [%d] %s lies in range %[4]d - %[5]d of module [%[3]s], but its module is [%[6]s]",
+	"内置模板快照不含依赖版本, 请在可以访问GOPROXY时于项目中执行 go mod tidy": "The built-in template snapshot pins no dependency versions; run go mod tidy in the project once GOPROXY is reachable",
+	"%s中组件[%s]的路径[%s]不在项目目录内":                        "component [%[2]s] in %[1]s has path [%[3]s] outside the project directory",
+	"读取mygo模块信息失败: %w":                               "failed to read mygo module info: %w",
+	"mygo %s 尚未下载, 请先执行 go mod download":             "mygo %s has not been downloaded, run go mod download first",
+	"解析文件[%s]失败: %s":                                 "failed to parse file [%s]: %s",
+	"跳过mygo兼容性检查: %s":                                "skipping mygo compatibility check: %s",
+	"项目依赖的mygo %s 中缺少 gbc %s 生成代码所用的API: %s, 生成的代码可能无法编译, 请执行 go get %s@latest 升级":             "the project's mygo %s lacks the APIs used by code generated by gbc %s: %s; generated code may not compile, run go get %s@latest to upgrade",
+	"查看gbc版本、VCS修订与构建信息, 以及gbc各功能生成代码所用的mygo API; 在项目目录中执行时根据模块缓存中的mygo源码检查项目依赖的mygo是否提供这些API": "show gbc version, VCS revision and build info, and the mygo APIs used by code generated by each gbc feature; when run in a project directory, check against the mygo source in the module cache whether the project's mygo provides these APIs",
+	"无法检查mygo兼容性: %s":                         "unable to check mygo compatibility: %s",
+	"功能\tmygo API":                            "FEATURE\tMYGO API",
+	"需升级, 缺少 %s":                              "upgrade required, missing %s",
+	"项目依赖的mygo提供gbc生成代码所需的API":                "the project's mygo provides the APIs required by gbc-generated code",
+	"执行 go mod download 下载依赖":                 "run go mod download to fetch dependencies",
+	"无法读取项目依赖的mygo源码: %s":                     "unable to read the project's mygo source: %s",
+	"mygo %s 缺少以下功能所需的API: %s":                "mygo %s lacks the APIs required by: %s",
+	"通过 go env -w GOBIN=<目录> 指定安装目录":          "set the install directory with go env -w GOBIN=<dir>",
+	"GOBIN与GOPATH均未设置, 无法确定 go install 的安装目录": "neither GOBIN nor GOPATH is set, unable to determine the go install directory",
+	"写入%s失败: %w":                              "failed to write %s: %w",
+	"%s 引用了 %s":                               "%s references %s",
+	"%s与所在包的其他文件之间存在未限定包名的引用, 移动到其他包后将无法编译, 请先手动解除这些引用": "%s and other files in its package reference each other without a package qualifier, so it would not compile after moving to another package; remove these references manually first",
+	"恢复文件[%s]失败: %v":                       "failed to restore file [%s]: %v",
+	"模板中没有%s, 不支持通过 --with/--without 选择组件": "the template has no %s, selecting components with --with/--without is not supported",
+	"应用名称[%s]不能是路径, 请仅指定项目目录的名称":           "app name [%s] must not be a path, specify only the name of the project directory",
+	"应用名称[%s]不能以.开头":                       "app name [%s] must not start with .",
+	"应用名称[%s]不合法: %w":                      "invalid app name [%s]: %w",
+	"无法从GitHub获取版本变更记录, 已跳过: %s":           "could not fetch the changelog from GitHub, skipped: %s",
+	"(↑/↓ 移动, 回车确认)":                       "(↑/↓ move, enter to confirm)",
+	"(↑/↓ 移动, 空格勾选, 回车确认)":                 "(↑/↓ move, space to toggle, enter to confirm)",
+}
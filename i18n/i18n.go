@@ -0,0 +1,84 @@
+// Package i18n gbc命令行的多语言消息目录
+//
+// 源码中的中文消息即为 zh-CN 消息, 同时作为目录的键; 其他语言的目录将其映射为对应的译文,
+// 格式化动词 (%s、%d、%w 等) 须与原文保持一致
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Locale 语言
+type Locale string
+
+const (
+	ZhCN Locale = "zh-CN"
+	En   Locale = "en"
+)
+
+// Locales 支持的语言
+var Locales = []Locale{ZhCN, En}
+
+var catalogs = map[Locale]map[string]string{
+	En: en,
+}
+
+var current = Detect()
+
+// Detect 依次根据环境变量 LC_ALL、LC_MESSAGES、LANG 选择语言, 未设置或无法识别时使用 zh-CN
+func Detect() Locale {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			if l, ok := Parse(v); ok {
+				return l
+			}
+			return ZhCN
+		}
+	}
+	return ZhCN
+}
+
+// Parse 解析 zh-CN、zh_CN.UTF-8、en、en_US.UTF-8 等形式的语言名称
+func Parse(name string) (Locale, bool) {
+	name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	switch {
+	case strings.HasPrefix(name, "zh"):
+		return ZhCN, true
+	case name == "en" || strings.HasPrefix(name, "en-") || strings.HasPrefix(name, "en."):
+		return En, true
+	}
+	return "", false
+}
+
+// SetLocale 设置当前语言
+func SetLocale(l Locale) {
+	if slices.Contains(Locales, l) {
+		current = l
+	}
+}
+
+// Current 当前语言
+func Current() Locale {
+	return current
+}
+
+// T 返回消息在当前语言下的文本, 目录中不存在时原样返回
+func T(msg string) string {
+	if s, ok := catalogs[current][msg]; ok {
+		return s
+	}
+	return msg
+}
+
+// Sprintf 按当前语言翻译format后格式化
+func Sprintf(format string, a ...any) string {
+	return fmt.Sprintf(T(format), a...)
+}
+
+// Errorf 按当前语言翻译format后构造错误, 支持 %w
+func Errorf(format string, a ...any) error {
+	return fmt.Errorf(T(format), a...)
+}
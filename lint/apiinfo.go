@@ -0,0 +1,66 @@
+package lint
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// APIInfoAnalyzer 检查API的Info字段是否声明了非空的 name/desc 标签, 二者用于生成接口文档
+var APIInfoAnalyzer = &analysis.Analyzer{
+	Name:     "apiinfo",
+	Doc:      i18n.T("检查XxxApi的Info字段是否具有非空的name与desc标签"),
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runAPIInfo,
+}
+
+func runAPIInfo(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.TypeSpec)(nil)}, func(n ast.Node) {
+		spec := n.(*ast.TypeSpec)
+		obj, ok := pass.TypesInfo.Defs[spec.Name].(*types.TypeName)
+		if !ok || !isAPIType(obj) {
+			return
+		}
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return
+		}
+		var info *ast.Field
+		for _, field := range st.Fields.List {
+			for _, name := range field.Names {
+				if name.Name == "Info" {
+					info = field
+				}
+			}
+		}
+		if info == nil {
+			pass.Reportf(spec.Name.Pos(), i18n.T("%s缺少Info字段, 应声明 Info struct{} `name:\"API名称\" desc:\"API描述\"`"), obj.Name())
+			return
+		}
+		tag := reflect.StructTag("")
+		if info.Tag != nil {
+			if value, err := strconv.Unquote(info.Tag.Value); err == nil {
+				tag = reflect.StructTag(value)
+			}
+		}
+		missing := make([]string, 0, 2)
+		for _, key := range []string{"name", "desc"} {
+			if strings.TrimSpace(tag.Get(key)) == "" {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			pass.Reportf(info.Pos(), i18n.T("%s的Info字段缺少非空的%s标签"), obj.Name(), strings.Join(missing, "/"))
+		}
+	})
+	return nil, nil
+}
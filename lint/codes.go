@@ -0,0 +1,170 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+
+	gbcanalysis "github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// CodeFact 包级业务状态码变量 var X = kit.NewCode(code, "msg") 的取值, 跨包传递给依赖它的检查
+type CodeFact struct {
+	Code    int64
+	Message string
+}
+
+func (*CodeFact) AFact() {}
+
+func (f *CodeFact) String() string {
+	return fmt.Sprintf("code(%d, %q)", f.Code, f.Message)
+}
+
+// CodeVars 当前包及其依赖中声明的业务状态码变量
+type CodeVars map[types.Object]*CodeFact
+
+// CodeFactAnalyzer 为包级业务状态码变量导出 CodeFact, 并将当前包可见的全部业务状态码变量作为结果
+// 事实仅对声明它的检查可见, 其他检查通过结果使用
+var CodeFactAnalyzer = &analysis.Analyzer{
+	Name:       "mygocodes",
+	Doc:        i18n.T("收集包级 kit.NewCode 业务状态码变量"),
+	FactTypes:  []analysis.Fact{new(CodeFact)},
+	ResultType: reflect.TypeFor[CodeVars](),
+	Run:        runCodeFact,
+}
+
+func runCodeFact(pass *analysis.Pass) (any, error) {
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, value := range vs.Values {
+					if i >= len(vs.Names) {
+						break
+					}
+					code, msg, ok := gbcanalysis.ParseNewCodeCall(pass.TypesInfo, value)
+					if !ok {
+						continue
+					}
+					if obj, ok := pass.TypesInfo.Defs[vs.Names[i]].(*types.Var); ok {
+						pass.ExportObjectFact(obj, &CodeFact{Code: code, Message: msg})
+					}
+				}
+			}
+		}
+	}
+	vars := make(CodeVars)
+	for _, fact := range pass.AllObjectFacts() {
+		if cf, ok := fact.Fact.(*CodeFact); ok {
+			vars[fact.Object] = cf
+		}
+	}
+	return vars, nil
+}
+
+// CodeReturnAnalyzer 检查API的Run方法只返回已声明的业务状态码变量, 不返回字面量或临时构造的状态码
+var CodeReturnAnalyzer = &analysis.Analyzer{
+	Name:     "coderet",
+	Doc:      i18n.T("检查XxxApi.Run返回已声明的业务状态码变量而非kit.Code字面量"),
+	Requires: []*analysis.Analyzer{inspect.Analyzer, CodeFactAnalyzer},
+	Run:      runCodeReturn,
+}
+
+func runCodeReturn(pass *analysis.Pass) (any, error) {
+	for _, decl := range methodDecls(pass, isAPIType, "Run") {
+		file := fileOf(pass, decl.Pos())
+		inspectBody(decl.Body, func(n ast.Node) bool {
+			ret, ok := n.(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				return true
+			}
+			result := ast.Unparen(ret.Results[0])
+			switch expr := result.(type) {
+			case *ast.CompositeLit:
+				pass.Reportf(expr.Pos(), "%s", i18n.T("Run应返回已声明的业务状态码变量, 而不是kit.Code字面量"))
+			case *ast.CallExpr:
+				// 其他调用视为委托给返回业务状态码的辅助函数, 如 return svc.Do(ctx)
+				code, _, isNewCode := gbcanalysis.ParseNewCodeCall(pass.TypesInfo, expr)
+				if !isNewCode {
+					return true
+				}
+				diag := analysis.Diagnostic{
+					Pos:     expr.Pos(),
+					End:     expr.End(),
+					Message: i18n.T("Run中不应临时构造业务状态码, 请在comm中声明后返回该变量"),
+				}
+				if ref := findCodeVar(pass, file, code); ref != "" {
+					diag.SuggestedFixes = []analysis.SuggestedFix{{
+						Message:   i18n.Sprintf("替换为 %s", ref),
+						TextEdits: []analysis.TextEdit{{Pos: expr.Pos(), End: expr.End(), NewText: []byte(ref)}},
+					}}
+				}
+				pass.Report(diag)
+			}
+			return true
+		})
+	}
+	return nil, nil
+}
+
+// findCodeVar 查找取值为code且在当前文件中可直接引用的业务状态码变量, 不存在或不唯一时返回空
+func findCodeVar(pass *analysis.Pass, file *ast.File, code int64) string {
+	refs := make([]string, 0, 1)
+	for obj, cf := range pass.ResultOf[CodeFactAnalyzer].(CodeVars) {
+		if cf.Code != code {
+			continue
+		}
+		if obj.Pkg() == pass.Pkg {
+			refs = append(refs, obj.Name())
+			continue
+		}
+		if name := importName(file, obj.Pkg()); name != "" && obj.Exported() {
+			refs = append(refs, name+"."+obj.Name())
+		}
+	}
+	if len(refs) != 1 {
+		return ""
+	}
+	return refs[0]
+}
+
+// importName 文件中导入pkg时使用的名称, 未导入时返回空
+func importName(file *ast.File, pkg *types.Package) string {
+	if file == nil {
+		return ""
+	}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || path != pkg.Path() {
+			continue
+		}
+		if spec.Name != nil {
+			if spec.Name.Name == "_" || spec.Name.Name == "." {
+				return ""
+			}
+			return spec.Name.Name
+		}
+		return pkg.Name()
+	}
+	return ""
+}
+
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, f := range pass.Files {
+		if f.FileStart <= pos && pos <= f.FileEnd {
+			return f
+		}
+	}
+	return nil
+}
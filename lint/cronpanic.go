@@ -0,0 +1,71 @@
+package lint
+
+import (
+	"go/ast"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+
+	gbcanalysis "github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// panicFuncs 会导致panic或直接退出进程的日志函数 (log包与logrus)
+var panicFuncs = []string{"Panic", "Panicf", "Panicln", "Fatal", "Fatalf", "Fatalln"}
+
+var panicPkgs = []string{"log", "github.com/sirupsen/logrus"}
+
+// CronPanicAnalyzer 检查定时任务的Run方法中的panic, 定时任务中的panic会导致整个进程退出
+var CronPanicAnalyzer = &analysis.Analyzer{
+	Name:     "cronpanic",
+	Doc:      i18n.T("检查XxxJob.Run中未被recover的panic与Fatal/Panic日志调用"),
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runCronPanic,
+}
+
+func runCronPanic(pass *analysis.Pass) (any, error) {
+	for obj, decl := range methodDecls(pass, gbcanalysis.IsCronJob, "Run") {
+		if hasDeferredRecover(pass, decl.Body) {
+			continue
+		}
+		inspectBody(decl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if isBuiltin(pass.TypesInfo, call, "panic") {
+				pass.Reportf(call.Pos(), i18n.T("定时任务%s.Run中不应panic, 请记录日志后返回"), obj.Name())
+				return true
+			}
+			fn := calledFunc(pass.TypesInfo, call)
+			if fn != nil && fn.Pkg() != nil && slices.Contains(panicPkgs, fn.Pkg().Path()) && slices.Contains(panicFuncs, fn.Name()) {
+				pass.Reportf(call.Pos(), i18n.T("定时任务%s.Run中不应调用%s, 该调用会panic或退出进程"), obj.Name(), fn.Name())
+			}
+			return true
+		})
+	}
+	return nil, nil
+}
+
+// hasDeferredRecover 函数体中是否存在 defer func() { ... recover() ... }()
+func hasDeferredRecover(pass *analysis.Pass, body *ast.BlockStmt) bool {
+	found := false
+	for _, stmt := range body.List {
+		def, ok := stmt.(*ast.DeferStmt)
+		if !ok {
+			continue
+		}
+		lit, ok := def.Call.Fun.(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok && isBuiltin(pass.TypesInfo, call, "recover") {
+				found = true
+			}
+			return !found
+		})
+	}
+	return found
+}
@@ -0,0 +1,135 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+
+	gbcanalysis "github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// bindMethods 各请求参数部分在模板中使用的绑定方法
+var bindMethods = map[string]string{
+	"Uri":    "ShouldBindUri",
+	"Header": "ShouldBindHeader",
+	"Query":  "ShouldBindQuery",
+	"Body":   "ShouldBindJSON",
+}
+
+// InitBindAnalyzer 检查API的Init方法是否绑定了Request中声明的每个请求参数部分
+var InitBindAnalyzer = &analysis.Analyzer{
+	Name:     "initbind",
+	Doc:      i18n.T("检查XxxApi.Init是否绑定了Request中声明的Uri/Header/Query/Body"),
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runInitBind,
+}
+
+func runInitBind(pass *analysis.Pass) (any, error) {
+	for obj, decl := range methodDecls(pass, isAPIType, "Init") {
+		parts := declaredParts(obj)
+		if len(parts) == 0 {
+			continue
+		}
+		bound := make(map[string]bool)
+		inspectBody(decl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			for _, arg := range call.Args {
+				if part := requestPartOf(arg); part != "" {
+					bound[part] = true
+				}
+			}
+			return true
+		})
+		missing := make([]string, 0)
+		for _, part := range parts {
+			if !bound[part] {
+				missing = append(missing, part)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		diag := analysis.Diagnostic{
+			Pos:     decl.Name.Pos(),
+			End:     decl.Name.End(),
+			Message: i18n.Sprintf("%s.Init未绑定Request中声明的%s", obj.Name(), strings.Join(missing, "/")),
+		}
+		if fix, ok := bindFix(decl, missing); ok {
+			diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+		}
+		pass.Report(diag)
+	}
+	return nil, nil
+}
+
+// declaredParts API的Request字段中声明的请求参数部分
+func declaredParts(obj *types.TypeName) []string {
+	st := obj.Type().Underlying().(*types.Struct)
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Name() != "Request" {
+			continue
+		}
+		req, ok := st.Field(i).Type().Underlying().(*types.Struct)
+		if !ok {
+			return nil
+		}
+		parts := make([]string, 0, req.NumFields())
+		for j := 0; j < req.NumFields(); j++ {
+			if slices.Contains(gbcanalysis.RequestParts, req.Field(j).Name()) {
+				parts = append(parts, req.Field(j).Name())
+			}
+		}
+		return parts
+	}
+	return nil
+}
+
+// requestPartOf 参数形如 &x.Request.Part 或 x.Request.Part 时返回Part
+func requestPartOf(arg ast.Expr) string {
+	if u, ok := ast.Unparen(arg).(*ast.UnaryExpr); ok {
+		arg = u.X
+	}
+	sel, ok := ast.Unparen(arg).(*ast.SelectorExpr)
+	if !ok || !slices.Contains(gbcanalysis.RequestParts, sel.Sel.Name) {
+		return ""
+	}
+	inner, ok := sel.X.(*ast.SelectorExpr)
+	if !ok || inner.Sel.Name != "Request" {
+		return ""
+	}
+	return sel.Sel.Name
+}
+
+// bindFix 在Init最后的return之前插入缺失的绑定代码
+func bindFix(decl *ast.FuncDecl, missing []string) (analysis.SuggestedFix, bool) {
+	if len(decl.Recv.List[0].Names) == 0 || decl.Type.Params.NumFields() == 0 || len(decl.Type.Params.List[0].Names) == 0 {
+		return analysis.SuggestedFix{}, false
+	}
+	recv := decl.Recv.List[0].Names[0].Name
+	ctx := decl.Type.Params.List[0].Names[0].Name
+	if recv == "_" || ctx == "_" || len(decl.Body.List) == 0 {
+		return analysis.SuggestedFix{}, false
+	}
+	last, ok := decl.Body.List[len(decl.Body.List)-1].(*ast.ReturnStmt)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+	buf := bytes.Buffer{}
+	for _, part := range missing {
+		fmt.Fprintf(&buf, "if err := %s.%s(&%s.Request.%s); err != nil {\n\t\treturn err\n\t}\n\t", ctx, bindMethods[part], recv, part)
+	}
+	return analysis.SuggestedFix{
+		Message:   i18n.Sprintf("绑定%s", strings.Join(missing, "/")),
+		TextEdits: []analysis.TextEdit{{Pos: last.Pos(), End: last.Pos(), NewText: buf.Bytes()}},
+	}, true
+}
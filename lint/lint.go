@@ -0,0 +1,103 @@
+package lint
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	gbcanalysis "github.com/zjutjh/gbc/analysis"
+)
+
+// ReplyPkgPath mygo中写入统一格式响应的包
+const ReplyPkgPath = "github.com/zjutjh/mygo/foundation/reply"
+
+// Analyzers gbc lint 与 gbc-vet 执行的全部检查
+var Analyzers = []*analysis.Analyzer{
+	APIInfoAnalyzer,
+	CodeReturnAnalyzer,
+	DoubleReplyAnalyzer,
+	CronPanicAnalyzer,
+	InitBindAnalyzer,
+}
+
+// isAPIType 是否为gbc模板生成的API类型: 名称以Api结尾的结构体, 且存在 Run(*gin.Context) kit.Code 方法
+func isAPIType(obj *types.TypeName) bool {
+	if !strings.HasSuffix(obj.Name(), "Api") || obj.IsAlias() {
+		return false
+	}
+	if _, ok := obj.Type().Underlying().(*types.Struct); !ok {
+		return false
+	}
+	sel := types.NewMethodSet(types.NewPointer(obj.Type())).Lookup(obj.Pkg(), "Run")
+	if sel == nil {
+		return false
+	}
+	sig := sel.Type().(*types.Signature)
+	return sig.Params().Len() == 1 && sig.Results().Len() == 1 &&
+		gbcanalysis.IsNamedPointer(sig.Params().At(0).Type(), gbcanalysis.GinPkgPath, "Context") &&
+		gbcanalysis.IsNamed(sig.Results().At(0).Type(), gbcanalysis.KitPkgPath, "Code")
+}
+
+// methodDecls 收集包中指定类型的方法声明
+func methodDecls(pass *analysis.Pass, match func(obj *types.TypeName) bool, name string) map[*types.TypeName]*ast.FuncDecl {
+	decls := make(map[*types.TypeName]*ast.FuncDecl)
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		decl := n.(*ast.FuncDecl)
+		if decl.Recv == nil || decl.Name.Name != name || decl.Body == nil {
+			return
+		}
+		fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+		if !ok {
+			return
+		}
+		recv := fn.Type().(*types.Signature).Recv().Type()
+		if ptr, ok := recv.(*types.Pointer); ok {
+			recv = ptr.Elem()
+		}
+		named, ok := types.Unalias(recv).(*types.Named)
+		if ok && match(named.Obj()) {
+			decls[named.Obj()] = decl
+		}
+	})
+	return decls
+}
+
+// inspectBody 遍历函数体, 不进入其中的函数字面量
+func inspectBody(body *ast.BlockStmt, fn func(n ast.Node) bool) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		return fn(n)
+	})
+}
+
+// calledFunc 调用表达式所调用的函数或方法, 无法静态确定时返回nil
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[ident].(*types.Func)
+	return fn
+}
+
+// isBuiltin 调用是否为指定的内置函数
+func isBuiltin(info *types.Info, call *ast.CallExpr, name string) bool {
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	b, ok := info.Uses[ident].(*types.Builtin)
+	return ok && b.Name() == name
+}
@@ -0,0 +1,36 @@
+package lint
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+func TestAnalyzers(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	i18n.SetLocale(i18n.ZhCN)
+
+	tests := []struct {
+		analyzer *analysis.Analyzer
+		pkg      string
+		fix      bool
+	}{
+		{APIInfoAnalyzer, "app/apiinfo", false},
+		{CodeReturnAnalyzer, "app/coderet", true},
+		{DoubleReplyAnalyzer, "app/doublereply", false},
+		{CronPanicAnalyzer, "app/cronpanic", false},
+		{InitBindAnalyzer, "app/initbind", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.analyzer.Name, func(t *testing.T) {
+			if tt.fix {
+				analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), tt.analyzer, tt.pkg)
+				return
+			}
+			analysistest.Run(t, analysistest.TestData(), tt.analyzer, tt.pkg)
+		})
+	}
+}
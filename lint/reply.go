@@ -0,0 +1,127 @@
+package lint
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/cfg"
+
+	gbcanalysis "github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// ginWriters *gin.Context上写入响应但不中止请求的方法
+var ginWriters = []string{
+	"JSON", "IndentedJSON", "SecureJSON", "JSONP", "AsciiJSON", "PureJSON",
+	"XML", "YAML", "TOML", "ProtoBuf", "String", "HTML", "Render", "Redirect",
+	"Data", "DataFromReader", "File", "FileAttachment", "FileFromFS", "SSEvent", "Stream",
+}
+
+// DoubleReplyAnalyzer 检查API的Run方法自行写入响应后未中止请求便返回
+// 模板生成的入口函数在请求未中止时会根据返回的状态码 (包括OK) 再次写入响应, 导致响应体被写入两次
+var DoubleReplyAnalyzer = &analysis.Analyzer{
+	Name:     "doublereply",
+	Doc:      i18n.T("检查XxxApi.Run写入响应后未调用ctx.Abort*便返回"),
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runDoubleReply,
+}
+
+func runDoubleReply(pass *analysis.Pass) (any, error) {
+	for _, decl := range methodDecls(pass, isAPIType, "Run") {
+		g := cfg.New(decl.Body, func(call *ast.CallExpr) bool {
+			return !isBuiltin(pass.TypesInfo, call, "panic")
+		})
+		// 每个返回只报告控制流上最早到达它的写入
+		writers := make(map[*ast.ReturnStmt]*ast.CallExpr)
+		for _, b := range g.Blocks {
+			if !b.Live {
+				continue
+			}
+			for i, n := range b.Nodes {
+				writer := findCall(n, func(call *ast.CallExpr) bool { return writesResponse(pass.TypesInfo, call) })
+				if writer == nil || findCall(n, func(call *ast.CallExpr) bool { return aborts(pass.TypesInfo, call) }) != nil {
+					continue
+				}
+				reachedReturns(pass.TypesInfo, b, i+1, func(ret *ast.ReturnStmt) {
+					if prev, ok := writers[ret]; !ok || writer.Pos() < prev.Pos() {
+						writers[ret] = writer
+					}
+				})
+			}
+		}
+		for ret, writer := range writers {
+			pass.Reportf(ret.Pos(), i18n.T("Run已在第%d行自行写入响应, 未中止请求时入口函数会再次写入响应, 请在返回前调用 ctx.Abort() 或改用 ctx.Abort* 系列方法写入"),
+				pass.Fset.Position(writer.Pos()).Line)
+		}
+	}
+	return nil, nil
+}
+
+// reachedReturns 从块b的第start个节点出发, 沿控制流查找未经过 ctx.Abort* 调用即可到达的return语句
+func reachedReturns(info *types.Info, b *cfg.Block, start int, visit func(ret *ast.ReturnStmt)) {
+	seen := make(map[*cfg.Block]bool)
+	var walk func(b *cfg.Block, start int)
+	walk = func(b *cfg.Block, start int) {
+		for _, n := range b.Nodes[start:] {
+			if findCall(n, func(call *ast.CallExpr) bool { return aborts(info, call) }) != nil {
+				return
+			}
+			if ret, ok := n.(*ast.ReturnStmt); ok {
+				visit(ret)
+				return
+			}
+		}
+		for _, succ := range b.Succs {
+			if !seen[succ] {
+				seen[succ] = true
+				walk(succ, 0)
+			}
+		}
+	}
+	walk(b, start)
+}
+
+// findCall 返回节点中首个满足match的调用, 不进入函数字面量
+func findCall(n ast.Node, match func(call *ast.CallExpr) bool) *ast.CallExpr {
+	var found *ast.CallExpr
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if match(n) {
+				found = n
+			}
+		}
+		return found == nil
+	})
+	return found
+}
+
+// aborts 调用是否中止请求: *gin.Context的 Abort* 系列方法
+func aborts(info *types.Info, call *ast.CallExpr) bool {
+	fn := calledFunc(info, call)
+	if fn == nil {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	return sig.Recv() != nil && strings.HasPrefix(fn.Name(), "Abort") &&
+		gbcanalysis.IsNamedPointer(sig.Recv().Type(), gbcanalysis.GinPkgPath, "Context")
+}
+
+// writesResponse 调用是否写入响应: *gin.Context的写入方法或reply包中的函数
+func writesResponse(info *types.Info, call *ast.CallExpr) bool {
+	fn := calledFunc(info, call)
+	if fn == nil || fn.Pkg() == nil {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() == nil {
+		return fn.Pkg().Path() == ReplyPkgPath
+	}
+	return gbcanalysis.IsNamedPointer(sig.Recv().Type(), gbcanalysis.GinPkgPath, "Context") && slices.Contains(ginWriters, fn.Name())
+}
@@ -0,0 +1,130 @@
+package lint
+
+import (
+	"cmp"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	gbcanalysis "github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// Finding 一条检查结果
+type Finding struct {
+	Analyzer string `json:"analyzer"`
+	Position string `json:"position"`
+	Message  string `json:"message"`
+	Fixable  bool   `json:"fixable"`
+
+	pos   token.Position
+	fixes []analysis.SuggestedFix
+	fset  *token.FileSet
+}
+
+// Run 对dir下匹配patterns的软件包执行全部检查
+func Run(dir string, buildTags []string, patterns ...string) ([]Finding, error) {
+	cfg := &packages.Config{
+		Mode:       packages.LoadAllSyntax,
+		Dir:        dir,
+		BuildFlags: gbcanalysis.BuildFlags(buildTags...),
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, i18n.Errorf("软件包中存在错误")
+	}
+	graph, err := checker.Analyze(Analyzers, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]Finding, 0)
+	for act := range graph.All() {
+		if !act.IsRoot {
+			continue
+		}
+		if act.Err != nil {
+			return nil, fmt.Errorf("%s: %w", act, act.Err)
+		}
+		for _, diag := range act.Diagnostics {
+			pos := act.Package.Fset.Position(diag.Pos)
+			rel := pos
+			if r, err := filepath.Rel(absDir, pos.Filename); err == nil {
+				rel.Filename = filepath.ToSlash(r)
+			}
+			findings = append(findings, Finding{
+				Analyzer: act.Analyzer.Name,
+				Position: rel.String(),
+				Message:  diag.Message,
+				Fixable:  len(diag.SuggestedFixes) > 0,
+				pos:      pos,
+				fixes:    diag.SuggestedFixes,
+				fset:     act.Package.Fset,
+			})
+		}
+	}
+	slices.SortFunc(findings, func(a, b Finding) int {
+		return cmp.Or(
+			cmp.Compare(a.pos.Filename, b.pos.Filename),
+			cmp.Compare(a.pos.Offset, b.pos.Offset),
+			cmp.Compare(a.Analyzer, b.Analyzer),
+		)
+	})
+	return findings, nil
+}
+
+// ApplyFixes 应用检查结果中的第一个修复建议, 返回修改的文件
+func ApplyFixes(findings []Finding) ([]string, error) {
+	type edit struct {
+		start, end int
+		text       []byte
+	}
+	edits := make(map[string][]edit)
+	for _, f := range findings {
+		if len(f.fixes) == 0 {
+			continue
+		}
+		for _, e := range f.fixes[0].TextEdits {
+			start, end := f.fset.Position(e.Pos), f.fset.Position(e.End)
+			edits[start.Filename] = append(edits[start.Filename], edit{start.Offset, end.Offset, e.NewText})
+		}
+	}
+	files := make([]string, 0, len(edits))
+	for file, es := range edits {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return files, err
+		}
+		// 从后向前应用, 保证前面的偏移量不受影响
+		slices.SortFunc(es, func(a, b edit) int { return cmp.Compare(b.start, a.start) })
+		for i, e := range es {
+			if i > 0 && e.end > es[i-1].start {
+				continue // 与已应用的修改重叠
+			}
+			src = slices.Concat(src[:e.start], e.text, src[e.end:])
+		}
+		if formatted, err := format.Source(src); err == nil {
+			src = formatted
+		}
+		if err := os.WriteFile(file, src, 0644); err != nil {
+			return files, err
+		}
+		files = append(files, file)
+	}
+	slices.Sort(files)
+	return files, nil
+}
@@ -0,0 +1,31 @@
+package apiinfo
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/kit"
+)
+
+type LoginApi struct {
+	Info struct{} `name:"登录" desc:"用户登录"`
+}
+
+func (l *LoginApi) Run(ctx *gin.Context) kit.Code { return kit.Code{} }
+
+type LogoutApi struct{} // want `LogoutApi缺少Info字段`
+
+func (l *LogoutApi) Run(ctx *gin.Context) kit.Code { return kit.Code{} }
+
+type InfoApi struct {
+	Info struct{} `name:"用户信息" desc:" "` // want `InfoApi的Info字段缺少非空的desc标签`
+}
+
+func (i *InfoApi) Run(ctx *gin.Context) kit.Code { return kit.Code{} }
+
+type ListApi struct {
+	Info struct{} // want `ListApi的Info字段缺少非空的name/desc标签`
+}
+
+func (l *ListApi) Run(ctx *gin.Context) kit.Code { return kit.Code{} }
+
+// HelperApi 没有 Run(*gin.Context) kit.Code 方法, 不是API
+type HelperApi struct{}
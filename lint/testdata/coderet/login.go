@@ -0,0 +1,31 @@
+package coderet
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/kit"
+
+	"app/comm"
+)
+
+type service struct{}
+
+func (service) Login(ctx *gin.Context) kit.Code { return comm.CodeOK }
+
+type LoginApi struct {
+	step int
+	svc  service
+}
+
+func (l *LoginApi) Run(ctx *gin.Context) kit.Code {
+	switch l.step {
+	case 1:
+		return kit.Code{Code: 10001} // want `而不是kit.Code字面量`
+	case 2:
+		return kit.NewCode(10001, "用户不存在") // want `Run中不应临时构造业务状态码`
+	case 3:
+		return kit.NewCode(10002, "密码错误") // want `Run中不应临时构造业务状态码`
+	case 4:
+		return l.svc.Login(ctx)
+	}
+	return comm.CodeOK
+}
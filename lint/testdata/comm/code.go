@@ -0,0 +1,8 @@
+package comm
+
+import "github.com/zjutjh/mygo/kit"
+
+var (
+	CodeOK           = kit.NewCode(0, "成功")
+	CodeUserNotFound = kit.NewCode(10001, "用户不存在")
+)
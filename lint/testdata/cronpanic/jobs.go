@@ -0,0 +1,39 @@
+package cronpanic
+
+import (
+	"log"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CleanJob struct{ fail bool }
+
+func (j *CleanJob) Run() {
+	if j.fail {
+		panic("clean failed") // want `定时任务CleanJob.Run中不应panic`
+	}
+	logrus.Fatal("clean failed") // want `定时任务CleanJob.Run中不应调用Fatal`
+	log.Panicf("clean failed")   // want `定时任务CleanJob.Run中不应调用Panicf`
+	logrus.Info("clean finished")
+	go func() {
+		panic("其他goroutine中的panic不在检查范围内")
+	}()
+}
+
+type SafeJob struct{}
+
+func (j *SafeJob) Run() {
+	defer func() {
+		if err := recover(); err != nil {
+			logrus.Info(err)
+		}
+	}()
+	panic("recovered")
+}
+
+// Runner 名称不以Job结尾, 不是定时任务
+type Runner struct{}
+
+func (r *Runner) Run() {
+	panic("not a job")
+}
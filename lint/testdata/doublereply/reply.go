@@ -0,0 +1,66 @@
+package doublereply
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/foundation/reply"
+	"github.com/zjutjh/mygo/kit"
+
+	"app/comm"
+)
+
+type WriteApi struct{}
+
+func (w *WriteApi) Run(ctx *gin.Context) kit.Code {
+	ctx.JSON(200, gin.H{"ok": true})
+	return comm.CodeOK // want `Run已在第14行自行写入响应`
+}
+
+type BranchApi struct{ found bool }
+
+func (b *BranchApi) Run(ctx *gin.Context) kit.Code {
+	if !b.found {
+		return comm.CodeUserNotFound
+	}
+	if b.found {
+		reply.Fail(ctx, comm.CodeUserNotFound)
+		return comm.CodeUserNotFound // want `Run已在第25行自行写入响应`
+	}
+	// 写入响应的分支已经返回, 这里不会在写入之后到达
+	return comm.CodeOK
+}
+
+type AbortApi struct{ found bool }
+
+func (a *AbortApi) Run(ctx *gin.Context) kit.Code {
+	if !a.found {
+		ctx.String(404, "not found")
+		ctx.Abort()
+		return comm.CodeUserNotFound
+	}
+	if a.found {
+		ctx.AbortWithStatusJSON(200, gin.H{})
+		return comm.CodeOK
+	}
+	return comm.CodeOK
+}
+
+type LoopApi struct{ items []string }
+
+func (l *LoopApi) Run(ctx *gin.Context) kit.Code {
+	for _, item := range l.items {
+		if item == "" {
+			return comm.CodeUserNotFound // want `Run已在第54行自行写入响应`
+		}
+		ctx.String(200, item)
+	}
+	ctx.Abort()
+	return comm.CodeOK
+}
+
+type CallbackApi struct{}
+
+func (c *CallbackApi) Run(ctx *gin.Context) kit.Code {
+	write := func() { ctx.JSON(200, gin.H{}) }
+	_ = write
+	return comm.CodeOK
+}
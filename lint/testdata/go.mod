@@ -0,0 +1,15 @@
+module app
+
+go 1.24
+
+require (
+	github.com/gin-gonic/gin v1.0.0
+	github.com/sirupsen/logrus v1.0.0
+	github.com/zjutjh/mygo v1.0.0
+)
+
+replace (
+	github.com/gin-gonic/gin => ../../analysis/testdata/mod/gin
+	github.com/sirupsen/logrus => ../../analysis/testdata/mod/logrus
+	github.com/zjutjh/mygo => ../../analysis/testdata/mod/mygo
+)
@@ -0,0 +1,52 @@
+package initbind
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zjutjh/mygo/kit"
+)
+
+type LoginApi struct {
+	Request struct {
+		Uri  struct{ ID string }
+		Body struct{ Username string }
+	}
+}
+
+func (l *LoginApi) Run(ctx *gin.Context) kit.Code { return kit.Code{} }
+
+func (l *LoginApi) Init(ctx *gin.Context) (err error) { // want `LoginApi.Init未绑定Request中声明的Body`
+	err = ctx.ShouldBindUri(&l.Request.Uri)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+type SearchApi struct {
+	Request struct {
+		Header struct{ Token string }
+		Query  struct{ Keyword string }
+	}
+}
+
+func (s *SearchApi) Run(ctx *gin.Context) kit.Code { return kit.Code{} }
+
+func (s *SearchApi) Init(ctx *gin.Context) (err error) {
+	if err = ctx.ShouldBindHeader(&s.Request.Header); err != nil {
+		return err
+	}
+	return ctx.ShouldBindQuery(&s.Request.Query)
+}
+
+type InfoApi struct {
+	Request struct {
+		Query struct{ ID string }
+	}
+}
+
+func (i *InfoApi) Run(ctx *gin.Context) kit.Code { return kit.Code{} }
+
+// 接收者未命名时无法生成修复建议
+func (*InfoApi) Init(ctx *gin.Context) error { // want `InfoApi.Init未绑定Request中声明的Query`
+	return nil
+}
@@ -0,0 +1,158 @@
+// Package mock 根据项目中的API定义与路由注册提供本地mock服务
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+const (
+	// CodeHeader 指定响应业务状态码的请求头, 取值为状态码或变量名, 如 20001、CodeUserNotFound
+	CodeHeader = "X-Mock-Code"
+	// CodeQuery 指定响应业务状态码的查询参数, 优先级低于 CodeHeader
+	CodeQuery = "_mock_code"
+)
+
+// Envelope 与 mygo reply.Success/reply.Fail 一致的响应结构
+type Envelope struct {
+	Code    int64  `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data"`
+}
+
+// Server mock服务
+type Server struct {
+	mux   *http.ServeMux
+	codes []analysis.CodeEntry
+	ok    analysis.CodeEntry
+}
+
+// NewServer 为每条路由注册mock处理器, 无法注册的路由 (如与其他路由冲突) 以错误返回
+func NewServer(routes []analysis.Route, codes []analysis.CodeEntry) (*Server, []error) {
+	s := &Server{mux: http.NewServeMux(), codes: codes, ok: analysis.CodeEntry{Var: "CodeOK", Code: 0, Message: "ok"}}
+	for _, c := range codes {
+		if c.Var == "CodeOK" {
+			s.ok = c
+		}
+	}
+	errs := make([]error, 0)
+	for _, r := range routes {
+		if err := s.handle(r); err != nil {
+			errs = append(errs, i18n.Errorf("注册路由[%s %s]失败: %s", r.Method, r.Path, err))
+		}
+	}
+	return s, errs
+}
+
+// handle 注册一条路由, ServeMux在模式冲突时panic, 此处转为错误
+func (s *Server) handle(r analysis.Route) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("%v", e)
+		}
+	}()
+	pattern := Pattern(r.Path)
+	if r.Method != "Any" {
+		pattern = r.Method + " " + pattern
+	}
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, req *http.Request) {
+		s.serve(w, req, r)
+	})
+	return nil
+}
+
+// Pattern 将gin格式的路径转换为 http.ServeMux 的模式, 如 /user/:id/*path -> /user/{id}/{path...}
+func Pattern(path string) string {
+	segs := strings.Split(path, "/")
+	for i, seg := range segs {
+		switch {
+		case strings.HasPrefix(seg, ":"):
+			segs[i] = "{" + seg[1:] + "}"
+		case strings.HasPrefix(seg, "*"):
+			segs[i] = "{" + seg[1:] + "...}"
+		}
+	}
+	pattern := strings.Join(segs, "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "{$}"
+	}
+	return pattern
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// 允许前端开发服务器跨域访问
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "*")
+	if req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	s.mux.ServeHTTP(w, req)
+}
+
+func (s *Server) serve(w http.ResponseWriter, req *http.Request, r analysis.Route) {
+	start := time.Now()
+	env := Envelope{Code: s.ok.Code, Message: s.ok.Message}
+	if r.API != nil {
+		env.Data = Value(r.API.FieldType("Response"), "")
+	}
+
+	want := req.Header.Get(CodeHeader)
+	if want == "" {
+		want = req.URL.Query().Get(CodeQuery)
+	}
+	if want != "" {
+		code, ok := s.findCode(r, want)
+		if !ok {
+			http.Error(w, i18n.Sprintf("路由[%s %s]不会返回业务状态码[%s], 可选的值有: %s", r.Method, r.Path, want, s.codeNames(r)), http.StatusBadRequest)
+			comm.Log.Warnf("%s %s -> %s", req.Method, req.URL.Path, i18n.Sprintf("业务状态码[%s]不存在", want))
+			return
+		}
+		if code.Code != s.ok.Code {
+			env = Envelope{Code: code.Code, Message: code.Message}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(env)
+	comm.Log.Infof("%s %s -> %d %s (%s)", req.Method, req.URL.Path, env.Code, env.Message, time.Since(start).Round(time.Microsecond))
+}
+
+// candidates 路由可返回的业务状态码, 未生成注册文件时为项目中的全部业务状态码
+func (s *Server) candidates(r analysis.Route) []analysis.CodeEntry {
+	if len(r.Codes) > 0 {
+		return r.Codes
+	}
+	return s.codes
+}
+
+// findCode 按状态码或变量名查找路由可返回的业务状态码
+func (s *Server) findCode(r analysis.Route, want string) (analysis.CodeEntry, bool) {
+	n, err := strconv.ParseInt(want, 10, 64)
+	for _, c := range s.candidates(r) {
+		if (err == nil && c.Code == n) || c.Var == want {
+			return c, true
+		}
+	}
+	if (err == nil && n == s.ok.Code) || want == s.ok.Var {
+		return s.ok, true
+	}
+	return analysis.CodeEntry{}, false
+}
+
+func (s *Server) codeNames(r analysis.Route) string {
+	names := make([]string, 0)
+	for _, c := range s.candidates(r) {
+		names = append(names, fmt.Sprintf("%d(%s)", c.Code, c.Var))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,92 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zjutjh/gbc/analysis"
+)
+
+func TestPattern(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/user/login", "/user/login"},
+		{"/user/:id", "/user/{id}"},
+		{"/user/:id/posts/:post", "/user/{id}/posts/{post}"},
+		{"/static/*filepath", "/static/{filepath...}"},
+		{"/user/", "/user/{$}"},
+		{"/", "/{$}"},
+	}
+	for _, tt := range tests {
+		if got := Pattern(tt.path); got != tt.want {
+			t.Errorf("Pattern(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestServer(t *testing.T) {
+	ok := analysis.CodeEntry{Var: "CodeOK", Code: 0, Message: "成功"}
+	notFound := analysis.CodeEntry{Var: "CodeUserNotFound", Code: 10001, Message: "用户不存在"}
+	wrong := analysis.CodeEntry{Var: "CodePasswordWrong", Code: 10002, Message: "密码错误"}
+	routes := []analysis.Route{
+		{Method: "GET", Path: "/user/:id", Codes: []analysis.CodeEntry{notFound}},
+		{Method: "POST", Path: "/user/login"},
+		{Method: "Any", Path: "/static/*filepath"},
+		{Method: "GET", Path: "/files/"},
+		// 与 /user/:id 冲突, 注册失败
+		{Method: "GET", Path: "/user/:name"},
+	}
+	s, errs := NewServer(routes, []analysis.CodeEntry{ok, notFound, wrong})
+	if len(errs) != 1 {
+		t.Fatalf("NewServer() errors = %v, want the conflicting route only", errs)
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		header   string
+		status   int
+		wantCode int64
+	}{
+		{name: "ok by default", method: "GET", target: "/user/1", status: 200, wantCode: 0},
+		{name: "code from header", method: "GET", target: "/user/1", header: "10001", status: 200, wantCode: 10001},
+		{name: "var name from query", method: "GET", target: "/user/1?_mock_code=CodeUserNotFound", status: 200, wantCode: 10001},
+		{name: "header before query", method: "GET", target: "/user/1?_mock_code=CodeOK", header: "CodeUserNotFound", status: 200, wantCode: 10001},
+		{name: "ok code is always allowed", method: "GET", target: "/user/1", header: "CodeOK", status: 200, wantCode: 0},
+		{name: "code not returned by the route", method: "GET", target: "/user/1", header: "10002", status: 400},
+		{name: "all codes without registered codes", method: "POST", target: "/user/login?_mock_code=10002", status: 200, wantCode: 10002},
+		{name: "unknown code", method: "POST", target: "/user/login?_mock_code=99999", status: 400},
+		{name: "method mismatch", method: "PUT", target: "/user/login", status: 405},
+		{name: "any method and wildcard", method: "DELETE", target: "/static/js/app.js", status: 200, wantCode: 0},
+		{name: "trailing slash matches exactly", method: "GET", target: "/files/", status: 200, wantCode: 0},
+		{name: "trailing slash is not a prefix", method: "GET", target: "/files/a", status: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set(CodeHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			env := Envelope{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &env); err != nil {
+				t.Fatal(err)
+			}
+			if env.Code != tt.wantCode {
+				t.Errorf("code = %d, want %d", env.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/types"
+	"hash/fnv"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/zjutjh/gbc/analysis"
+)
+
+// maxDepth 构造嵌套结构体的最大深度
+const maxDepth = 6
+
+// mockTime 时间类字段使用的固定时间, 使每次响应保持一致
+var mockTime = time.Date(2025, 1, 1, 8, 0, 0, 0, time.FixedZone("CST", 8*3600))
+
+// field 对象中的一个字段
+type field struct {
+	Key   string
+	Value any
+}
+
+// object 保持字段声明顺序的JSON对象
+type object []field
+
+func (o object) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Value 按类型与字段名构造看起来合理的JSON值, t为nil时返回nil
+func Value(t types.Type, name string) any {
+	if t == nil {
+		return nil
+	}
+	g := &generator{visiting: make(map[*types.TypeName]bool)}
+	return g.value(t, name, 0)
+}
+
+// generator 记录正在展开的命名类型, 递归类型再次出现时不再展开: 指针与映射为null, 切片为[]
+type generator struct {
+	visiting map[*types.TypeName]bool
+}
+
+func (g *generator) value(t types.Type, name string, depth int) any {
+	if depth > maxDepth {
+		return nil
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		obj := named.Origin().Obj()
+		if g.visiting[obj] {
+			return nil
+		}
+		if _, ok := named.Underlying().(*types.Struct); ok {
+			g.visiting[obj] = true
+			defer delete(g.visiting, obj)
+		}
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok && named.Obj().Pkg() != nil {
+		switch named.Obj().Pkg().Path() + "." + named.Obj().Name() {
+		case "time.Time":
+			return mockTime.Format(time.RFC3339)
+		case "time.Duration":
+			return int64(time.Second)
+		case "encoding/json.RawMessage":
+			return object{}
+		}
+		// 自定义了JSON序列化的类型无法根据结构推测取值
+		if analysis.HasMethod(named, "MarshalJSON") {
+			return nil
+		}
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return basicValue(u, name)
+	case *types.Pointer:
+		return g.value(u.Elem(), name, depth+1)
+	case *types.Slice:
+		if b, ok := u.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Byte {
+			return "bW9jaw=="
+		}
+		if g.recursive(u.Elem()) {
+			return []any{}
+		}
+		elem := singular(name)
+		return []any{g.value(u.Elem(), elem, depth+1), g.value(u.Elem(), elem+"2", depth+1)}
+	case *types.Array:
+		if g.recursive(u.Elem()) {
+			return []any{}
+		}
+		n := min(u.Len(), 2)
+		res := make([]any, 0, n)
+		for i := int64(0); i < n; i++ {
+			res = append(res, g.value(u.Elem(), singular(name), depth+1))
+		}
+		return res
+	case *types.Map:
+		if g.recursive(u.Elem()) {
+			return object{}
+		}
+		return object{{Key: "key", Value: g.value(u.Elem(), name, depth+1)}}
+	case *types.Struct:
+		return g.structValue(u, depth)
+	}
+	// 接口、函数、通道等无法确定取值
+	return nil
+}
+
+// structValue 按 encoding/json 的规则展开结构体字段
+func (g *generator) structValue(st *types.Struct, depth int) object {
+	obj := object{}
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i)).Get("json")
+		key, _, _ := strings.Cut(tag, ",")
+		if key == "-" && !strings.HasPrefix(tag, "-,") {
+			continue
+		}
+		if f.Embedded() && key == "" {
+			typ := f.Type()
+			if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+				typ = ptr.Elem()
+			}
+			if embedded, ok := typ.Underlying().(*types.Struct); ok {
+				obj = append(obj, g.structValue(embedded, depth+1)...)
+				continue
+			}
+		}
+		if !f.Exported() {
+			continue
+		}
+		if key == "" {
+			key = f.Name()
+		}
+		v := g.value(f.Type(), key, depth+1)
+		if strings.Contains(tag, ",string") {
+			if b, err := json.Marshal(v); err == nil {
+				v = string(b)
+			}
+		}
+		obj = append(obj, field{Key: key, Value: v})
+	}
+	return obj
+}
+
+// recursive 元素类型 (去掉指针后) 是否为正在展开的命名类型
+func (g *generator) recursive(t types.Type) bool {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && g.visiting[named.Origin().Obj()]
+}
+
+// basicValue 根据字段名推测取值, 无法推测时使用与名称相关的稳定取值
+func basicValue(b *types.Basic, name string) any {
+	lower := strings.ToLower(name)
+	has := func(words ...string) bool {
+		for _, w := range words {
+			if strings.Contains(lower, w) {
+				return true
+			}
+		}
+		return false
+	}
+	info := b.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return true
+	case info&types.IsString != 0:
+		switch {
+		case has("email", "mail"):
+			return "user@example.com"
+		case has("phone", "mobile", "tel"):
+			return "13800138000"
+		case has("avatar", "image", "img", "icon", "pic"):
+			return "https://example.com/static/avatar.png"
+		case has("url", "link", "href", "website"):
+			return "https://example.com"
+		case has("time", "date") || strings.HasSuffix(lower, "_at") || strings.HasSuffix(name, "At"):
+			return mockTime.Format(time.DateTime)
+		case has("uuid", "guid"):
+			return "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+		case has("token", "secret", "key", "hash", "sign"):
+			return "c0ffee254729296a45a3885639ac7ac1"
+		case lower == "id" || strings.HasSuffix(lower, "_id") || strings.HasSuffix(name, "ID"):
+			return "10001"
+		case has("nickname", "username"):
+			return "jhwl"
+		case has("name"):
+			return "精弘网络"
+		case has("title"):
+			return "示例标题"
+		case has("desc", "content", "remark", "comment", "message", "msg", "text"):
+			return "这是一段示例文本"
+		case lower == "ip" || strings.HasSuffix(lower, "_ip") || strings.HasSuffix(name, "IP"):
+			return "127.0.0.1"
+		case has("status", "state", "type"):
+			return "normal"
+		case lower == "":
+			return "string"
+		}
+		return lower
+	case info&types.IsFloat != 0:
+		switch {
+		case has("lat"):
+			return 30.2741
+		case has("lng", "lon"):
+			return 120.1551
+		case has("price", "amount", "money", "fee", "cost"):
+			return 9.9
+		case has("rate", "ratio", "percent"):
+			return 0.5
+		}
+		return float64(stable(lower)%1000) / 10
+	case info&types.IsInteger != 0:
+		switch {
+		case has("time", "date") || strings.HasSuffix(lower, "_at") || strings.HasSuffix(name, "At"):
+			return mockTime.Unix()
+		case lower == "id" || strings.HasSuffix(lower, "_id") || strings.HasSuffix(name, "ID"):
+			return 10001
+		case has("size", "limit"):
+			return 20
+		case has("page"):
+			return 1
+		case has("age"):
+			return 18
+		case has("total", "count", "num"):
+			return 42
+		case has("status", "state", "type", "level", "gender", "sex"):
+			return 1
+		case has("year"):
+			return mockTime.Year()
+		}
+		return int64(stable(lower)%100) + 1
+	}
+	return nil
+}
+
+// singular 切片元素使用的名称, 如 tags -> tag
+func singular(name string) string {
+	if s, ok := strings.CutSuffix(name, "List"); ok {
+		return s
+	}
+	if s, ok := strings.CutSuffix(name, "_list"); ok {
+		return s
+	}
+	if strings.HasSuffix(name, "ss") {
+		return name
+	}
+	return strings.TrimSuffix(name, "s")
+}
+
+// stable 与名称相关的稳定数值
+func stable(name string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return h.Sum32()
+}
@@ -0,0 +1,77 @@
+package mock
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+const valueSrc = `package p
+
+import "time"
+
+type Node struct {
+	Name     string           ` + "`json:\"name\"`" + `
+	Children []Node           ` + "`json:\"children\"`" + `
+	Parent   *Node            ` + "`json:\"parent\"`" + `
+	Index    map[string]*Node ` + "`json:\"index\"`" + `
+}
+
+type Dept struct {
+	ID    int64   ` + "`json:\"id\"`" + `
+	Users []*User ` + "`json:\"users\"`" + `
+}
+
+type User struct {
+	ID        int64     ` + "`json:\"id\"`" + `
+	Dept      *Dept     ` + "`json:\"dept\"`" + `
+	CreatedAt time.Time ` + "`json:\"created_at\"`" + `
+}
+
+type Pair struct {
+	Left  Dept ` + "`json:\"left\"`" + `
+	Right Dept ` + "`json:\"right\"`" + `
+}
+`
+
+func TestValue(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", valueSrc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := (&types.Config{Importer: importer.ForCompiler(fset, "source", nil)}).Check("p", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		typ  string
+		want string
+	}{
+		// 自身递归: 切片为[], 指针为null, 映射为{}
+		{"Node", `{"name":"精弘网络","children":[],"parent":null,"index":{}}`},
+		// 相互递归: 再次遇到正在展开的Dept时停止
+		{"Dept", `{"id":10001,"users":[{"id":10001,"dept":null,"created_at":"2025-01-01T08:00:00+08:00"},{"id":10001,"dept":null,"created_at":"2025-01-01T08:00:00+08:00"}]}`},
+		// 同一类型在兄弟字段中出现不是递归, 均完整展开
+		{"Pair", `{"left":{"id":10001,"users":[{"id":10001,"dept":null,"created_at":"2025-01-01T08:00:00+08:00"},{"id":10001,"dept":null,"created_at":"2025-01-01T08:00:00+08:00"}]},"right":{"id":10001,"users":[{"id":10001,"dept":null,"created_at":"2025-01-01T08:00:00+08:00"},{"id":10001,"dept":null,"created_at":"2025-01-01T08:00:00+08:00"}]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			got, err := json.Marshal(Value(pkg.Scope().Lookup(tt.typ).Type(), ""))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Value(%s) =\n%s\nwant\n%s", tt.typ, got, tt.want)
+			}
+		})
+	}
+	if v := Value(nil, ""); v != nil {
+		t.Errorf("Value(nil) = %v, want nil", v)
+	}
+}
@@ -0,0 +1,9 @@
+package comm
+
+import "github.com/zjutjh/mygo/kit"
+
+// 业务状态码
+var (
+	CodeOK               = kit.NewCode(0, "成功")
+	CodeParameterInvalid = kit.NewCode(10001, "参数错误")
+)
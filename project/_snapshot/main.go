@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
+	"github.com/zjutjh/mygo/nlog"
+
+	"app/register"
+	_ "app/register/generate"
+	"app/router"
+)
+
+func main() {
+	root := &cobra.Command{
+		Use: "app",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			engine := gin.New()
+			router.Route(engine)
+			return engine.Run(":8080")
+		},
+	}
+	// gbc:begin cmd
+	register.Command(root)
+	// gbc:end cmd
+	if err := root.Execute(); err != nil {
+		nlog.Pick().WithError(err).Warn("启动失败")
+	}
+}
@@ -0,0 +1,7 @@
+package register
+
+import "github.com/spf13/cobra"
+
+// Command 注册命令
+func Command(root *cobra.Command) {
+}
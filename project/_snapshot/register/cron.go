@@ -0,0 +1,11 @@
+package register
+
+// Job 定时任务
+type Job interface {
+	Run()
+}
+
+// Cron 注册定时任务, key为cron表达式
+func Cron() map[string]Job {
+	return map[string]Job{}
+}
@@ -0,0 +1,7 @@
+package router
+
+import "github.com/gin-gonic/gin"
+
+// Route 注册API路由
+func Route(r *gin.Engine) {
+}
@@ -0,0 +1,129 @@
+package project
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// archiveEntry 归档中的一个条目
+type archiveEntry struct {
+	name string
+	dir  bool
+	mode os.FileMode
+	open func() (io.ReadCloser, error)
+}
+
+func extractTarGz(r io.Reader, dest string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return i18n.Errorf("读取归档失败: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	entries := make([]archiveEntry, 0)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return i18n.Errorf("读取归档失败: %w", err)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			entries = append(entries, archiveEntry{name: hdr.Name, dir: true})
+		case tar.TypeReg:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return i18n.Errorf("读取归档失败: %w", err)
+			}
+			entries = append(entries, archiveEntry{
+				name: hdr.Name,
+				mode: os.FileMode(hdr.Mode).Perm(),
+				open: func() (io.ReadCloser, error) {
+					return io.NopCloser(bytes.NewReader(data)), nil
+				},
+			})
+		}
+	}
+	return extractEntries(entries, dest)
+}
+
+func extractZip(r io.ReaderAt, size int64, dest string) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return i18n.Errorf("读取归档失败: %w", err)
+	}
+	entries := make([]archiveEntry, 0, len(zr.File))
+	for _, f := range zr.File {
+		entries = append(entries, archiveEntry{
+			name: f.Name,
+			dir:  f.FileInfo().IsDir(),
+			mode: f.Mode().Perm(),
+			open: f.Open,
+		})
+	}
+	return extractEntries(entries, dest)
+}
+
+// extractEntries 解压归档条目 (跳过.git目录), 若所有条目位于同一顶层目录下 (如GitHub生成的归档) 则去除该目录
+func extractEntries(entries []archiveEntry, dest string) error {
+	strip := commonRoot(entries)
+	for _, e := range entries {
+		name := strings.TrimPrefix(path.Clean("/"+e.name), "/")
+		if strip != "" {
+			name = strings.TrimPrefix(strings.TrimPrefix(name, strip), "/")
+		}
+		if name == "" || name == "." || name == ".git" || strings.HasPrefix(name, ".git/") {
+			continue
+		}
+		target := filepath.Join(dest, filepath.FromSlash(name))
+		if e.dir {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		rc, err := e.open()
+		if err != nil {
+			return err
+		}
+		mode := e.mode
+		if mode == 0 {
+			mode = 0644
+		}
+		err = writeFile(target, rc, mode)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func commonRoot(entries []archiveEntry) string {
+	root := ""
+	for _, e := range entries {
+		name := strings.TrimPrefix(path.Clean("/"+e.name), "/")
+		first, rest, _ := strings.Cut(name, "/")
+		if rest == "" && !e.dir {
+			// 顶层存在文件
+			return ""
+		}
+		if root == "" {
+			root = first
+		} else if root != first {
+			return ""
+		}
+	}
+	return root
+}
@@ -0,0 +1,200 @@
+package project
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// CreateOptions 创建项目的参数
+type CreateOptions struct {
+	AppName    string
+	Target     string   // 项目最终所在目录
+	Locations  []string // 模板来源, 依次尝试直到成功
+	Ref        string
+	ModulePath string
+
+	With    []string
+	Without []string
+	Ask     func(c Component) bool // 询问未通过 With/Without 指定的组件
+
+	Force bool // 目标目录非空时仍然覆盖
+
+	// 可选的创建后步骤
+	GitInit bool
+	Tidy    bool
+	Codegen func(dir string) error
+}
+
+// Create 在临时目录中完成模板获取、组件裁剪、改写及创建后步骤, 全部成功后原子地移动到目标目录
+// 任一步骤失败都会清理临时目录, 目标目录保持原样
+func Create(opts *CreateOptions) error {
+	if err := ValidateAppName(opts.AppName); err != nil {
+		return comm.UsageErrorf("%w", err)
+	}
+	if opts.ModulePath != "" {
+		if err := ValidateModulePath(opts.ModulePath); err != nil {
+			return comm.UsageErrorf("%w", err)
+		}
+	}
+	if !IsEmptyDir(opts.Target) && !opts.Force {
+		return comm.UsageErrorf("目录[%s]已存在且不为空, 如需覆盖请使用 --force", opts.Target)
+	}
+
+	// 临时目录与目标目录位于同一父目录下, 保证rename的原子性
+	parent := filepath.Dir(opts.Target)
+	if err := comm.EnsureDir(parent); err != nil {
+		return comm.IOErrorf("%w", err)
+	}
+	tmp, err := os.MkdirTemp(parent, ".gbc-new-*")
+	if err != nil {
+		return comm.IOErrorf("创建临时目录失败: %w", err)
+	}
+	defer func() {
+		if rmErr := os.RemoveAll(tmp); rmErr != nil {
+			comm.Log.Warnf("清理临时目录[%s]失败: %s", tmp, rmErr.Error())
+		}
+	}()
+	work := filepath.Join(tmp, opts.AppName)
+
+	lock, err := render(opts.Locations, opts.Ref, work, opts.AppName, opts.ModulePath, func(m *Manifest) (map[string]bool, error) {
+		selected, err := m.Select(opts.With, opts.Without, opts.Ask)
+		if err != nil {
+			return nil, comm.UsageErrorf("%w", err)
+		}
+		return selected, nil
+	})
+	if err != nil {
+		return err
+	}
+	// 锁文件在创建后步骤之前写入, 以便包含在git初始提交中
+	if err := WriteLock(work, lock); err != nil {
+		return comm.IOErrorf("写入%s失败: %w", LockFile, err)
+	}
+	if err := postCreate(work, opts); err != nil {
+		return err
+	}
+	return place(work, opts.Target, filepath.Join(tmp, "backup"))
+}
+
+// render 获取模板到dest, 按组件选择、应用名称与模块路径生成项目文件, 返回对应的模板锁
+// 模板没有组件清单时以nil调用selectFn
+func render(locations []string, ref, dest, appName, modulePath string, selectFn func(m *Manifest) (map[string]bool, error)) (*Lock, error) {
+	src, err := fetchTemplate(locations, ref, dest)
+	if err != nil {
+		return nil, err
+	}
+	// 按需移除模板中的可选组件
+	var selected map[string]bool
+	manifest, err := LoadManifest(dest)
+	if err != nil {
+		return nil, err
+	}
+	if selected, err = selectFn(manifest); err != nil {
+		return nil, err
+	}
+	if manifest != nil {
+		if err := ApplyComponents(dest, manifest, selected); err != nil {
+			return nil, err
+		}
+	}
+	// 改写模块路径与应用名称
+	if err := Rewrite(dest, appName, modulePath); err != nil {
+		return nil, err
+	}
+	if modulePath, err = comm.ModulePath(dest); err != nil {
+		return nil, err
+	}
+	files, err := hashFiles(dest)
+	if err != nil {
+		return nil, err
+	}
+	return &Lock{
+		Template: LockTemplate{
+			Location: lockLocation(src),
+			Ref:      ref,
+			Revision: src.Revision,
+		},
+		AppName:    appName,
+		ModulePath: modulePath,
+		Components: selected,
+		Files:      files,
+	}, nil
+}
+
+// fetchTemplate 依次尝试模板来源直到成功
+func fetchTemplate(locations []string, ref, dest string) (*Source, error) {
+	for i, location := range locations {
+		src, err := ParseSource(location, ref)
+		if err == nil {
+			comm.Log.Debugf("使用模板来源[%s]", src)
+			err = src.Fetch(dest)
+		}
+		if err == nil {
+			if src.Kind == SourceSnapshot && !snapshotPinsDeps() {
+				comm.Log.Warnf("内置模板快照不含依赖版本, 请在可以访问GOPROXY时于项目中执行 go mod tidy")
+			}
+			return src, nil
+		}
+		if i == len(locations)-1 {
+			return nil, comm.EnvErrorf("获取模板失败: %w", err)
+		}
+		comm.Log.Warnf("获取模板失败: %s, 尝试使用[%s]", err.Error(), locations[i+1])
+		if err := os.RemoveAll(dest); err != nil {
+			return nil, err
+		}
+	}
+	return nil, comm.UsageErrorf("未指定模板来源")
+}
+
+func postCreate(dir string, opts *CreateOptions) error {
+	if opts.Tidy {
+		comm.Log.Infof("执行 go mod tidy")
+		if err := runCommand(dir, "go", "mod", "tidy"); err != nil {
+			if errors.Is(err, exec.ErrNotFound) {
+				return comm.EnvErrorf("执行go mod tidy失败: %w", err)
+			}
+			return comm.FailureErrorf("执行go mod tidy失败: %w", err)
+		}
+	}
+	if opts.Codegen != nil {
+		comm.Log.Infof("执行 gbc codegen")
+		if err := opts.Codegen(dir); err != nil {
+			return i18n.Errorf("执行gbc codegen失败: %w", err)
+		}
+	}
+	if opts.GitInit {
+		comm.Log.Infof("初始化git仓库")
+		steps := [][]string{
+			{"init", "--quiet"},
+			{"add", "--all"},
+			{"commit", "--quiet", "-m", "init project from gbc-template"},
+		}
+		for _, step := range steps {
+			if err := runGit(dir, step...); err != nil {
+				return comm.EnvErrorf("执行git %s失败 (请确认已配置git user.name/user.email): %w", step[0], err)
+			}
+		}
+	}
+	return nil
+}
+
+// place 将work移动到target, target已存在时先移动到backup, 失败时恢复
+func place(work, target, backup string) error {
+	if _, err := os.Stat(target); err == nil {
+		if err := os.Rename(target, backup); err != nil {
+			return comm.IOErrorf("移动已存在的目录[%s]失败: %w", target, err)
+		}
+	}
+	if err := os.Rename(work, target); err != nil {
+		if _, statErr := os.Stat(backup); statErr == nil {
+			err = errors.Join(err, os.Rename(backup, target))
+		}
+		return comm.IOErrorf("移动项目到目录[%s]失败: %w", target, err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zjutjh/gbc/comm"
+)
+
+func TestCreateExitCodes(t *testing.T) {
+	tmpl := t.TempDir()
+	writeFiles(t, tmpl, map[string]string{
+		"go.mod":     "module app\n\ngo 1.24\n",
+		"main.go":    "package main\n\nfunc main() {}\n",
+		ManifestFile: "components:\n  - name: cron\n    files:\n      - cron\n",
+	})
+	plain := t.TempDir()
+	writeFiles(t, plain, map[string]string{
+		"go.mod":  "module app\n\ngo 1.24\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+	nonEmpty := t.TempDir()
+	writeFiles(t, nonEmpty, map[string]string{"keep.txt": "keep"})
+
+	tests := []struct {
+		name  string
+		opts  CreateOptions
+		setup func(t *testing.T)
+		want  int
+	}{
+		{name: "app name is a path", opts: CreateOptions{AppName: "../x"}, want: comm.ExitUsage},
+		{name: "target not empty", opts: CreateOptions{Target: nonEmpty}, want: comm.ExitUsage},
+		{name: "invalid module", opts: CreateOptions{ModulePath: "-bad"}, want: comm.ExitUsage},
+		{name: "unknown component", opts: CreateOptions{With: []string{"redis"}}, want: comm.ExitUsage},
+		{name: "components without manifest", opts: CreateOptions{Locations: []string{plain}, Without: []string{"cron"}}, want: comm.ExitUsage},
+		{name: "missing template", opts: CreateOptions{Locations: []string{filepath.Join(tmpl, "missing")}}, want: comm.ExitEnv},
+		{
+			name: "missing go",
+			opts: CreateOptions{Tidy: true},
+			setup: func(t *testing.T) {
+				t.Setenv("PATH", t.TempDir())
+			},
+			want: comm.ExitEnv,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup(t)
+			}
+			opts := tt.opts
+			if opts.AppName == "" {
+				opts.AppName = "app"
+			}
+			if opts.Target == "" {
+				opts.Target = filepath.Join(t.TempDir(), "app")
+			}
+			if opts.Locations == nil {
+				opts.Locations = []string{tmpl}
+			}
+			opts.Ask = func(c Component) bool { return c.Default }
+			err := Create(&opts)
+			if got := comm.ExitCode(err); got != tt.want {
+				t.Errorf("Create() exit code = %d (%v), want %d", got, err, tt.want)
+			}
+			if opts.Target != nonEmpty {
+				if _, err := os.Stat(opts.Target); !os.IsNotExist(err) {
+					t.Errorf("target should not be created, stat error: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package project
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// LockFile 记录项目创建时所用模板版本及模板文件摘要的锁文件, gbc sync-template 以此作为三方合并的基准
+const LockFile = "gbc.lock.yaml"
+
+const lockHeader = "# 由gbc生成, 记录项目所基于的模板版本, 供 gbc sync-template 使用, 请勿手动修改\n"
+
+// Lock 模板锁文件
+type Lock struct {
+	Template   LockTemplate      `yaml:"template"`
+	AppName    string            `yaml:"app_name"`
+	ModulePath string            `yaml:"module_path"`
+	Components map[string]bool   `yaml:"components,omitempty"`
+	Files      map[string]string `yaml:"files"` // 相对路径 -> 渲染后模板文件的sha256
+}
+
+// LockTemplate 模板来源及版本
+type LockTemplate struct {
+	Location string `yaml:"location"`
+	Ref      string `yaml:"ref,omitempty"`
+	Revision string `yaml:"revision,omitempty"`
+}
+
+// ReadLock 读取项目中的模板锁文件
+func ReadLock(dir string) (*Lock, error) {
+	data, err := os.ReadFile(filepath.Join(dir, LockFile))
+	if os.IsNotExist(err) {
+		return nil, comm.EnvErrorf("项目中不存在%s, 仅支持同步由 gbc new 创建并保留了锁文件的项目", LockFile)
+	}
+	if err != nil {
+		return nil, comm.IOErrorf("%w", err)
+	}
+	lock := &Lock{}
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		return nil, i18n.Errorf("解析%s失败: %w", LockFile, err)
+	}
+	if lock.Template.Location == "" {
+		return nil, i18n.Errorf("%s中缺少模板来源", LockFile)
+	}
+	return lock, nil
+}
+
+// WriteLock 写入模板锁文件
+func WriteLock(dir string, lock *Lock) error {
+	buf := bytes.NewBufferString(i18n.T(lockHeader))
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(lock); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, LockFile), buf.Bytes(), 0644)
+}
+
+// hashFiles 计算目录下全部文件的摘要, 不包含锁文件自身
+func hashFiles(dir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := walkFiles(dir, func(path string, d fs.DirEntry) error {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == LockFile {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[rel] = hashBytes(data)
+		return nil
+	})
+	return files, err
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// lockLocation 本地模板来源记录为绝对路径, 保证在其他目录下执行同步时仍可访问
+func lockLocation(src *Source) string {
+	if src.Kind == SourceSnapshot || isGitURL(src.Location) {
+		return src.Location
+	}
+	if abs, err := filepath.Abs(src.Location); err == nil {
+		return abs
+	}
+	return src.Location
+}
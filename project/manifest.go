@@ -0,0 +1,270 @@
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"gopkg.in/yaml.v3"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// ManifestFile 模板中声明可选组件的清单文件
+const ManifestFile = "gbc.template.yaml"
+
+// 注册片段的起止标记, 以注释形式写在模板文件中, 如:
+//
+//	// gbc:begin redis
+//	register.Redis()
+//	// gbc:end redis
+const (
+	snippetBegin = "gbc:begin"
+	snippetEnd   = "gbc:end"
+)
+
+// Manifest 模板组件清单
+type Manifest struct {
+	Components []Component `yaml:"components"`
+}
+
+// Component 模板中的可选组件
+type Component struct {
+	Name     string   `yaml:"name"`
+	Desc     string   `yaml:"desc"`
+	Default  bool     `yaml:"default"`
+	Files    []string `yaml:"files"`    // 组件独占的文件或目录, 未选择时删除
+	Snippets []string `yaml:"snippets"` // 含有该组件注册片段的文件
+}
+
+// LoadManifest 读取模板目录中的组件清单, 不存在时返回nil, 即模板没有可选组件
+func LoadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := yaml.Unmarshal(data, m); err != nil {
+		return nil, i18n.Errorf("解析%s失败: %w", ManifestFile, err)
+	}
+	names := make(map[string]struct{})
+	for _, c := range m.Components {
+		if c.Name == "" {
+			return nil, i18n.Errorf("%s中存在未命名的组件", ManifestFile)
+		}
+		if _, ok := names[c.Name]; ok {
+			return nil, i18n.Errorf("%s中组件[%s]重复声明", ManifestFile, c.Name)
+		}
+		names[c.Name] = struct{}{}
+		for _, file := range append(slices.Clone(c.Files), c.Snippets...) {
+			if !isLocalPath(file) {
+				return nil, i18n.Errorf("%s中组件[%s]的路径[%s]不在项目目录内", ManifestFile, c.Name, file)
+			}
+		}
+	}
+	return m, nil
+}
+
+// isLocalPath 路径为项目目录内的相对路径, 且不是项目目录本身
+func isLocalPath(file string) bool {
+	file = filepath.FromSlash(file)
+	return filepath.IsLocal(file) && filepath.Clean(file) != "."
+}
+
+// Names 全部组件名称
+func (m *Manifest) Names() []string {
+	if m == nil {
+		return nil
+	}
+	names := make([]string, 0, len(m.Components))
+	for _, c := range m.Components {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// Select 根据 --with/--without 决定组件取舍, 均未指定的组件交由ask决定
+func (m *Manifest) Select(with, without []string, ask func(c Component) bool) (map[string]bool, error) {
+	if m == nil {
+		if len(with) > 0 || len(without) > 0 {
+			return nil, i18n.Errorf("模板中没有%s, 不支持通过 --with/--without 选择组件", ManifestFile)
+		}
+		return nil, nil
+	}
+	for _, name := range append(slices.Clone(with), without...) {
+		if !slices.Contains(m.Names(), name) {
+			return nil, i18n.Errorf("模板中不存在组件[%s], 可选的组件有: %s", name, strings.Join(m.Names(), "、"))
+		}
+	}
+	selected := make(map[string]bool, len(m.Components))
+	for _, c := range m.Components {
+		inWith, inWithout := slices.Contains(with, c.Name), slices.Contains(without, c.Name)
+		switch {
+		case inWith && inWithout:
+			return nil, i18n.Errorf("组件[%s]同时出现在 --with 与 --without 中", c.Name)
+		case inWith:
+			selected[c.Name] = true
+		case inWithout:
+			selected[c.Name] = false
+		default:
+			selected[c.Name] = ask(c)
+		}
+	}
+	return selected, nil
+}
+
+// ApplyComponents 删除未选择组件的文件与注册片段, 清理不再使用的导入, 并删除清单文件
+func ApplyComponents(dir string, m *Manifest, selected map[string]bool) error {
+	snippetFiles := make([]string, 0)
+	for _, c := range m.Components {
+		snippetFiles = append(snippetFiles, c.Snippets...)
+		if selected[c.Name] {
+			continue
+		}
+		comm.Log.Debugf("移除组件[%s]", c.Name)
+		for _, file := range c.Files {
+			if err := os.RemoveAll(filepath.Join(dir, filepath.FromSlash(file))); err != nil {
+				return err
+			}
+		}
+	}
+	slices.Sort(snippetFiles)
+	for _, file := range slices.Compact(snippetFiles) {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			// 所在组件已被整体移除
+			continue
+		}
+		if err := applySnippets(path, selected); err != nil {
+			return i18n.Errorf("处理文件[%s]中的组件片段失败: %w", file, err)
+		}
+	}
+	return os.Remove(filepath.Join(dir, ManifestFile))
+}
+
+// applySnippets 去除已选组件的片段标记, 删除未选组件的片段
+func applySnippets(path string, selected map[string]bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	out := bytes.Buffer{}
+	skipping := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, ok := snippetMarker(line, snippetBegin); ok {
+			if skipping == "" && !selected[name] {
+				skipping = name
+			}
+			continue
+		}
+		if name, ok := snippetMarker(line, snippetEnd); ok {
+			if name == skipping {
+				skipping = ""
+			}
+			continue
+		}
+		if skipping == "" {
+			out.WriteString(line)
+			out.WriteByte('\n')
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if skipping != "" {
+		return i18n.Errorf("组件[%s]的片段缺少结束标记", skipping)
+	}
+	src := out.Bytes()
+	if strings.HasSuffix(path, ".go") {
+		if src, err = removeUnusedImports(path, src); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, src, 0644)
+}
+
+func snippetMarker(line, marker string) (string, bool) {
+	_, after, ok := strings.Cut(line, marker)
+	if !ok {
+		return "", false
+	}
+	fields := strings.Fields(after)
+	if len(fields) == 0 {
+		return "", false
+	}
+	return fields[0], true
+}
+
+// removeUnusedImports 删除片段移除后不再使用的导入
+func removeUnusedImports(path string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]struct{})
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok && x.Obj == nil {
+				used[x.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+	for _, spec := range slices.Clone(f.Imports) {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := importPathToAssumedName(importPath)
+		alias := ""
+		if spec.Name != nil {
+			alias = spec.Name.Name
+			name = alias
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		if _, ok := used[name]; !ok {
+			astutil.DeleteNamedImport(fset, f, alias, importPath)
+		}
+	}
+	buf := bytes.Buffer{}
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// importPathToAssumedName 按goimports的规则由导入路径推断包名
+func importPathToAssumedName(importPath string) string {
+	base := importPath[strings.LastIndex(importPath, "/")+1:]
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil {
+			dir := importPath[:max(strings.LastIndex(importPath, "/"), 0)]
+			base = dir[strings.LastIndex(dir, "/")+1:]
+		}
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_' || r >= 0x80)
+	}); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
@@ -0,0 +1,61 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadManifestRejectsOutsidePaths(t *testing.T) {
+	tests := []string{"../outside", "register/../../outside", "/etc/passwd", ".", ""}
+	for _, file := range tests {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			ManifestFile: "components:\n  - name: cmd\n    files:\n      - \"" + file + "\"\n",
+		})
+		if _, err := LoadManifest(dir); err == nil {
+			t.Errorf("LoadManifest() with file %q succeeded, want error", file)
+		}
+	}
+}
+
+func TestApplyComponentsSnapshot(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "app")
+	if err := extractSnapshot(dest); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadManifest(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 与 gbc new 的示例 --with cron --without cmd 一致
+	selected, err := m.Select([]string{"cron"}, []string{"cmd"}, func(c Component) bool {
+		t.Errorf("unexpected ask for component %s", c.Name)
+		return c.Default
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyComponents(dest, m, selected); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]bool{
+		"register/cmd.go":  false,
+		"register/cron.go": true,
+		ManifestFile:       false,
+	} {
+		_, err := os.Stat(filepath.Join(dest, name))
+		if got := err == nil; got != want {
+			t.Errorf("%s exists = %v, want %v", name, got, want)
+		}
+	}
+	main := readFile(t, filepath.Join(dest, "main.go"))
+	if strings.Contains(main, snippetBegin) || strings.Contains(main, snippetEnd) {
+		t.Errorf("snippet markers left in main.go:\n%s", main)
+	}
+
+	if _, err := m.Select([]string{"redis"}, nil, nil); err == nil {
+		t.Error("Select() with unknown component succeeded, want error")
+	}
+}
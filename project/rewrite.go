@@ -0,0 +1,220 @@
+package project
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/tools/go/ast/astutil"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// 模板中可使用的占位符, 与gbc代码模板的 {$Name} 风格保持一致
+const (
+	PlaceholderAppName    = "{$AppName}"
+	PlaceholderModulePath = "{$ModulePath}"
+)
+
+// configExts 需要替换应用名称的配置文件后缀
+var configExts = []string{".yaml", ".yml", ".toml", ".json", ".ini", ".env"}
+
+// Rewrite 将模板项目改写为指定的应用名称与模块路径
+// modulePath为空时保留模板原有的模块路径
+func Rewrite(dir, appName, modulePath string) error {
+	oldModule, err := comm.ModulePath(dir)
+	if err != nil {
+		return err
+	}
+	if modulePath == "" {
+		modulePath = oldModule
+	}
+	if err := ValidateModulePath(modulePath); err != nil {
+		return err
+	}
+
+	if err := replacePlaceholders(dir, map[string]string{
+		PlaceholderAppName:    appName,
+		PlaceholderModulePath: modulePath,
+	}); err != nil {
+		return err
+	}
+	if modulePath != oldModule {
+		comm.Log.Debugf("改写模块路径[%s]为[%s]", oldModule, modulePath)
+		if err := rewriteGoMod(dir, modulePath); err != nil {
+			return err
+		}
+		if err := rewriteImports(dir, oldModule, modulePath); err != nil {
+			return err
+		}
+	}
+	// 模板的应用名称与模块路径最后一段一致
+	oldAppName := oldModule[strings.LastIndex(oldModule, "/")+1:]
+	return rewriteConfigAppName(dir, oldAppName, appName)
+}
+
+// ValidateModulePath 校验模块路径是否合法
+func ValidateModulePath(modulePath string) error {
+	if err := module.CheckImportPath(modulePath); err != nil {
+		return i18n.Errorf("模块路径[%s]不合法: %w", modulePath, err)
+	}
+	return nil
+}
+
+// ValidateAppName 校验应用名称: 作为项目目录名使用, 须为单个合法的模块路径元素
+func ValidateAppName(appName string) error {
+	if appName == "." || appName == ".." || strings.ContainsAny(appName, `/\`) {
+		return i18n.Errorf("应用名称[%s]不能是路径, 请仅指定项目目录的名称", appName)
+	}
+	if strings.HasPrefix(appName, ".") {
+		return i18n.Errorf("应用名称[%s]不能以.开头", appName)
+	}
+	if err := module.CheckImportPath(appName); err != nil {
+		return i18n.Errorf("应用名称[%s]不合法: %w", appName, err)
+	}
+	return nil
+}
+
+// walkFiles 遍历项目中的普通文件, 跳过.git目录
+func walkFiles(dir string, fn func(path string, d fs.DirEntry) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return fn(path, d)
+	})
+}
+
+// replacePlaceholders 替换文件内容与文件名中的占位符
+func replacePlaceholders(dir string, values map[string]string) error {
+	replacer := make([]string, 0, len(values)*2)
+	for k, v := range values {
+		replacer = append(replacer, k, v)
+	}
+	r := strings.NewReplacer(replacer...)
+	renames := make(map[string]string)
+	err := walkFiles(dir, func(path string, d fs.DirEntry) error {
+		if name := r.Replace(d.Name()); name != d.Name() {
+			renames[path] = filepath.Join(filepath.Dir(path), name)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// 跳过二进制文件
+		if bytes.IndexByte(data, 0) >= 0 {
+			return nil
+		}
+		replaced := r.Replace(string(data))
+		if replaced == string(data) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(replaced), info.Mode().Perm())
+	})
+	if err != nil {
+		return err
+	}
+	for oldPath, newPath := range renames {
+		if err := os.Rename(oldPath, newPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rewriteGoMod(dir, modulePath string) error {
+	f, err := comm.ReadGoMod(dir)
+	if err != nil {
+		return err
+	}
+	if err := f.AddModuleStmt(modulePath); err != nil {
+		return err
+	}
+	data, err := f.Format()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "go.mod"), data, 0644)
+}
+
+// rewriteImports 基于语法树改写所有Go文件中对旧模块及其子包的导入
+func rewriteImports(dir, oldModule, newModule string) error {
+	return walkFiles(dir, func(path string, d fs.DirEntry) error {
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return i18n.Errorf("解析文件[%s]失败: %w", path, err)
+		}
+		olds := make([]string, 0)
+		for _, spec := range f.Imports {
+			p, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if p == oldModule || strings.HasPrefix(p, oldModule+"/") {
+				olds = append(olds, p)
+			}
+		}
+		if len(olds) == 0 {
+			return nil
+		}
+		slices.Sort(olds)
+		for _, p := range slices.Compact(olds) {
+			astutil.RewriteImport(fset, f, p, newModule+strings.TrimPrefix(p, oldModule))
+		}
+		buf := bytes.Buffer{}
+		if err := format.Node(&buf, fset, f); err != nil {
+			return i18n.Errorf("格式化文件[%s]失败: %w", path, err)
+		}
+		return os.WriteFile(path, buf.Bytes(), 0644)
+	})
+}
+
+// rewriteConfigAppName 将配置文件中取值为模板应用名称的 *name* 配置项改为新的应用名称
+func rewriteConfigAppName(dir, oldName, newName string) error {
+	if oldName == newName {
+		return nil
+	}
+	re := regexp.MustCompile(`(?mi)^(\s*"?[\w.-]*name"?\s*[:=]\s*["']?)` + regexp.QuoteMeta(oldName) + `(["']?\s*,?\s*)$`)
+	return walkFiles(dir, func(path string, d fs.DirEntry) error {
+		if !slices.Contains(configExts, strings.ToLower(filepath.Ext(path))) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		replaced := re.ReplaceAll(data, []byte("${1}"+strings.ReplaceAll(newName, "$", "$$")+"${2}"))
+		if bytes.Equal(replaced, data) {
+			return nil
+		}
+		comm.Log.Debugf("改写配置文件[%s]中的应用名称", path)
+		return os.WriteFile(path, replaced, 0644)
+	})
+}
@@ -0,0 +1,131 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module github.com/zjutjh/gbc-template\n\ngo 1.24\n\nrequire github.com/gin-gonic/gin v1.10.0\n",
+		"main.go": `package main
+
+import (
+	"fmt"
+
+	"github.com/zjutjh/gbc-template"
+	"github.com/zjutjh/gbc-template-extra/x"
+	"github.com/zjutjh/gbc-template/register"
+	r "github.com/zjutjh/gbc-template/router"
+)
+
+// 注释中的 github.com/zjutjh/gbc-template 不受影响
+func main() {
+	fmt.Println(template.Name, x.Name, register.Name, r.Name)
+}
+`,
+		"config/config.yaml":        "app:\n  name: gbc-template\n  desc: gbc-template demo\nlog:\n  file_name: \"gbc-template\"\n",
+		"config/app.json":           "{\n  \"appName\": \"gbc-template\",\n  \"home\": \"gbc-template\"\n}\n",
+		"README.md":                 "# {$AppName}\n\ngo get {$ModulePath}\n",
+		"deploy/{$AppName}.service": "ExecStart=/usr/bin/{$AppName}\n",
+		"assets/logo.bin":           "{$AppName}\x00",
+	}
+
+	tests := []struct {
+		name       string
+		appName    string
+		modulePath string
+		want       map[string]string
+		wantErr    bool
+	}{
+		{
+			name:       "module path",
+			appName:    "svc",
+			modulePath: "github.com/org/svc",
+			want: map[string]string{
+				"go.mod": "module github.com/org/svc\n\ngo 1.24\n\nrequire github.com/gin-gonic/gin v1.10.0\n",
+				"main.go": `package main
+
+import (
+	"fmt"
+
+	"github.com/org/svc"
+	"github.com/org/svc/register"
+	r "github.com/org/svc/router"
+	"github.com/zjutjh/gbc-template-extra/x"
+)
+
+// 注释中的 github.com/zjutjh/gbc-template 不受影响
+func main() {
+	fmt.Println(template.Name, x.Name, register.Name, r.Name)
+}
+`,
+				"config/config.yaml": "app:\n  name: svc\n  desc: gbc-template demo\nlog:\n  file_name: \"svc\"\n",
+				"config/app.json":    "{\n  \"appName\": \"svc\",\n  \"home\": \"gbc-template\"\n}\n",
+				"README.md":          "# svc\n\ngo get github.com/org/svc\n",
+				"deploy/svc.service": "ExecStart=/usr/bin/svc\n",
+				"assets/logo.bin":    "{$AppName}\x00",
+			},
+		},
+		{
+			name:    "keep module path",
+			appName: "demo",
+			want: map[string]string{
+				"go.mod":              files["go.mod"],
+				"main.go":             files["main.go"],
+				"config/config.yaml":  "app:\n  name: demo\n  desc: gbc-template demo\nlog:\n  file_name: \"demo\"\n",
+				"README.md":           "# demo\n\ngo get github.com/zjutjh/gbc-template\n",
+				"deploy/demo.service": "ExecStart=/usr/bin/demo\n",
+			},
+		},
+		{
+			name:       "invalid module path",
+			appName:    "svc",
+			modulePath: "github.com/org/svc/",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, files)
+			err := Rewrite(dir, tt.appName, tt.modulePath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Rewrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for name, want := range tt.want {
+				if got := readFile(t, filepath.Join(dir, name)); got != want {
+					t.Errorf("%s =\n%s\nwant\n%s", name, got, want)
+				}
+			}
+			if _, err := os.Stat(filepath.Join(dir, "deploy", "{$AppName}.service")); err == nil && !tt.wantErr {
+				t.Errorf("file name placeholder not replaced")
+			}
+		})
+	}
+}
+
+func TestValidateAppName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"app", false},
+		{"user-center", false},
+		{"svc_v2", false},
+		{"", true},
+		{".", true},
+		{"..", true},
+		{"../x", true},
+		{"a/b", true},
+		{`a\b`, true},
+		{".hidden", true},
+		{"my app", true},
+	}
+	for _, tt := range tests {
+		if err := ValidateAppName(tt.name); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateAppName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
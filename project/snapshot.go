@@ -0,0 +1,69 @@
+package project
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// snapshot 内置的gbc-template快照, 在无法访问gbc-template时用于创建项目
+// 快照由 snapshot_gen.go 固定到gbc-template的某个tag生成, 其中的go.mod与go.sum固定了依赖版本;
+// 快照不包含模块缓存, 依赖的模块仍需从GOPROXY或本地模块缓存获取
+// 由于go:embed无法嵌入包含go.mod的目录, 快照中以 .tmpl 结尾的文件在释放时去除该后缀
+//
+// 更新快照: go run snapshot_gen.go -ref <gbc-template的tag>
+//
+//go:embed all:_snapshot
+var snapshot embed.FS
+
+const snapshotRoot = "_snapshot"
+
+func extractSnapshot(dest string) error {
+	return fs.WalkDir(snapshot, snapshotRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(path, snapshotRoot), "/")
+		target := filepath.Join(dest, filepath.FromSlash(strings.TrimSuffix(rel, ".tmpl")))
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		f, err := snapshot.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return writeFile(target, f, 0644)
+	})
+}
+
+// snapshotPinsDeps 快照中是否包含固定依赖版本的go.sum
+func snapshotPinsDeps() bool {
+	_, err := fs.Stat(snapshot, snapshotRoot+"/go.sum")
+	return err == nil
+}
+
+// snapshotRevision 内置快照的版本, 用于在模板锁文件中标识快照: 生成自gbc-template时为对应的tag与commit, 否则为内容摘要
+func snapshotRevision() string {
+	if snapshotCommit != "" {
+		return SnapshotSource + ":" + snapshotRef + "@" + snapshotCommit
+	}
+	h := sha256.New()
+	_ = fs.WalkDir(snapshot, snapshotRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := snapshot.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		h.Write([]byte(path))
+		h.Write(data)
+		return nil
+	})
+	return SnapshotSource + ":" + hex.EncodeToString(h.Sum(nil))[:12]
+}
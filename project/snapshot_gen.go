@@ -0,0 +1,123 @@
+//go:build ignore
+
+// snapshot_gen 将指定版本的gbc-template写入 _snapshot 目录, 并在 snapshot_version.go 中记录版本
+//
+//	go run snapshot_gen.go -ref v1.2.0
+//
+// 模板中的 go.mod 写入为 go.mod.tmpl; 模板没有 gbc.template.yaml 时保留快照中现有的组件清单
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/zjutjh/gbc/config"
+)
+
+const (
+	snapshotDir  = "_snapshot"
+	versionFile  = "snapshot_version.go"
+	manifestFile = "gbc.template.yaml"
+)
+
+func main() {
+	repo := flag.String("repo", config.GBCGitTemplateHTTPS, "gbc-template仓库地址")
+	ref := flag.String("ref", "", "gbc-template的tag, 快照固定到该版本")
+	flag.Parse()
+	if *ref == "" {
+		log.Fatal("必须通过 -ref 指定gbc-template的tag")
+	}
+
+	tmp, err := os.MkdirTemp("", "gbc-snapshot-*")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "template")
+	run("", "git", "clone", "--quiet", *repo, src)
+	run(src, "git", "-c", "advice.detachedHead=false", "checkout", "--quiet", *ref)
+	commit := strings.TrimSpace(run(src, "git", "rev-parse", "HEAD"))
+
+	if _, err := os.Stat(filepath.Join(src, "go.sum")); err != nil {
+		log.Fatalf("gbc-template@%s 中没有go.sum, 快照无法固定依赖版本", *ref)
+	}
+	manifest, err := os.ReadFile(filepath.Join(snapshotDir, manifestFile))
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(src, manifestFile)); err == nil {
+		manifest = nil
+	} else if manifest != nil {
+		log.Printf("gbc-template@%s 中没有%s, 保留快照中现有的组件清单", *ref, manifestFile)
+	}
+
+	if err := os.RemoveAll(snapshotDir); err != nil {
+		log.Fatal(err)
+	}
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(filepath.Join(snapshotDir, rel), 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		// go:embed 无法嵌入包含go.mod的目录
+		if d.Name() == "go.mod" {
+			rel += ".tmpl"
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(snapshotDir, rel), data, 0644)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if manifest != nil {
+		if err := os.WriteFile(filepath.Join(snapshotDir, manifestFile), manifest, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	buf := bytes.Buffer{}
+	fmt.Fprintf(&buf, "// Code generated by snapshot_gen.go. DO NOT EDIT.\n\npackage project\n\n")
+	fmt.Fprintf(&buf, "// 内置快照对应的gbc-template版本与commit\nconst (\n\tsnapshotRef = %q\n\tsnapshotCommit = %q\n)\n", *ref, commit)
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(versionFile, formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("快照已更新为 gbc-template@%s (%s)", *ref, commit)
+}
+
+func run(dir, name string, args ...string) string {
+	c := exec.Command(name, args...)
+	c.Dir = dir
+	c.Stderr = os.Stderr
+	out, err := c.Output()
+	if err != nil {
+		log.Fatalf("%s %s: %v", name, strings.Join(args, " "), err)
+	}
+	return string(out)
+}
@@ -0,0 +1,8 @@
+package project
+
+// 内置快照对应的gbc-template版本与commit, 由 snapshot_gen.go 生成
+// 为空表示快照尚未从gbc-template生成, 仅包含创建项目所需的最小骨架
+const (
+	snapshotRef    = ""
+	snapshotCommit = ""
+)
@@ -0,0 +1,265 @@
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// SourceKind 项目模板来源类型
+type SourceKind string
+
+const (
+	SourceGit      SourceKind = "git"      // git仓库 (HTTPS/SSH/本地裸仓库)
+	SourceDir      SourceKind = "dir"      // 本地目录
+	SourceArchive  SourceKind = "archive"  // .tar.gz/.tgz/.zip 归档 (本地或HTTP)
+	SourceSnapshot SourceKind = "snapshot" // 内置的模板快照
+)
+
+// SnapshotSource 使用内置模板快照的特殊来源名称
+const SnapshotSource = "embed"
+
+// Source 项目模板来源
+type Source struct {
+	Kind     SourceKind
+	Location string
+	Ref      string // git tag/branch/commit
+	Revision string // Fetch实际获取到的版本: git commit或内置快照摘要
+}
+
+// ParseSource 根据模板地址推断来源类型
+func ParseSource(location, ref string) (*Source, error) {
+	src := &Source{Location: location, Ref: ref}
+	switch {
+	case location == SnapshotSource:
+		src.Kind = SourceSnapshot
+	case isArchive(location):
+		src.Kind = SourceArchive
+	case isGitURL(location):
+		src.Kind = SourceGit
+	default:
+		fi, err := os.Stat(location)
+		if err != nil {
+			return nil, i18n.Errorf("无法识别的模板来源[%s]: %w", location, err)
+		}
+		if !fi.IsDir() {
+			return nil, i18n.Errorf("无法识别的模板来源[%s]: 不是目录或归档文件", location)
+		}
+		// 裸仓库以及指定了ref的本地仓库按git来源处理
+		if isBareRepo(location) || (ref != "" && isWorkTree(location)) {
+			src.Kind = SourceGit
+		} else {
+			src.Kind = SourceDir
+		}
+	}
+	if src.Ref != "" && src.Kind != SourceGit {
+		return nil, i18n.Errorf("模板来源[%s]不是git仓库, 不支持指定ref", location)
+	}
+	return src, nil
+}
+
+func (s *Source) String() string {
+	if s.Ref != "" {
+		return fmt.Sprintf("%s@%s", s.Location, s.Ref)
+	}
+	return s.Location
+}
+
+// Fetch 将模板内容放置到dest目录 (dest须不存在), 不包含.git目录
+func (s *Source) Fetch(dest string) error {
+	switch s.Kind {
+	case SourceGit:
+		revision, err := fetchGit(s.Location, s.Ref, dest)
+		s.Revision = revision
+		return err
+	case SourceDir:
+		return copyDir(s.Location, dest)
+	case SourceArchive:
+		return fetchArchive(s.Location, dest)
+	case SourceSnapshot:
+		s.Revision = snapshotRevision()
+		return extractSnapshot(dest)
+	}
+	return i18n.Errorf("不支持的模板来源类型: %s", s.Kind)
+}
+
+// IsEmptyDir 目录不存在或为空目录
+func IsEmptyDir(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return true
+	}
+	return err == nil && len(entries) == 0
+}
+
+func isArchive(location string) bool {
+	l := strings.ToLower(location)
+	return strings.HasSuffix(l, ".tar.gz") || strings.HasSuffix(l, ".tgz") || strings.HasSuffix(l, ".zip")
+}
+
+func isGitURL(location string) bool {
+	for _, prefix := range []string{"git@", "ssh://", "git://", "https://", "http://", "file://"} {
+		if strings.HasPrefix(location, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWorkTree(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+func isBareRepo(dir string) bool {
+	for _, name := range []string{"HEAD", "objects", "refs"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func runGit(dir string, args ...string) error {
+	return runCommand(dir, "git", args...)
+}
+
+// runCommand 执行外部命令, 非调试模式下失败时将命令输出附加到错误中
+func runCommand(dir, name string, args ...string) error {
+	c := exec.Command(name, args...)
+	c.Dir = dir
+	if comm.DebugMode {
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+		return c.Run()
+	}
+	out, err := c.CombinedOutput()
+	if err != nil && len(bytes.TrimSpace(out)) > 0 {
+		return fmt.Errorf("%w\n%s", err, bytes.TrimSpace(out))
+	}
+	return err
+}
+
+// fetchGit 拉取git模板并返回对应的commit
+func fetchGit(url, ref, dest string) (string, error) {
+	comm.Log.Debugf("拉取模板仓库[%s]", url)
+	if err := runGit("", "clone", "--quiet", url, dest); err != nil {
+		return "", i18n.Errorf("拉取模板仓库[%s]错误: %w", url, err)
+	}
+	if ref != "" {
+		comm.Log.Debugf("切换模板版本[%s]", ref)
+		if err := runGit(dest, "-c", "advice.detachedHead=false", "checkout", "--quiet", ref); err != nil {
+			return "", i18n.Errorf("切换模板版本[%s]错误: %w", ref, err)
+		}
+	}
+	c := exec.Command("git", "rev-parse", "HEAD")
+	c.Dir = dest
+	out, err := c.Output()
+	if err != nil {
+		return "", i18n.Errorf("获取模板版本错误: %w", err)
+	}
+	return strings.TrimSpace(string(out)), os.RemoveAll(filepath.Join(dest, ".git"))
+}
+
+func fetchArchive(location, dest string) error {
+	path := location
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		tmp, err := os.CreateTemp("", "gbc-template-*"+archiveExt(location))
+		if err != nil {
+			return err
+		}
+		tmp.Close()
+		defer os.Remove(tmp.Name())
+		comm.Log.Debugf("下载模板归档[%s]", location)
+		resp, err := resty.New().R().SetOutput(tmp.Name()).Get(location)
+		if err != nil {
+			return i18n.Errorf("下载模板归档[%s]错误: %w", location, err)
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return i18n.Errorf("下载模板归档[%s]错误: Status Code[%d|%s]", location, resp.StatusCode(), resp.Status())
+		}
+		path = tmp.Name()
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if archiveExt(path) == ".zip" {
+		fi, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		return extractZip(f, fi.Size(), dest)
+	}
+	return extractTarGz(f, dest)
+}
+
+func archiveExt(location string) string {
+	if strings.HasSuffix(strings.ToLower(location), ".zip") {
+		return ".zip"
+	}
+	return ".tar.gz"
+}
+
+// copyDir 复制目录, 跳过.git
+func copyDir(src, dest string) error {
+	return filepath.Walk(src, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() && fi.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		target := filepath.Join(dest, rel)
+		switch {
+		case fi.IsDir():
+			return os.MkdirAll(target, 0755)
+		case fi.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case fi.Mode().IsRegular():
+			return copyFile(path, target, fi.Mode().Perm())
+		}
+		return nil
+	})
+}
+
+func copyFile(src, dest string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	return writeFile(dest, in, perm)
+}
+
+func writeFile(dest string, r io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, r)
+	return errors.Join(err, out.Close())
+}
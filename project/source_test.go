@@ -0,0 +1,288 @@
+package project
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// git 在dir中执行git命令并返回输出
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	c := exec.Command("git", append([]string{"-c", "user.name=gbc", "-c", "user.email=gbc@example.com", "-c", "init.defaultBranch=main"}, args...)...)
+	c.Dir = dir
+	out, err := c.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func assertNoGitDir(t *testing.T, dir string) {
+	t.Helper()
+	if _, err := os.Stat(filepath.Join(dir, ".git")); !os.IsNotExist(err) {
+		t.Errorf(".git should not be copied, stat error: %v", err)
+	}
+}
+
+func TestParseSource(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	writeFiles(t, dir, map[string]string{"file.txt": "x"})
+
+	tests := []struct {
+		location string
+		ref      string
+		want     SourceKind
+		wantErr  bool
+	}{
+		{location: SnapshotSource, want: SourceSnapshot},
+		{location: "https://example.com/t.tar.gz", want: SourceArchive},
+		{location: "t.TGZ", want: SourceArchive},
+		{location: "t.zip", want: SourceArchive},
+		{location: "git@github.com:org/t.git", ref: "v1", want: SourceGit},
+		{location: "https://github.com/org/t.git", want: SourceGit},
+		{location: dir, want: SourceDir},
+		{location: dir, ref: "v1", wantErr: true},
+		{location: "t.zip", ref: "v1", wantErr: true},
+		{location: SnapshotSource, ref: "v1", wantErr: true},
+		{location: file, wantErr: true},
+		{location: filepath.Join(dir, "missing"), wantErr: true},
+	}
+	for _, tt := range tests {
+		src, err := ParseSource(tt.location, tt.ref)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseSource(%q, %q) = %s, want error", tt.location, tt.ref, src.Kind)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSource(%q, %q) error: %v", tt.location, tt.ref, err)
+			continue
+		}
+		if src.Kind != tt.want {
+			t.Errorf("ParseSource(%q, %q) = %s, want %s", tt.location, tt.ref, src.Kind, tt.want)
+		}
+	}
+}
+
+func TestFetchGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	work := t.TempDir()
+	git(t, work, "init", "--quiet")
+	writeFiles(t, work, map[string]string{"main.go": "v1"})
+	git(t, work, "add", "--all")
+	git(t, work, "commit", "--quiet", "-m", "v1")
+	git(t, work, "tag", "v1.0.0")
+	v1 := git(t, work, "rev-parse", "HEAD")
+	writeFiles(t, work, map[string]string{"main.go": "v2"})
+	git(t, work, "commit", "--quiet", "-am", "v2")
+	v2 := git(t, work, "rev-parse", "HEAD")
+
+	bare := filepath.Join(t.TempDir(), "template.git")
+	git(t, "", "clone", "--quiet", "--bare", work, bare)
+
+	tests := []struct {
+		location string
+		ref      string
+		content  string
+		revision string
+	}{
+		{location: bare, ref: "v1.0.0", content: "v1", revision: v1},
+		{location: bare, content: "v2", revision: v2},
+		// 指定ref的本地工作区同样按git来源处理
+		{location: work, ref: v1, content: "v1", revision: v1},
+	}
+	for _, tt := range tests {
+		src, err := ParseSource(tt.location, tt.ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if src.Kind != SourceGit {
+			t.Fatalf("ParseSource(%q, %q) = %s, want git", tt.location, tt.ref, src.Kind)
+		}
+		dest := filepath.Join(t.TempDir(), "app")
+		if err := src.Fetch(dest); err != nil {
+			t.Fatal(err)
+		}
+		if got := readFile(t, filepath.Join(dest, "main.go")); got != tt.content {
+			t.Errorf("%s: main.go = %q, want %q", src, got, tt.content)
+		}
+		if src.Revision != tt.revision {
+			t.Errorf("%s: Revision = %s, want %s", src, src.Revision, tt.revision)
+		}
+		assertNoGitDir(t, dest)
+	}
+
+	src, err := ParseSource(bare, "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Fetch(filepath.Join(t.TempDir(), "app")); err == nil {
+		t.Error("Fetch with unknown ref succeeded, want error")
+	}
+}
+
+func TestFetchDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main.go":         "package main",
+		"config/a.yaml":   "a: 1",
+		".git/HEAD":       "ref: refs/heads/main",
+		".gitignore":      "/bin",
+		"register/cmd.go": "package register",
+	})
+	src, err := ParseSource(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(t.TempDir(), "app")
+	if err := src.Fetch(dest); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"main.go", "config/a.yaml", ".gitignore", "register/cmd.go"} {
+		if _, err := os.Stat(filepath.Join(dest, name)); err != nil {
+			t.Errorf("%s not copied: %v", name, err)
+		}
+	}
+	assertNoGitDir(t, dest)
+}
+
+var archiveFiles = []struct {
+	name    string
+	content string
+}{
+	{"main.go", "package main"},
+	{"config/config.yaml", "a: 1"},
+	{".git/HEAD", "ref: refs/heads/main"},
+}
+
+func buildTarGz(t *testing.T, root string) []byte {
+	t.Helper()
+	buf := bytes.Buffer{}
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range archiveFiles {
+		hdr := &tar.Header{Name: root + f.name, Mode: 0644, Size: int64(len(f.content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(f.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func buildZip(t *testing.T, root string) []byte {
+	t.Helper()
+	buf := bytes.Buffer{}
+	zw := zip.NewWriter(&buf)
+	for _, f := range archiveFiles {
+		w, err := zw.Create(root + f.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(f.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestFetchArchive(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(t *testing.T, root string) []byte
+		root  string
+	}{
+		{name: "t.tar.gz", build: buildTarGz},
+		// GitHub生成的归档位于同一顶层目录下
+		{name: "t.tgz", build: buildTarGz, root: "gbc-template-main/"},
+		{name: "t.zip", build: buildZip},
+		{name: "t.zip", build: buildZip, root: "gbc-template-main/"},
+	}
+	for _, tt := range tests {
+		location := filepath.Join(t.TempDir(), tt.name)
+		if err := os.WriteFile(location, tt.build(t, tt.root), 0644); err != nil {
+			t.Fatal(err)
+		}
+		src, err := ParseSource(location, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		dest := filepath.Join(t.TempDir(), "app")
+		if err := src.Fetch(dest); err != nil {
+			t.Fatalf("%s(%q): %v", tt.name, tt.root, err)
+		}
+		for _, f := range archiveFiles[:2] {
+			if got := readFile(t, filepath.Join(dest, f.name)); got != f.content {
+				t.Errorf("%s(%q): %s = %q, want %q", tt.name, tt.root, f.name, got, f.content)
+			}
+		}
+		assertNoGitDir(t, dest)
+	}
+}
+
+func TestFetchSnapshot(t *testing.T) {
+	src, err := ParseSource(SnapshotSource, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(t.TempDir(), "app")
+	if err := src.Fetch(dest); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(src.Revision, SnapshotSource+":") {
+		t.Errorf("Revision = %q, want prefix %q", src.Revision, SnapshotSource+":")
+	}
+	if got := readFile(t, filepath.Join(dest, "go.mod")); !strings.HasPrefix(got, "module app") {
+		t.Errorf("go.mod = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "go.mod.tmpl")); !os.IsNotExist(err) {
+		t.Errorf("go.mod.tmpl should be renamed, stat error: %v", err)
+	}
+	if m, err := LoadManifest(dest); err != nil || m == nil {
+		t.Errorf("LoadManifest() = %v, %v", m, err)
+	}
+}
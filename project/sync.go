@@ -0,0 +1,272 @@
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// SyncAction 同步模板时对单个文件的处理方式, 取值为稳定的标识符, 展示时使用 Label
+type SyncAction string
+
+const (
+	SyncUpdate   SyncAction = "update"   // 项目未修改, 直接使用新模板
+	SyncAdd      SyncAction = "add"      // 新模板中新增的文件
+	SyncDelete   SyncAction = "delete"   // 新模板中已删除且项目未修改
+	SyncMerge    SyncAction = "merge"    // 三方合并无冲突
+	SyncConflict SyncAction = "conflict" // 三方合并存在冲突, 文件中写入冲突标记
+	SyncReject   SyncAction = "reject"   // 无法合并, 新模板内容写入 .rej 文件
+	SyncKeep     SyncAction = "keep"     // 新模板中已删除但项目中有修改
+)
+
+var syncActionLabels = map[SyncAction]string{
+	SyncUpdate:   "更新",
+	SyncAdd:      "新增",
+	SyncDelete:   "删除",
+	SyncMerge:    "合并",
+	SyncConflict: "冲突",
+	SyncReject:   "拒绝",
+	SyncKeep:     "保留",
+}
+
+// Label 当前语言下的处理方式名称
+func (a SyncAction) Label() string {
+	if label, ok := syncActionLabels[a]; ok {
+		return i18n.T(label)
+	}
+	return string(a)
+}
+
+// SyncOptions 同步模板的参数
+type SyncOptions struct {
+	Dir      string
+	Location string // 为空时使用锁文件中记录的模板来源
+	Ref      string
+	DryRun   bool
+}
+
+// SyncChange 同步模板时单个文件的变更
+type SyncChange struct {
+	Path   string
+	Action SyncAction
+}
+
+// Sync 将新版本模板的改动三方合并到项目中:
+// 基准为锁文件记录的模板版本, 当前版本为项目中的文件, 目标为新版本模板, 三者均按项目的组件选择、应用名称与模块路径渲染
+func Sync(opts *SyncOptions) ([]SyncChange, error) {
+	lock, err := ReadLock(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	location := opts.Location
+	if location == "" {
+		location = lock.Template.Location
+	}
+
+	tmp, err := os.MkdirTemp("", "gbc-sync-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	// 按锁文件中的组件选择渲染, 新模板中新增的组件使用默认值
+	selectFn := func(m *Manifest) (map[string]bool, error) {
+		with, without := make([]string, 0), make([]string, 0)
+		for _, name := range m.Names() {
+			enabled, ok := lock.Components[name]
+			switch {
+			case !ok:
+			case enabled:
+				with = append(with, name)
+			default:
+				without = append(without, name)
+			}
+		}
+		return m.Select(with, without, func(c Component) bool {
+			return c.Default
+		})
+	}
+
+	baseDir := filepath.Join(tmp, "base")
+	comm.Log.Infof("获取基准模板[%s@%s]", lock.Template.Location, lock.Template.Revision)
+	if _, err := render([]string{lock.Template.Location}, baseRef(lock), baseDir, lock.AppName, lock.ModulePath, selectFn); err != nil {
+		comm.Log.Warnf("获取基准模板失败, 将按两方比较处理: %s", err.Error())
+		baseDir = ""
+	}
+	newDir := filepath.Join(tmp, "new")
+	comm.Log.Infof("获取新模板[%s]", location)
+	newLock, err := render([]string{location}, opts.Ref, newDir, lock.AppName, lock.ModulePath, selectFn)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := slices.Sorted(maps.Keys(newLock.Files))
+	for path := range lock.Files {
+		if _, ok := newLock.Files[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	changes := make([]SyncChange, 0)
+	for _, path := range paths {
+		action, err := syncFile(opts, lock, baseDir, newDir, path)
+		if err != nil {
+			return changes, i18n.Errorf("同步文件[%s]失败: %w", path, err)
+		}
+		if action != "" {
+			changes = append(changes, SyncChange{Path: path, Action: action})
+		}
+		if action == SyncConflict || action == SyncReject {
+			// 冲突解决前保留原有的摘要, 下次同步时仍按项目中的修改进行合并
+			if hash, ok := lock.Files[path]; ok {
+				newLock.Files[path] = hash
+			} else {
+				delete(newLock.Files, path)
+			}
+		}
+	}
+	if opts.DryRun {
+		return changes, nil
+	}
+	return changes, WriteLock(opts.Dir, newLock)
+}
+
+// baseRef 基准模板的版本, git来源使用记录的commit
+func baseRef(lock *Lock) string {
+	src, err := ParseSource(lock.Template.Location, "")
+	if err == nil && src.Kind == SourceGit && lock.Template.Revision != "" {
+		return lock.Template.Revision
+	}
+	return lock.Template.Ref
+}
+
+func syncFile(opts *SyncOptions, lock *Lock, baseDir, newDir, path string) (SyncAction, error) {
+	target := filepath.Join(opts.Dir, filepath.FromSlash(path))
+	ours, oursErr := readOptional(target)
+	theirs, theirsErr := readOptional(filepath.Join(newDir, filepath.FromSlash(path)))
+	if err := errors.Join(oursErr, theirsErr); err != nil {
+		return "", err
+	}
+	lockHash, inLock := lock.Files[path]
+	base, err := readBase(baseDir, path, lockHash)
+	if err != nil {
+		return "", err
+	}
+	oursChanged := ours == nil || hashBytes(ours) != lockHash
+
+	write := func(data []byte) error {
+		if opts.DryRun {
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	}
+
+	switch {
+	case theirs == nil:
+		// 新模板中已删除
+		if ours == nil {
+			return "", nil
+		}
+		if oursChanged {
+			return SyncKeep, nil
+		}
+		if opts.DryRun {
+			return SyncDelete, nil
+		}
+		return SyncDelete, os.Remove(target)
+	case bytes.Equal(ours, theirs):
+		return "", nil
+	case ours == nil && !inLock:
+		return SyncAdd, write(theirs)
+	case inLock && !oursChanged:
+		return SyncUpdate, write(theirs)
+	case base != nil && bytes.Equal(base, theirs):
+		// 模板未改动, 保留项目中的修改
+		return "", nil
+	case ours == nil || isBinary(ours) || isBinary(theirs):
+		// 项目中已删除或无法按文本合并
+		if opts.DryRun {
+			return SyncReject, nil
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return "", err
+		}
+		return SyncReject, os.WriteFile(target+".rej", theirs, 0644)
+	}
+
+	merged, conflicts, err := mergeFile(ours, base, theirs)
+	if err != nil {
+		return "", err
+	}
+	if conflicts {
+		return SyncConflict, write(merged)
+	}
+	return SyncMerge, write(merged)
+}
+
+// readBase 读取基准模板中的文件, 仅当与锁文件记录的摘要一致时可作为合并基准
+func readBase(baseDir, path, lockHash string) ([]byte, error) {
+	if baseDir == "" || lockHash == "" {
+		return nil, nil
+	}
+	data, err := readOptional(filepath.Join(baseDir, filepath.FromSlash(path)))
+	if err != nil || data == nil {
+		return nil, err
+	}
+	if hashBytes(data) != lockHash {
+		comm.Log.Debugf("基准模板中的文件[%s]与锁文件记录不一致, 不作为合并基准", path)
+		return nil, nil
+	}
+	return data, nil
+}
+
+// mergeFile 使用 git merge-file 进行三方合并, base为nil时以空文件为基准
+func mergeFile(ours, base, theirs []byte) ([]byte, bool, error) {
+	dir, err := os.MkdirTemp("", "gbc-merge-*")
+	if err != nil {
+		return nil, false, err
+	}
+	defer os.RemoveAll(dir)
+	files := make([]string, 0, 3)
+	for i, data := range [][]byte{ours, base, theirs} {
+		name := filepath.Join(dir, fmt.Sprint(i))
+		if err := os.WriteFile(name, data, 0644); err != nil {
+			return nil, false, err
+		}
+		files = append(files, name)
+	}
+	c := exec.Command("git", append([]string{"merge-file", "-p", "-L", i18n.T("当前项目"), "-L", i18n.T("原模板"), "-L", i18n.T("新模板")}, files...)...)
+	out, err := c.Output()
+	// 退出码为冲突数量, 负数(255)表示出错
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+		return out, true, nil
+	}
+	if err != nil {
+		return nil, false, i18n.Errorf("执行git merge-file失败: %w", err)
+	}
+	return out, false, nil
+}
+
+func readOptional(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func isBinary(data []byte) bool {
+	return bytes.IndexByte(data, 0) >= 0
+}
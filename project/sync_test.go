@@ -0,0 +1,87 @@
+package project
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+func TestSyncKeepsLockForConflicts(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	tmpl := t.TempDir()
+	writeFiles(t, tmpl, map[string]string{
+		"go.mod":    "module app\n\ngo 1.24\n",
+		"a.txt":     "template v1\n",
+		"sub/b.txt": "template v1\n",
+		"same.txt":  "template v1\n",
+	})
+	target := filepath.Join(t.TempDir(), "app")
+	if err := Create(&CreateOptions{AppName: "app", Target: target, Locations: []string{tmpl}}); err != nil {
+		t.Fatal(err)
+	}
+	old, err := ReadLock(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 项目与模板均修改了a.txt, 项目删除了sub目录, 模板修改了其中的文件
+	writeFiles(t, target, map[string]string{"a.txt": "project\n"})
+	if err := os.RemoveAll(filepath.Join(target, "sub")); err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, tmpl, map[string]string{
+		"a.txt":     "template v2\n",
+		"sub/b.txt": "template v2\n",
+		"same.txt":  "template v2\n",
+	})
+
+	changes, err := Sync(&SyncOptions{Dir: target})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]SyncAction)
+	for _, c := range changes {
+		got[c.Path] = c.Action
+	}
+	want := map[string]SyncAction{"a.txt": SyncConflict, "sub/b.txt": SyncReject, "same.txt": SyncUpdate}
+	for path, action := range want {
+		if got[path] != action {
+			t.Errorf("%s: action = %q, want %q", path, got[path], action)
+		}
+	}
+	if rej := readFile(t, filepath.Join(target, "sub/b.txt.rej")); rej != "template v2\n" {
+		t.Errorf("sub/b.txt.rej = %q", rej)
+	}
+
+	lock, err := ReadLock(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"a.txt", "sub/b.txt"} {
+		if lock.Files[path] != old.Files[path] {
+			t.Errorf("%s: lock hash changed before conflict is resolved", path)
+		}
+	}
+	if lock.Files["same.txt"] == old.Files["same.txt"] {
+		t.Error("same.txt: lock hash not updated")
+	}
+}
+
+func TestSyncActionLabel(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	i18n.SetLocale(i18n.En)
+	for action, want := range map[SyncAction]string{SyncConflict: "conflict", SyncUpdate: "update", SyncKeep: "keep"} {
+		if got := action.Label(); got != want {
+			t.Errorf("%s.Label() = %q, want %q", action, got, want)
+		}
+	}
+	i18n.SetLocale(i18n.ZhCN)
+	if got := SyncReject.Label(); got != "拒绝" || string(SyncReject) != "reject" {
+		t.Errorf("SyncReject = %q, Label() = %q", string(SyncReject), got)
+	}
+}
@@ -0,0 +1,90 @@
+package refactor
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/zjutjh/gbc/comm"
+)
+
+// Kind 脚手架制品类型, 与 gbc api/cmd/cron 模板一一对应
+type Kind struct {
+	Name       string
+	Prefix     string
+	DefaultPkg string
+	// Idents 由制品名称推导出模板中声明的全部顶层标识符
+	Idents func(name string) []string
+}
+
+var Kinds = map[string]Kind{
+	"api": {
+		Name:       "api",
+		Prefix:     "./api/",
+		DefaultPkg: "api",
+		Idents: func(name string) []string {
+			return []string{name + "Api", name + "ApiRequest", name + "ApiResponse", name + "Handler", "hf" + name}
+		},
+	},
+	"cmd": {
+		Name:       "cmd",
+		Prefix:     "./cmd/",
+		DefaultPkg: "cmd",
+		Idents: func(name string) []string {
+			return []string{name + "Run"}
+		},
+	},
+	"cron": {
+		Name:       "cron",
+		Prefix:     "./cron/",
+		DefaultPkg: "cron",
+		Idents: func(name string) []string {
+			return []string{name + "Job"}
+		},
+	},
+}
+
+// KindNames 支持的制品类型名称
+func KindNames() []string {
+	return []string{"api", "cmd", "cron"}
+}
+
+// Artifact 项目中一个已存在的脚手架制品
+type Artifact struct {
+	Kind        Kind
+	Key         *comm.Key
+	PackageName string // 实际包名 (优先使用目录中已有文件声明的包名)
+	ImportPath  string // 所在包的导入路径
+}
+
+// Idents 制品声明的顶层标识符
+func (a *Artifact) Idents() []string {
+	return a.Kind.Idents(a.Key.Ident)
+}
+
+// Receiver 模板生成的方法接收者名称
+func (a *Artifact) Receiver() string {
+	return comm.ReceiverName(a.Key.Ident)
+}
+
+// ResolveArtifact 解析key对应的制品
+func ResolveArtifact(kind Kind, modulePath, key string) (*Artifact, error) {
+	k, err := comm.NormalizeKey(key, kind.DefaultPkg, kind.Prefix, ".go")
+	if err != nil {
+		return nil, err
+	}
+	packageName := k.PackageName
+	if existing := comm.ExistingPackageName(k.Dir); existing != "" {
+		packageName = existing
+	}
+	return &Artifact{
+		Kind:        kind,
+		Key:         k,
+		PackageName: packageName,
+		ImportPath:  path.Join(modulePath, filepath.ToSlash(k.Dir)),
+	}, nil
+}
+
+func (a *Artifact) String() string {
+	return fmt.Sprintf("%s[%s]", a.Kind.Name, a.Key.Raw)
+}
@@ -0,0 +1,246 @@
+package refactor
+
+import (
+	"go/ast"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// MoveArtifact 重命名/移动制品: 重命名其声明的标识符与文件, 并改写其他文件中的引用与导入
+func (p *Project) MoveArtifact(from, to *Artifact) error {
+	oldFile, newFile := filepath.Clean(from.Key.Path), filepath.Clean(to.Key.Path)
+	f, ok := p.Files[oldFile]
+	if !ok {
+		return i18n.Errorf("%s对应的文件[%s]不存在", from, oldFile)
+	}
+	if _, ok := p.Files[newFile]; ok {
+		return i18n.Errorf("%s对应的文件[%s]已存在", to, newFile)
+	}
+	if _, err := os.Stat(filepath.Join(p.Dir, newFile)); err == nil {
+		return i18n.Errorf("%s对应的文件[%s]已存在", to, newFile)
+	}
+	renames := renamePlan(from, to)
+	samePkg := from.ImportPath == to.ImportPath
+	if !samePkg {
+		if refs := p.siblingRefs(oldFile, from.Idents()); len(refs) > 0 {
+			for _, ref := range refs {
+				comm.Log.With("path", p.Position(ref.Pos())).Errorf("%s 引用了 %s", p.Position(ref.Pos()), ref.Name)
+			}
+			return i18n.Errorf("%s与所在包的其他文件之间存在未限定包名的引用, 移动到其他包后将无法编译, 请先手动解除这些引用", from)
+		}
+	}
+
+	// 改写制品文件自身
+	renameIdents(f, renames)
+	renameReceivers(f, from.Receiver(), to.Receiver())
+	renameInComments(f, renames)
+	f.Name.Name = to.PackageName
+	p.Remove(oldFile)
+	p.Create(newFile, f)
+
+	for _, name := range p.SortedFiles() {
+		if name == newFile {
+			continue
+		}
+		f := p.Files[name]
+		dir := filepath.Dir(name)
+		if dir == filepath.Dir(oldFile) {
+			// 跨包移动时同包内已确认不存在引用
+			if samePkg && renameIdents(f, renames) > 0 {
+				p.MarkDirty(name)
+			}
+			continue
+		}
+		local := importName(f, from.ImportPath, from.PackageName)
+		if local == "" || len(collectQualifiedRefs(f, local, from.Idents())) == 0 {
+			continue
+		}
+		switch {
+		case samePkg:
+			renameQualifiedRefs(f, local, local, from.Idents(), renames)
+		case dir == filepath.Dir(newFile):
+			// 目标包内的文件直接引用标识符, 不能再导入自身
+			unqualifyRefs(f, local, from.Idents(), renames)
+			deleteImportIfUnused(p, f, local, from.ImportPath)
+		default:
+			newLocal := importName(f, to.ImportPath, to.PackageName)
+			if newLocal == "" {
+				newLocal = to.PackageName
+				alias := ""
+				if newLocal != path.Base(to.ImportPath) {
+					alias = newLocal
+				}
+				astutil.AddNamedImport(p.Fset, f, alias, to.ImportPath)
+			}
+			renameQualifiedRefs(f, local, newLocal, from.Idents(), renames)
+			deleteImportIfUnused(p, f, local, from.ImportPath)
+		}
+		comm.Log.With("path", name).Infof("改写文件[%s]中对%s的引用", name, from)
+		p.MarkDirty(name)
+	}
+	return nil
+}
+
+// siblingRefs 返回制品文件与同包其他文件之间未限定包名的相互引用:
+// 制品文件引用的同包其他文件中声明的顶层标识符, 以及同包其他文件对制品标识符的引用
+func (p *Project) siblingRefs(file string, idents []string) []*ast.Ident {
+	dir := filepath.Dir(file)
+	declared := make(map[string]struct{})
+	siblings := make([]*ast.File, 0)
+	for _, name := range p.SortedFiles() {
+		if name == file || filepath.Dir(name) != dir {
+			continue
+		}
+		f := p.Files[name]
+		siblings = append(siblings, f)
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		for _, decl := range f.Decls {
+			for _, id := range DeclaredIdents(decl) {
+				declared[id.Name] = struct{}{}
+			}
+		}
+	}
+	refs := make([]*ast.Ident, 0)
+	// 未在文件内解析到的标识符即为对同包其他文件或预声明标识符的引用
+	for _, id := range p.Files[file].Unresolved {
+		if _, ok := declared[id.Name]; ok {
+			refs = append(refs, id)
+		}
+	}
+	for _, f := range siblings {
+		for _, id := range f.Unresolved {
+			if slices.Contains(idents, id.Name) {
+				refs = append(refs, id)
+			}
+		}
+	}
+	return refs
+}
+
+// DeclaredIdents 包级声明引入的标识符, 不含方法与init函数
+func DeclaredIdents(decl ast.Decl) []*ast.Ident {
+	res := make([]*ast.Ident, 0)
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv == nil && d.Name.Name != "init" {
+			res = append(res, d.Name)
+		}
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.ValueSpec:
+				res = append(res, s.Names...)
+			case *ast.TypeSpec:
+				res = append(res, s.Name)
+			}
+		}
+	}
+	return res
+}
+
+// renamePlan 制品重命名时新旧标识符的映射
+func renamePlan(from, to *Artifact) map[string]string {
+	olds, news := from.Idents(), to.Idents()
+	renames := make(map[string]string, len(olds))
+	for i := range olds {
+		if olds[i] != news[i] {
+			renames[olds[i]] = news[i]
+		}
+	}
+	return renames
+}
+
+// renameIdents 重命名节点中未限定包名的标识符, 返回重命名数量
+func renameIdents(node ast.Node, renames map[string]string) int {
+	n := 0
+	ast.Inspect(node, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.SelectorExpr:
+			// 仅处理选择器左侧, 右侧为字段/方法或其他包的标识符
+			n += renameIdents(node.X, renames)
+			return false
+		case *ast.Ident:
+			if newName, ok := renames[node.Name]; ok {
+				node.Name = newName
+				n++
+			}
+		}
+		return true
+	})
+	return n
+}
+
+// renameQualifiedRefs 将 local.Old 改写为 newLocal.New
+func renameQualifiedRefs(f *ast.File, local, newLocal string, idents []string, renames map[string]string) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := isQualifiedRef(n, local, idents)
+		if !ok {
+			return true
+		}
+		sel.X.(*ast.Ident).Name = newLocal
+		if newName, ok := renames[sel.Sel.Name]; ok {
+			sel.Sel.Name = newName
+		}
+		return true
+	})
+}
+
+// unqualifyRefs 将 local.Old 改写为未限定包名的 New
+func unqualifyRefs(f *ast.File, local string, idents []string, renames map[string]string) {
+	astutil.Apply(f, nil, func(c *astutil.Cursor) bool {
+		sel, ok := isQualifiedRef(c.Node(), local, idents)
+		if !ok {
+			return true
+		}
+		name := sel.Sel.Name
+		if newName, ok := renames[name]; ok {
+			name = newName
+		}
+		c.Replace(ast.NewIdent(name))
+		return true
+	})
+}
+
+// renameReceivers 重命名方法接收者, 方法体内已存在同名标识符时跳过
+func renameReceivers(f *ast.File, oldRecv, newRecv string) {
+	if oldRecv == newRecv {
+		return
+	}
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 || len(fn.Recv.List[0].Names) != 1 {
+			continue
+		}
+		recv := fn.Recv.List[0].Names[0]
+		if recv.Name != oldRecv || recv.Obj == nil {
+			continue
+		}
+		conflict := false
+		ast.Inspect(fn, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && id.Name == newRecv {
+				conflict = true
+			}
+			return !conflict
+		})
+		if conflict {
+			continue
+		}
+		obj := recv.Obj
+		ast.Inspect(fn, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && id.Obj == obj {
+				id.Name = newRecv
+			}
+			return true
+		})
+	}
+}
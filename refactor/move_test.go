@@ -0,0 +1,183 @@
+package refactor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testLoginAPI = `package user
+
+// LoginHandler API router注册点
+func LoginHandler() {
+	_ = hfLogin
+}
+
+type LoginApi struct{}
+
+type LoginApiRequest struct{}
+
+type LoginApiResponse struct{}
+
+func hfLogin() {}
+`
+
+// writeProject 在临时目录中创建项目并切换工作目录, files的键为相对路径
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files["go.mod"] = "module app\n\ngo 1.24\n"
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+	return dir
+}
+
+func moveAPI(t *testing.T, from, to string) (*Project, error) {
+	t.Helper()
+	p, err := LoadProject(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := ResolveArtifact(Kinds["api"], p.ModulePath, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ResolveArtifact(Kinds["api"], p.ModulePath, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, p.MoveArtifact(a, b)
+}
+
+func TestMoveArtifactAcrossPackages(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"api/user/login.go": testLoginAPI,
+		"router/router.go": `package router
+
+import "app/api/user"
+
+func Route() {
+	user.LoginHandler()
+}
+`,
+	})
+	p, err := moveAPI(t, "user.login", "auth.sign_in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Apply(false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "api/user")); !os.IsNotExist(err) {
+		t.Errorf("empty directory api/user should be removed, stat error: %v", err)
+	}
+	moved, err := os.ReadFile(filepath.Join(dir, "api/auth/sign_in.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"package auth", "func SignInHandler()", "type SignInApi struct", "func hfSignIn()"} {
+		if !strings.Contains(string(moved), want) {
+			t.Errorf("moved file missing %q:\n%s", want, moved)
+		}
+	}
+	router, err := os.ReadFile(filepath.Join(dir, "router/router.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(router), `"app/api/auth"`) || !strings.Contains(string(router), "auth.SignInHandler()") {
+		t.Errorf("router not rewritten:\n%s", router)
+	}
+}
+
+func TestMoveArtifactRefusesSiblingRefs(t *testing.T) {
+	tests := map[string]map[string]string{
+		// 制品文件引用了同包其他文件中的辅助函数
+		"artifact uses sibling": {
+			"api/user/login.go":  strings.Replace(testLoginAPI, "func hfLogin() {}", "func hfLogin() { check() }", 1),
+			"api/user/helper.go": "package user\n\nfunc check() {}\n",
+		},
+		// 同包其他文件引用了制品的标识符
+		"sibling uses artifact": {
+			"api/user/login.go":  testLoginAPI,
+			"api/user/helper.go": "package user\n\nvar _ = LoginApi{}\n",
+		},
+	}
+	for name, files := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := writeProject(t, files)
+			_, err := moveAPI(t, "user.login", "auth.login")
+			if err == nil {
+				t.Fatal("MoveArtifact succeeded, want error")
+			}
+			if _, err := os.Stat(filepath.Join(dir, "api/user/login.go")); err != nil {
+				t.Errorf("original file should be kept: %v", err)
+			}
+		})
+	}
+
+	// 同包内重命名不受影响
+	writeProject(t, map[string]string{
+		"api/user/login.go":  testLoginAPI,
+		"api/user/helper.go": "package user\n\nvar _ = LoginApi{}\n",
+	})
+	p, err := moveAPI(t, "user.login", "user.sign_in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Apply(false); err != nil {
+		t.Fatal(err)
+	}
+	helper, err := os.ReadFile("api/user/helper.go")
+	if err != nil || !strings.Contains(string(helper), "SignInApi{}") {
+		t.Errorf("helper.go not rewritten: %s, %v", helper, err)
+	}
+}
+
+func TestApplyRollback(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"api/user/login.go": testLoginAPI,
+		"router/router.go": `package router
+
+import "app/api/user"
+
+func Route() {
+	user.LoginHandler()
+}
+`,
+		"broken/keep.txt": "keep",
+	})
+	p, err := moveAPI(t, "user.login", "auth.sign_in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 删除一个非空目录必然失败, 此时已写入的文件需要恢复
+	p.Remove("broken")
+	if err := p.Apply(false); err == nil {
+		t.Fatal("Apply succeeded, want error")
+	}
+
+	login, err := os.ReadFile(filepath.Join(dir, "api/user/login.go"))
+	if err != nil || string(login) != testLoginAPI {
+		t.Errorf("api/user/login.go not restored: %v", err)
+	}
+	router, err := os.ReadFile(filepath.Join(dir, "router/router.go"))
+	if err != nil || strings.Contains(string(router), "auth") {
+		t.Errorf("router/router.go not restored:\n%s", router)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "api/auth")); !os.IsNotExist(err) {
+		t.Errorf("api/auth should be removed after rollback, stat error: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "router"))
+	if err != nil || len(entries) != 1 {
+		t.Errorf("temporary files left in router: %v", entries)
+	}
+}
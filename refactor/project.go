@@ -0,0 +1,277 @@
+package refactor
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// Project 以语法树形式加载的项目源码, 所有修改先记录在内存中, 由 Apply 统一落盘
+type Project struct {
+	Dir        string
+	ModulePath string
+	Fset       *token.FileSet
+	Files      map[string]*ast.File // 文件路径(相对Dir) -> 语法树
+
+	dirty   map[string]struct{}
+	writes  map[string][]byte
+	removes []string
+}
+
+// LoadProject 解析dir下除vendor、testdata及隐藏目录外的全部Go文件
+func LoadProject(dir string) (*Project, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	modulePath, err := comm.ModulePath(dir)
+	if err != nil {
+		return nil, err
+	}
+	p := &Project{
+		Dir:        dir,
+		ModulePath: modulePath,
+		Fset:       token.NewFileSet(),
+		Files:      make(map[string]*ast.File),
+		dirty:      make(map[string]struct{}),
+		writes:     make(map[string][]byte),
+	}
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		f, err := parser.ParseFile(p.Fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return i18n.Errorf("解析文件[%s]失败: %w", rel, err)
+		}
+		p.Files[rel] = f
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// SortedFiles 按路径排序的文件列表
+func (p *Project) SortedFiles() []string {
+	files := make([]string, 0, len(p.Files))
+	for file := range p.Files {
+		files = append(files, file)
+	}
+	slices.Sort(files)
+	return files
+}
+
+// MarkDirty 标记文件已被修改, Apply时重新格式化写入
+func (p *Project) MarkDirty(file string) {
+	p.dirty[file] = struct{}{}
+}
+
+// Create 记录一个新建文件
+func (p *Project) Create(file string, f *ast.File) {
+	p.Files[file] = f
+	p.MarkDirty(file)
+}
+
+// Remove 记录一个待删除文件
+func (p *Project) Remove(file string) {
+	delete(p.Files, file)
+	delete(p.dirty, file)
+	p.removes = append(p.removes, file)
+}
+
+// Position 返回相对路径形式的位置描述
+func (p *Project) Position(pos token.Pos) string {
+	position := p.Fset.Position(pos)
+	if rel, err := filepath.Rel(p.Dir, position.Filename); err == nil {
+		position.Filename = rel
+	}
+	return fmt.Sprintf("%s:%d", filepath.ToSlash(position.Filename), position.Line)
+}
+
+// Apply 格式化并写入所有修改, dryRun时仅输出计划
+func (p *Project) Apply(dryRun bool) error {
+	files := make([]string, 0, len(p.dirty))
+	for file := range p.dirty {
+		files = append(files, file)
+	}
+	slices.Sort(files)
+	for _, file := range files {
+		buf := bytes.Buffer{}
+		if err := format.Node(&buf, p.Fset, p.Files[file]); err != nil {
+			return i18n.Errorf("格式化文件[%s]失败: %w", file, err)
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return i18n.Errorf("格式化文件[%s]失败: %w", file, err)
+		}
+		p.writes[file] = src
+	}
+
+	for _, file := range p.removes {
+		comm.Log.With("path", file).Successf("删除文件: %s", file)
+	}
+	for _, file := range files {
+		comm.Log.With("path", file).Successf("写入文件: %s", file)
+	}
+	if dryRun {
+		return nil
+	}
+
+	if err := p.commit(files); err != nil {
+		return err
+	}
+	for _, file := range p.removes {
+		removeEmptyDirs(p.Dir, filepath.Dir(filepath.Join(p.Dir, file)))
+	}
+	return nil
+}
+
+// backup 修改前的文件内容, 用于回滚
+type backup struct {
+	path    string
+	data    []byte
+	mode    fs.FileMode
+	existed bool
+}
+
+// commit 将全部写入与删除作为一个整体落盘:
+// 先将新内容写入目标目录中的临时文件, 全部成功后再逐个替换与删除, 任一步骤失败时恢复所有已修改的文件
+func (p *Project) commit(files []string) (err error) {
+	temps := make(map[string]string, len(files))
+	backups := make([]backup, 0, len(files)+len(p.removes))
+	createdDirs := make([]string, 0)
+	defer func() {
+		for _, tmp := range temps {
+			_ = os.Remove(tmp)
+		}
+		if err == nil {
+			return
+		}
+		for i := len(backups) - 1; i >= 0; i-- {
+			b := backups[i]
+			if !b.existed {
+				_ = os.Remove(b.path)
+				continue
+			}
+			if rerr := os.WriteFile(b.path, b.data, b.mode); rerr != nil {
+				comm.Log.Errorf("恢复文件[%s]失败: %v", b.path, rerr)
+			}
+		}
+		for i := len(createdDirs) - 1; i >= 0; i-- {
+			removeEmptyDirs(p.Dir, createdDirs[i])
+		}
+	}()
+
+	// 暂存: 新内容先写入临时文件, 此阶段失败不会改动任何已有文件
+	for _, file := range files {
+		path := filepath.Join(p.Dir, file)
+		dir := filepath.Dir(path)
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			createdDirs = append(createdDirs, dir)
+		}
+		if err := comm.EnsureDir(dir); err != nil {
+			return err
+		}
+		tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".gbc-*")
+		if err != nil {
+			return i18n.Errorf("写入文件[%s]失败: %w", file, err)
+		}
+		temps[file] = tmp.Name()
+		_, err = tmp.Write(p.writes[file])
+		if cerr := tmp.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return i18n.Errorf("写入文件[%s]失败: %w", file, err)
+		}
+	}
+
+	// 替换: 记录原内容后再覆盖或删除
+	for _, file := range files {
+		path := filepath.Join(p.Dir, file)
+		b, err := readBackup(path)
+		if err != nil {
+			return i18n.Errorf("写入文件[%s]失败: %w", file, err)
+		}
+		if err := os.Chmod(temps[file], b.mode); err != nil {
+			return i18n.Errorf("写入文件[%s]失败: %w", file, err)
+		}
+		if err := os.Rename(temps[file], path); err != nil {
+			return i18n.Errorf("写入文件[%s]失败: %w", file, err)
+		}
+		delete(temps, file)
+		backups = append(backups, b)
+	}
+	for _, file := range p.removes {
+		path := filepath.Join(p.Dir, file)
+		b, err := readBackup(path)
+		if err != nil {
+			return i18n.Errorf("删除文件[%s]失败: %w", file, err)
+		}
+		if !b.existed {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return i18n.Errorf("删除文件[%s]失败: %w", file, err)
+		}
+		backups = append(backups, b)
+	}
+	return nil
+}
+
+// readBackup 读取文件当前的内容与权限, 文件不存在时记录为新建文件
+func readBackup(path string) (backup, error) {
+	fi, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return backup{path: path, mode: 0644}, nil
+	}
+	if err != nil {
+		return backup{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return backup{}, err
+	}
+	return backup{path: path, data: data, mode: fi.Mode().Perm(), existed: true}, nil
+}
+
+// removeEmptyDirs 自下而上删除空目录, 直到root为止
+func removeEmptyDirs(root, dir string) {
+	root = filepath.Clean(root)
+	for dir = filepath.Clean(dir); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) > 0 {
+			return
+		}
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package refactor
+
+import (
+	"go/ast"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// importName 返回文件导入importPath时使用的本地名称, 未导入时返回空字符串
+func importName(f *ast.File, importPath, pkgName string) string {
+	for _, spec := range f.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || p != importPath {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+		return pkgName
+	}
+	return ""
+}
+
+// isQualifiedRef 判断节点是否为 local.Ident 形式且Ident属于idents
+func isQualifiedRef(n ast.Node, local string, idents []string) (*ast.SelectorExpr, bool) {
+	sel, ok := n.(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	if !ok || x.Name != local || x.Obj != nil {
+		return nil, false
+	}
+	return sel, slices.Contains(idents, sel.Sel.Name)
+}
+
+func containsQualifiedRef(n ast.Node, local string, idents []string) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if _, ok := isQualifiedRef(n, local, idents); ok {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isStructField 判断复合字面量元素是否为结构体字段赋值 (Key为标识符)
+func isStructField(n ast.Node) bool {
+	kv, ok := n.(*ast.KeyValueExpr)
+	if !ok {
+		return false
+	}
+	_, ok = kv.Key.(*ast.Ident)
+	return ok
+}
+
+// deleteQualifiedRefs 删除文件中引用了 local.Ident 的语句或集合元素, 返回删除数量
+func deleteQualifiedRefs(f *ast.File, local string, idents []string) int {
+	deleted := 0
+	astutil.Apply(f, nil, func(c *astutil.Cursor) bool {
+		n := c.Node()
+		if n == nil || c.Index() < 0 {
+			return true
+		}
+		switch c.Parent().(type) {
+		case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+			if _, ok := n.(*ast.ExprStmt); !ok {
+				return true
+			}
+		case *ast.CompositeLit:
+			if c.Name() != "Elts" || isStructField(n) {
+				return true
+			}
+		default:
+			return true
+		}
+		if containsQualifiedRef(n, local, idents) {
+			// 删除代码块的最后一条语句时将右括号移至该语句所在行, 避免留下空行
+			if block, ok := c.Parent().(*ast.BlockStmt); ok && c.Index() == len(block.List)-1 {
+				block.Rbrace = n.Pos()
+			}
+			c.Delete()
+			deleted++
+		}
+		return true
+	})
+	return deleted
+}
+
+// collectQualifiedRefs 返回文件中剩余的 local.Ident 引用
+func collectQualifiedRefs(f *ast.File, local string, idents []string) []*ast.SelectorExpr {
+	refs := make([]*ast.SelectorExpr, 0)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := isQualifiedRef(n, local, idents); ok {
+			refs = append(refs, sel)
+		}
+		return true
+	})
+	return refs
+}
+
+// collectIdentRefs 返回文件中未限定包名的标识符引用 (同包内引用)
+func collectIdentRefs(f *ast.File, idents []string) []*ast.Ident {
+	refs := make([]*ast.Ident, 0)
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			ast.Inspect(n.X, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok && slices.Contains(idents, id.Name) {
+					refs = append(refs, id)
+				}
+				return true
+			})
+			return false
+		case *ast.Ident:
+			if slices.Contains(idents, n.Name) {
+				refs = append(refs, n)
+			}
+		}
+		return true
+	})
+	return refs
+}
+
+// deleteImportIfUnused 若导入不再被使用则删除
+func deleteImportIfUnused(p *Project, f *ast.File, local, importPath string) bool {
+	for _, spec := range f.Imports {
+		if ip, _ := strconv.Unquote(spec.Path.Value); ip != importPath {
+			continue
+		}
+		if spec.Name != nil && (spec.Name.Name == "_" || spec.Name.Name == ".") {
+			return false
+		}
+	}
+	used := false
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok && x.Name == local && x.Obj == nil {
+				used = true
+			}
+		}
+		return !used
+	})
+	if used {
+		return false
+	}
+	name := ""
+	if local != path.Base(importPath) {
+		name = local
+	}
+	return astutil.DeleteNamedImport(p.Fset, f, name, importPath)
+}
+
+// renameInComments 以单词边界替换注释中的标识符
+func renameInComments(f *ast.File, renames map[string]string) {
+	if len(renames) == 0 {
+		return
+	}
+	olds := make([]string, 0, len(renames))
+	for old := range renames {
+		olds = append(olds, regexp.QuoteMeta(old))
+	}
+	re := regexp.MustCompile(`\b(` + strings.Join(olds, "|") + `)\b`)
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			c.Text = re.ReplaceAllStringFunc(c.Text, func(s string) string {
+				return renames[s]
+			})
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package refactor
+
+import (
+	"path/filepath"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// RemoveArtifact 删除制品文件, 并移除其他文件中对其的注册调用与不再使用的导入
+func (p *Project) RemoveArtifact(a *Artifact) error {
+	file := filepath.Clean(a.Key.Path)
+	if _, ok := p.Files[file]; !ok {
+		return i18n.Errorf("%s对应的文件[%s]不存在", a, file)
+	}
+	idents := a.Idents()
+	p.Remove(file)
+
+	for _, name := range p.SortedFiles() {
+		f := p.Files[name]
+		if filepath.Dir(name) == filepath.Dir(file) {
+			// 同包内的其他文件无法自动处理, 仅提示
+			for _, ref := range collectIdentRefs(f, idents) {
+				comm.Log.With("path", p.Position(ref.Pos())).Warnf("%s 仍引用了 %s, 请手动处理", p.Position(ref.Pos()), ref.Name)
+			}
+			continue
+		}
+		local := importName(f, a.ImportPath, a.PackageName)
+		if local == "" {
+			continue
+		}
+		if n := deleteQualifiedRefs(f, local, idents); n > 0 {
+			comm.Log.With("path", name).Infof("移除文件[%s]中 %d 处对%s的引用", name, n, a)
+			p.MarkDirty(name)
+		}
+		for _, ref := range collectQualifiedRefs(f, local, idents) {
+			comm.Log.With("path", p.Position(ref.Pos())).Warnf("%s 仍引用了 %s.%s, 请手动处理", p.Position(ref.Pos()), local, ref.Sel.Name)
+		}
+		if deleteImportIfUnused(p, f, local, a.ImportPath) {
+			p.MarkDirty(name)
+		}
+	}
+	return nil
+}
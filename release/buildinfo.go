@@ -0,0 +1,44 @@
+package release
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// DevelVersion 无法从构建信息中获得版本号时 (如 go run 或未包含VCS信息的本地构建) 使用的版本
+const DevelVersion = "(devel)"
+
+// BuildInfo gbc自身的构建信息
+type BuildInfo struct {
+	Version   string    `json:"version"`
+	Revision  string    `json:"revision,omitempty"`
+	Time      time.Time `json:"time,omitzero"`
+	Modified  bool      `json:"modified"`
+	GoVersion string    `json:"go_version"`
+}
+
+// Current 读取当前gbc的构建信息
+// 通过 go install 安装时版本号为对应的tag, 源码构建时为go根据VCS信息生成的伪版本
+var Current = sync.OnceValue(func() *BuildInfo {
+	info := &BuildInfo{Version: DevelVersion, GoVersion: runtime.Version()}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if bi.Main.Version != "" {
+		info.Version = bi.Main.Version
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Revision = s.Value
+		case "vcs.time":
+			info.Time, _ = time.Parse(time.RFC3339, s.Value)
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+	return info
+})
@@ -0,0 +1,116 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// CheckInterval 两次联网检查新版本的最小间隔
+const CheckInterval = 24 * time.Hour
+
+// checkTimeout 后台联网检查新版本的最长耗时
+const checkTimeout = 3 * time.Second
+
+// checkState 缓存在用户缓存目录中的检查结果
+type checkState struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest"`
+}
+
+// UpdateCheck 在后台检查gbc是否有新版本
+type UpdateCheck struct {
+	current *version.Version
+	result  chan string
+}
+
+// StartUpdateCheck 开始检查新版本: 距上次检查不足 CheckInterval 时直接使用缓存, 否则在后台通过lister查询并更新缓存
+func StartUpdateCheck(current string, lister func() Lister) *UpdateCheck {
+	cur, err := version.NewVersion(current)
+	if err != nil {
+		return nil
+	}
+	u := &UpdateCheck{current: cur, result: make(chan string, 1)}
+	path := checkStatePath()
+	state := readCheckState(path)
+	if state != nil && time.Since(state.CheckedAt) < CheckInterval {
+		u.result <- state.Latest
+		return u
+	}
+	// 联网前先记录检查时间, 避免网络不可用或进程提前退出时每次执行命令都发起请求
+	latest := ""
+	if state != nil {
+		latest = state.Latest
+	}
+	writeCheckState(path, &checkState{CheckedAt: time.Now(), Latest: latest})
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+		defer cancel()
+		if tags, err := lister().Tags(ctx); err == nil {
+			if tag, err := Pick(tags, "", false); err == nil && tag.Name != latest {
+				latest = tag.Name
+				writeCheckState(path, &checkState{CheckedAt: time.Now(), Latest: latest})
+			}
+		}
+		u.result <- latest
+	}()
+	return u
+}
+
+// Newer 最多等待timeout, 返回比当前版本更新的版本号, 无更新或未完成检查时返回空
+func (u *UpdateCheck) Newer(timeout time.Duration) string {
+	if u == nil {
+		return ""
+	}
+	select {
+	case latest := <-u.result:
+		ver, err := version.NewVersion(latest)
+		if err != nil || !ver.GreaterThan(u.current) {
+			return ""
+		}
+		return latest
+	case <-time.After(timeout):
+		return ""
+	}
+}
+
+func checkStatePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gbc", "update-check.json")
+}
+
+func readCheckState(path string) *checkState {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	state := &checkState{}
+	if json.Unmarshal(data, state) != nil {
+		return nil
+	}
+	return state
+}
+
+func writeCheckState(path string, state *checkState) {
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(filepath.Dir(path), 0755) != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,51 @@
+package release
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// blockingLister 直到ctx结束才返回, 用于检查后台检查的超时
+type blockingLister struct {
+	deadline chan time.Time
+}
+
+func (l *blockingLister) Tags(ctx context.Context) ([]Tag, error) {
+	deadline, _ := ctx.Deadline()
+	l.deadline <- deadline
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestStartUpdateCheckRecordsBeforeFetch(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	path := checkStatePath()
+	writeCheckState(path, &checkState{CheckedAt: time.Now().Add(-2 * CheckInterval), Latest: "v1.1.0"})
+
+	lister := &blockingLister{deadline: make(chan time.Time, 1)}
+	start := time.Now()
+	u := StartUpdateCheck("v1.0.0", func() Lister { return lister })
+
+	// 查询尚未完成时已记录检查时间, 并保留上次的结果
+	state := readCheckState(path)
+	if state == nil || state.CheckedAt.Before(start) || state.Latest != "v1.1.0" {
+		t.Fatalf("state before fetch = %+v", state)
+	}
+	deadline := <-lister.deadline
+	if deadline.IsZero() || deadline.Sub(start) > checkTimeout+time.Second {
+		t.Errorf("lister deadline = %v after start, want at most %v", deadline.Sub(start), checkTimeout)
+	}
+	if got := u.Newer(checkTimeout + time.Second); got != "v1.1.0" {
+		t.Errorf("Newer() = %q, want cached v1.1.0", got)
+	}
+
+	// 距上次检查不足CheckInterval时不再联网
+	u = StartUpdateCheck("v1.0.0", func() Lister {
+		t.Error("lister called within CheckInterval")
+		return lister
+	})
+	if got := u.Newer(time.Second); got != "v1.1.0" {
+		t.Errorf("Newer() = %q, want cached v1.1.0", got)
+	}
+}
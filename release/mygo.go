@@ -0,0 +1,193 @@
+package release
+
+import (
+	"cmp"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// MygoModulePath gbc生成的代码所依赖的框架
+const MygoModulePath = "github.com/zjutjh/mygo"
+
+// Feature gbc功能与其生成代码所调用的mygo API
+type Feature struct {
+	Name string   `json:"name"` // gbc命令
+	API  []string `json:"api"`  // 生成代码调用的mygo API, 形如 包路径.标识符, 包路径相对于mygo模块
+}
+
+// Features gbc功能与mygo API的对应关系, 新增或修改生成代码所用的mygo API时需同步维护
+// 兼容性不依赖人工维护的版本号, 而是检查项目实际依赖的mygo源码中是否声明了这些API
+var Features = []Feature{
+	{Name: "api", API: []string{"swagger.CM", "foundation/reply.Success", "foundation/reply.Fail", "kit.Code"}},
+	{Name: "cmd", API: []string{"nlog.Pick"}},
+	{Name: "cron", API: []string{"nlog.Pick"}},
+	{Name: "codegen", API: []string{"swagger.MustRegisterBusinessStatusCodes", "kit.NewCode"}},
+}
+
+// FindFeature 按名称查找功能
+func FindFeature(name string) *Feature {
+	for i := range Features {
+		if Features[i].Name == name {
+			return &Features[i]
+		}
+	}
+	return nil
+}
+
+// ProjectMygoVersion 读取项目go.mod中依赖的mygo版本, 未依赖时返回空
+func ProjectMygoVersion(dir string) (string, error) {
+	f, err := comm.ReadGoMod(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range f.Require {
+		if r.Mod.Path == MygoModulePath {
+			return r.Mod.Version, nil
+		}
+	}
+	return "", nil
+}
+
+// MygoAPI 项目实际依赖的mygo (含replace) 中各包声明的顶层标识符
+type MygoAPI struct {
+	Version string
+	Dir     string
+	pkgs    map[string]map[string]bool
+}
+
+// LoadMygoAPI 按项目go.mod中的mygo依赖 (含replace) 定位其源码, 项目未依赖mygo时返回nil
+// 只读取本地模块缓存或replace指向的目录, 不执行go命令, 因此不会修改项目的go.mod/go.sum; 尚未下载时返回错误
+func LoadMygoAPI(dir string) (*MygoAPI, error) {
+	f, err := comm.ReadGoMod(dir)
+	if err != nil {
+		return nil, err
+	}
+	var mod *module.Version
+	for _, r := range f.Require {
+		if r.Mod.Path == MygoModulePath {
+			mod = &r.Mod
+		}
+	}
+	if mod == nil {
+		return nil, nil
+	}
+	version := mod.Version
+	for _, r := range f.Replace {
+		if r.Old.Path != MygoModulePath || (r.Old.Version != "" && r.Old.Version != mod.Version) {
+			continue
+		}
+		if modfile.IsDirectoryPath(r.New.Path) {
+			local := r.New.Path
+			if !filepath.IsAbs(local) {
+				local = filepath.Join(dir, local)
+			}
+			return &MygoAPI{Version: version, Dir: local, pkgs: make(map[string]map[string]bool)}, nil
+		}
+		mod = &r.New
+		version = r.New.Version
+	}
+
+	path, err := module.EscapePath(mod.Path)
+	if err == nil {
+		var escaped string
+		escaped, err = module.EscapeVersion(mod.Version)
+		path += "@" + escaped
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取mygo模块信息失败: %w", err)
+	}
+	cache := GoEnv("GOMODCACHE", "GOPATH")
+	root := cache["GOMODCACHE"]
+	if root == "" {
+		root = filepath.Join(cmp.Or(cache["GOPATH"], build.Default.GOPATH), "pkg", "mod")
+	}
+	moduleDir := filepath.Join(root, path)
+	if info, err := os.Stat(moduleDir); err != nil || !info.IsDir() {
+		return nil, i18n.Errorf("mygo %s 尚未下载, 请先执行 go mod download", version)
+	}
+	return &MygoAPI{Version: version, Dir: moduleDir, pkgs: make(map[string]map[string]bool)}, nil
+}
+
+// Missing 返回功能所需但mygo中未声明的API
+func (a *MygoAPI) Missing(f *Feature) []string {
+	missing := make([]string, 0)
+	for _, api := range f.API {
+		i := strings.LastIndex(api, ".")
+		if !a.declared(api[:i])[api[i+1:]] {
+			missing = append(missing, api)
+		}
+	}
+	return missing
+}
+
+// declared 读取包中非测试文件声明的顶层标识符, 包不存在时为空
+func (a *MygoAPI) declared(pkg string) map[string]bool {
+	if names, ok := a.pkgs[pkg]; ok {
+		return names
+	}
+	names := make(map[string]bool)
+	a.pkgs[pkg] = names
+	files, _ := filepath.Glob(filepath.Join(a.Dir, filepath.FromSlash(pkg), "*.go"))
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			comm.Log.Debugf("解析文件[%s]失败: %s", file, err.Error())
+			continue
+		}
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					names[d.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.ValueSpec:
+						for _, n := range s.Names {
+							names[n.Name] = true
+						}
+					case *ast.TypeSpec:
+						names[s.Name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// WarnIncompatibleMygo 项目依赖的mygo中缺少功能所需的API时输出警告
+func WarnIncompatibleMygo(dir, feature string) {
+	f := FindFeature(feature)
+	if f == nil {
+		return
+	}
+	api, err := LoadMygoAPI(dir)
+	if err != nil {
+		comm.Log.Debugf("跳过mygo兼容性检查: %s", err.Error())
+		return
+	}
+	if api == nil {
+		return
+	}
+	if missing := api.Missing(f); len(missing) > 0 {
+		comm.Log.Warnf("项目依赖的mygo %s 中缺少 gbc %s 生成代码所用的API: %s, 生成的代码可能无法编译, 请执行 go get %s@latest 升级",
+			api.Version, f.Name, strings.Join(missing, ", "), MygoModulePath)
+	}
+}
@@ -0,0 +1,111 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMygoAPIMissing(t *testing.T) {
+	root := t.TempDir()
+	// 通过replace使用本地的mygo, 其中缺少reply.Fail与swagger.MustRegisterBusinessStatusCodes
+	writeTree(t, filepath.Join(root, "mygo"), map[string]string{
+		"go.mod":                        "module github.com/zjutjh/mygo\n\ngo 1.24\n",
+		"swagger/swagger.go":            "package swagger\n\nvar CM = 1\n",
+		"foundation/reply/reply.go":     "package reply\n\nfunc Success() {}\n\ntype R struct{}\n\nfunc (R) Fail() {}\n",
+		"foundation/reply/fail_test.go": "package reply\n\nfunc Fail() {}\n",
+		"kit/code.go":                   "package kit\n\ntype Code struct{}\n\nfunc NewCode() Code { return Code{} }\n",
+		"nlog/nlog.go":                  "package nlog\n\nconst (\n\tPick = iota\n)\n",
+	})
+	writeTree(t, filepath.Join(root, "app"), map[string]string{
+		"go.mod": "module app\n\ngo 1.24\n\nrequire github.com/zjutjh/mygo v0.1.0\n\nreplace github.com/zjutjh/mygo => ../mygo\n",
+	})
+
+	api, err := LoadMygoAPI(filepath.Join(root, "app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string][]string{
+		"api":     {"foundation/reply.Fail"},
+		"cmd":     {},
+		"cron":    {},
+		"codegen": {"swagger.MustRegisterBusinessStatusCodes"},
+	}
+	for name, want := range tests {
+		if got := api.Missing(FindFeature(name)); !slices.Equal(got, want) {
+			t.Errorf("Missing(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestLoadMygoAPI(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"plain/go.mod":   "module plain\n\ngo 1.24\n",
+		"missing/go.mod": "module missing\n\ngo 1.24\n\nrequire github.com/zjutjh/mygo v0.1.0\n",
+	})
+	api, err := LoadMygoAPI(filepath.Join(root, "plain"))
+	if api != nil || err != nil {
+		t.Errorf("LoadMygoAPI() without mygo = %v, %v, want nil, nil", api, err)
+	}
+
+	// 模块缓存中不存在时不联网下载
+	t.Setenv("GOMODCACHE", filepath.Join(root, "modcache"))
+	if _, err := LoadMygoAPI(filepath.Join(root, "missing")); err == nil {
+		t.Error("LoadMygoAPI() with mygo not downloaded succeeded, want error")
+	}
+
+	writeTree(t, root, map[string]string{
+		"modcache/github.com/zjutjh/mygo@v0.2.0/go.mod":  "module github.com/zjutjh/mygo\n",
+		"modcache/github.com/!my!org/mygo@v0.3.0/go.mod": "module github.com/MyOrg/mygo\n",
+		"local/mygo/go.mod": "module github.com/zjutjh/mygo\n",
+		"cached/go.mod":     "module cached\n\ngo 1.24\n\nrequire github.com/zjutjh/mygo v0.2.0\n",
+		"forked/go.mod":     "module forked\n\ngo 1.24\n\nrequire github.com/zjutjh/mygo v0.2.0\n\nreplace github.com/zjutjh/mygo => github.com/MyOrg/mygo v0.3.0\n",
+		"replaced/go.mod":   "module replaced\n\ngo 1.24\n\nrequire github.com/zjutjh/mygo v0.2.0\n\nreplace github.com/zjutjh/mygo => ../local/mygo\n",
+	})
+	tests := []struct {
+		project string
+		version string
+		dir     string
+	}{
+		{"cached", "v0.2.0", "modcache/github.com/zjutjh/mygo@v0.2.0"},
+		{"forked", "v0.3.0", "modcache/github.com/!my!org/mygo@v0.3.0"},
+		{"replaced", "v0.2.0", "local/mygo"},
+	}
+	for _, tt := range tests {
+		project := filepath.Join(root, tt.project)
+		before, err := os.ReadFile(filepath.Join(project, "go.mod"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		api, err := LoadMygoAPI(project)
+		if err != nil {
+			t.Errorf("LoadMygoAPI(%s) error = %v", tt.project, err)
+			continue
+		}
+		if api.Version != tt.version || api.Dir != filepath.Join(root, tt.dir) {
+			t.Errorf("LoadMygoAPI(%s) = %s %s, want %s %s", tt.project, api.Version, api.Dir, tt.version, tt.dir)
+		}
+		// 只读检查不得修改项目的go.mod/go.sum
+		if after, _ := os.ReadFile(filepath.Join(project, "go.mod")); string(after) != string(before) {
+			t.Errorf("LoadMygoAPI(%s) modified go.mod:\n%s", tt.project, after)
+		}
+		if _, err := os.Stat(filepath.Join(project, "go.sum")); err == nil {
+			t.Errorf("LoadMygoAPI(%s) created go.sum", tt.project)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package release
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-version"
+	"golang.org/x/mod/module"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+const defaultGOPROXY = "https://proxy.golang.org,direct"
+
+// Lister 版本列表来源
+type Lister interface {
+	Tags(ctx context.Context) ([]Tag, error)
+}
+
+// errNotFound 模块代理返回404/410, 按GOPROXY协议可继续尝试逗号分隔的下一个代理
+var errNotFound = errors.New(i18n.T("模块代理中不存在该模块"))
+
+// ProxyLister 通过GOPROXY协议 (/@v/list 与 /@latest) 获取模块版本
+// 遵循 GOPROXY 的回退顺序: 逗号分隔的代理仅在404/410时回退, 竖线分隔的代理在任意错误时回退
+// 匹配 GONOPROXY (未设置时为 GOPRIVATE) 的模块直接从版本控制仓库获取
+type ProxyLister struct {
+	ModulePath string
+	Proxy      string // GOPROXY
+	NoProxy    string // GONOPROXY
+	Private    string // GOPRIVATE
+	http       *resty.Client
+}
+
+func NewProxyLister(modulePath string, env map[string]string) *ProxyLister {
+	return &ProxyLister{
+		ModulePath: modulePath,
+		Proxy:      env["GOPROXY"],
+		NoProxy:    env["GONOPROXY"],
+		Private:    env["GOPRIVATE"],
+		http:       resty.New().SetTimeout(10 * time.Second),
+	}
+}
+
+// GoEnv 读取go env中的配置 (包含 go env -w 写入的值), go命令不可用时读取环境变量
+func GoEnv(keys ...string) map[string]string {
+	env := make(map[string]string, len(keys))
+	out, err := exec.Command("go", append([]string{"env", "-json"}, keys...)...).Output()
+	if err == nil && json.Unmarshal(out, &env) == nil {
+		return env
+	}
+	for _, key := range keys {
+		env[key] = os.Getenv(key)
+	}
+	return env
+}
+
+// Tags 按GOPROXY配置依次尝试获取版本列表
+func (l *ProxyLister) Tags(ctx context.Context) ([]Tag, error) {
+	noProxy := l.NoProxy
+	if noProxy == "" {
+		noProxy = l.Private
+	}
+	if module.MatchPrefixPatterns(noProxy, l.ModulePath) {
+		return l.listDirect(ctx)
+	}
+
+	proxy := l.Proxy
+	if proxy == "" {
+		proxy = defaultGOPROXY
+	}
+	var errs []error
+	for proxy != "" {
+		entry, rest, anyErr := nextProxy(proxy)
+		proxy = rest
+		var (
+			tags []Tag
+			err  error
+		)
+		switch entry {
+		case "":
+			continue
+		case "off":
+			err = errors.New(i18n.T("GOPROXY=off, 禁止访问模块代理"))
+		case "direct":
+			tags, err = l.listDirect(ctx)
+		default:
+			tags, err = l.listProxy(ctx, entry)
+		}
+		if err == nil {
+			return tags, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", entry, err))
+		if !anyErr && !errors.Is(err, errNotFound) {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// nextProxy 拆分GOPROXY的第一项, anyErr表示该项之后以竖线分隔, 任意错误均可回退
+func nextProxy(proxy string) (entry, rest string, anyErr bool) {
+	i := strings.IndexAny(proxy, ",|")
+	if i < 0 {
+		return strings.TrimSpace(proxy), "", false
+	}
+	return strings.TrimSpace(proxy[:i]), proxy[i+1:], proxy[i] == '|'
+}
+
+func (l *ProxyLister) listProxy(ctx context.Context, proxyURL string) ([]Tag, error) {
+	escaped, err := module.EscapePath(l.ModulePath)
+	if err != nil {
+		return nil, err
+	}
+	base := strings.TrimSuffix(proxyURL, "/") + "/" + escaped
+
+	body, err := l.get(ctx, base+"/@v/list")
+	if err != nil {
+		return nil, err
+	}
+	names := strings.Fields(body)
+	if len(names) == 0 {
+		// 仅有伪版本时 /@v/list 为空, 使用 /@latest
+		body, err := l.get(ctx, base+"/@latest")
+		if err != nil {
+			return nil, err
+		}
+		latest := struct{ Version string }{}
+		if err := json.Unmarshal([]byte(body), &latest); err != nil {
+			return nil, i18n.Errorf("解析@latest响应失败: %w", err)
+		}
+		if latest.Version != "" && !module.IsPseudoVersion(latest.Version) {
+			names = append(names, latest.Version)
+		}
+	}
+	return toTags(names), nil
+}
+
+func (l *ProxyLister) get(ctx context.Context, url string) (string, error) {
+	resp, err := l.http.R().SetContext(ctx).Get(url)
+	if err != nil {
+		return "", err
+	}
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return resp.String(), nil
+	case http.StatusNotFound, http.StatusGone:
+		return "", errNotFound
+	}
+	return "", fmt.Errorf("Status Code[%d|%s]", resp.StatusCode(), resp.Status())
+}
+
+// listDirect 直接从版本控制仓库读取tag
+func (l *ProxyLister) listDirect(ctx context.Context) ([]Tag, error) {
+	out, err := exec.CommandContext(ctx, "git", "ls-remote", "--tags", "--refs", "https://"+l.ModulePath).Output()
+	if err != nil {
+		return nil, i18n.Errorf("读取仓库tag失败: %w", err)
+	}
+	names := make([]string, 0)
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 {
+			names = append(names, strings.TrimPrefix(fields[1], "refs/tags/"))
+		}
+	}
+	return toTags(names), nil
+}
+
+func toTags(names []string) []Tag {
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		ver, err := version.NewVersion(name)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, Tag{Name: name, Version: ver})
+	}
+	SortTags(tags)
+	return tags
+}
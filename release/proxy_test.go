@@ -0,0 +1,142 @@
+package release
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+const testModule = "github.com/Org/gbc"
+
+// fakeProxy 模拟模块代理, routes的键为请求路径, 值为响应体, 未配置的路径返回status
+func fakeProxy(t *testing.T, status int, routes map[string]string) (string, func() int) {
+	t.Helper()
+	mu := sync.Mutex{}
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits++
+		mu.Unlock()
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return hits
+	}
+}
+
+func TestProxyListerFallback(t *testing.T) {
+	// 模块路径中的大写字母按GOPROXY协议转义
+	list := map[string]string{"/github.com/!org/gbc/@v/list": "v1.1.0\nv1.0.0\nv1.2.0-rc.1\n"}
+	ok, _ := fakeProxy(t, http.StatusNotFound, list)
+	notFound, _ := fakeProxy(t, http.StatusNotFound, nil)
+	gone, _ := fakeProxy(t, http.StatusGone, nil)
+	broken, _ := fakeProxy(t, http.StatusInternalServerError, nil)
+
+	tests := []struct {
+		name    string
+		proxy   string
+		wantErr bool
+	}{
+		{name: "single", proxy: ok},
+		{name: "comma after 404", proxy: notFound + "," + ok},
+		{name: "comma after 410", proxy: gone + "," + ok},
+		{name: "comma stops on other errors", proxy: broken + "," + ok, wantErr: true},
+		{name: "pipe after any error", proxy: broken + "|" + ok},
+		{name: "mixed", proxy: notFound + "," + broken + "|" + ok},
+		{name: "empty entries", proxy: " ," + ok},
+		{name: "off", proxy: "off," + ok, wantErr: true},
+		{name: "all missing", proxy: notFound + "," + gone, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := NewProxyLister(testModule, map[string]string{"GOPROXY": tt.proxy}).Tags(t.Context())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Tags() = %v, want error", tagNames(tags))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []string{"v1.0.0", "v1.1.0", "v1.2.0-rc.1"}
+			if got := tagNames(tags); !slices.Equal(got, want) {
+				t.Errorf("Tags() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestProxyListerLatest(t *testing.T) {
+	tests := []struct {
+		name   string
+		latest string
+		want   []string
+	}{
+		{name: "release", latest: `{"Version":"v1.3.0","Time":"2025-01-01T00:00:00Z"}`, want: []string{"v1.3.0"}},
+		// 仅有伪版本时没有可安装的版本
+		{name: "pseudo version", latest: `{"Version":"v0.0.0-20250101000000-abcdefabcdef"}`, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy, _ := fakeProxy(t, http.StatusNotFound, map[string]string{
+				"/github.com/!org/gbc/@v/list": "",
+				"/github.com/!org/gbc/@latest": tt.latest,
+			})
+			tags, err := NewProxyLister(testModule, map[string]string{"GOPROXY": proxy}).Tags(t.Context())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tagNames(tags); !slices.Equal(got, tt.want) {
+				t.Errorf("Tags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProxyListerNoProxy(t *testing.T) {
+	// 直接访问版本控制仓库时不应等待输入凭据
+	t.Setenv("GIT_TERMINAL_PROMPT", "0")
+	const private = "gbc.invalid/org/gbc"
+	tests := []struct {
+		name      string
+		env       map[string]string
+		wantProxy bool
+	}{
+		{name: "GONOPROXY", env: map[string]string{"GONOPROXY": "gbc.invalid"}},
+		{name: "GOPRIVATE", env: map[string]string{"GOPRIVATE": "*.example.com,gbc.invalid/org"}},
+		// GONOPROXY优先于GOPRIVATE
+		{name: "GONOPROXY overrides GOPRIVATE", env: map[string]string{"GONOPROXY": "none", "GOPRIVATE": "gbc.invalid"}, wantProxy: true},
+		{name: "no match", env: map[string]string{"GOPRIVATE": "gbc.invalid/other"}, wantProxy: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy, hits := fakeProxy(t, http.StatusNotFound, map[string]string{"/gbc.invalid/org/gbc/@v/list": "v1.0.0\n"})
+			tt.env["GOPROXY"] = proxy
+			tags, err := NewProxyLister(private, tt.env).Tags(t.Context())
+			if tt.wantProxy {
+				if err != nil || hits() == 0 {
+					t.Fatalf("Tags() = %v, %v with %d proxy requests, want proxy result", tagNames(tags), err, hits())
+				}
+				return
+			}
+			// 直接访问的仓库不存在, 且不应请求模块代理
+			if err == nil {
+				t.Errorf("Tags() = %v, want error from direct access", tagNames(tags))
+			}
+			if hits() != 0 {
+				t.Errorf("proxy requested %d times, want 0", hits())
+			}
+		})
+	}
+}
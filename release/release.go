@@ -0,0 +1,152 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-version"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// Tag gbc的一个版本
+type Tag struct {
+	Name    string
+	Version *version.Version
+}
+
+// Note 某个版本的发布说明
+type Note struct {
+	Tag  string
+	Name string
+	Body string
+}
+
+// Changelog 两个版本之间的变更
+type Changelog struct {
+	Notes   []Note   // 区间内各版本的发布说明, 按版本升序
+	Commits []string // 无发布说明时使用区间内的提交标题
+}
+
+// Client GitHub仓库API客户端, 用于获取发布说明与提交记录, 也可作为版本列表来源, BaseURL形如 https://api.github.com/repos/{owner}/{repo}
+type Client struct {
+	BaseURL string
+	http    *resty.Client
+}
+
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		http:    resty.New().SetTimeout(10 * time.Second),
+	}
+}
+
+// Tags 通过GitHub API获取仓库中全部符合语义化版本的tag, 按版本升序
+func (c *Client) Tags(ctx context.Context) ([]Tag, error) {
+	result := make([]struct {
+		Name string `json:"name"`
+	}, 0)
+	if err := c.get(ctx, "/tags?per_page=100", &result); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(result))
+	for _, t := range result {
+		names = append(names, t.Name)
+	}
+	return toTags(names), nil
+}
+
+// Changelog 获取from(不含)到to(含)之间的变更, from高于to时为回退, 返回将被撤销的变更
+func (c *Client) Changelog(ctx context.Context, from, to *version.Version) (*Changelog, error) {
+	lo, hi := from, to
+	if lo.GreaterThan(hi) {
+		lo, hi = hi, lo
+	}
+	releases := make([]struct {
+		TagName string `json:"tag_name"`
+		Name    string `json:"name"`
+		Body    string `json:"body"`
+	}, 0)
+	if err := c.get(ctx, "/releases?per_page=100", &releases); err != nil {
+		return nil, err
+	}
+	log := &Changelog{}
+	for _, r := range releases {
+		ver, err := version.NewVersion(r.TagName)
+		if err != nil || !ver.GreaterThan(lo) || ver.GreaterThan(hi) || strings.TrimSpace(r.Body) == "" {
+			continue
+		}
+		log.Notes = append(log.Notes, Note{Tag: r.TagName, Name: r.Name, Body: strings.TrimSpace(r.Body)})
+	}
+	if len(log.Notes) > 0 {
+		slices.SortFunc(log.Notes, func(a, b Note) int {
+			return version.Must(version.NewVersion(a.Tag)).Compare(version.Must(version.NewVersion(b.Tag)))
+		})
+		return log, nil
+	}
+
+	compare := struct {
+		Commits []struct {
+			Commit struct {
+				Message string `json:"message"`
+			} `json:"commit"`
+		} `json:"commits"`
+	}{}
+	if err := c.get(ctx, fmt.Sprintf("/compare/%s...%s", lo.Original(), hi.Original()), &compare); err != nil {
+		return nil, err
+	}
+	for _, commit := range compare.Commits {
+		subject, _, _ := strings.Cut(commit.Commit.Message, "\n")
+		log.Commits = append(log.Commits, subject)
+	}
+	return log, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, result any) error {
+	resp, err := c.http.R().
+		SetContext(ctx).
+		ForceContentType("application/json").
+		SetResult(result).
+		Get(c.BaseURL + path)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Status Code[%d|%s]", resp.StatusCode(), resp.Status())
+	}
+	return nil
+}
+
+// SortTags 按版本升序排列
+func SortTags(tags []Tag) {
+	slices.SortFunc(tags, func(a, b Tag) int {
+		return a.Version.Compare(b.Version)
+	})
+}
+
+// Pick 选择目标版本: target非空时须为已发布的版本, 否则选择最新版本, prerelease为false时忽略预发布版本
+func Pick(tags []Tag, target string, prerelease bool) (*Tag, error) {
+	if target != "" {
+		want, err := version.NewVersion(target)
+		if err != nil {
+			return nil, i18n.Errorf("版本号[%s]不合法: %w", target, err)
+		}
+		for i := range tags {
+			if tags[i].Version.Equal(want) {
+				return &tags[i], nil
+			}
+		}
+		return nil, i18n.Errorf("版本[%s]不存在", target)
+	}
+	for i := len(tags) - 1; i >= 0; i-- {
+		if prerelease || tags[i].Version.Prerelease() == "" {
+			return &tags[i], nil
+		}
+	}
+	return nil, i18n.Errorf("未找到可用的版本")
+}
@@ -0,0 +1,149 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+// fakeGitHub 模拟GitHub仓库API, routes的键为请求路径 (含查询参数), 值为响应体
+func fakeGitHub(t *testing.T, routes map[string]string) (*Client, *[]string) {
+	t.Helper()
+	requests := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		body, ok := routes[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL + "/"), &requests
+}
+
+func tagNames(tags []Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+func TestClientTags(t *testing.T) {
+	c, _ := fakeGitHub(t, map[string]string{
+		"/tags?per_page=100": `[{"name":"v1.10.0"},{"name":"v1.2.0"},{"name":"nightly"},{"name":"v1.11.0-rc.1"},{"name":"v1.9.0"}]`,
+	})
+	tags, err := c.Tags(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"v1.2.0", "v1.9.0", "v1.10.0", "v1.11.0-rc.1"}
+	if got := tagNames(tags); !slices.Equal(got, want) {
+		t.Errorf("Tags() = %v, want %v", got, want)
+	}
+
+	c, _ = fakeGitHub(t, nil)
+	if _, err := c.Tags(t.Context()); err == nil {
+		t.Error("Tags() on 404 succeeded, want error")
+	}
+}
+
+func TestPick(t *testing.T) {
+	tags := toTags([]string{"v1.0.0", "v1.1.0", "v1.2.0-rc.1", "v0.9.0"})
+	tests := []struct {
+		name       string
+		target     string
+		prerelease bool
+		want       string
+		wantErr    bool
+	}{
+		{name: "latest stable", want: "v1.1.0"},
+		{name: "latest prerelease", prerelease: true, want: "v1.2.0-rc.1"},
+		{name: "target", target: "v1.2.0-rc.1", want: "v1.2.0-rc.1"},
+		{name: "target without v", target: "1.1.0", want: "v1.1.0"},
+		{name: "rollback", target: "v0.9.0", want: "v0.9.0"},
+		{name: "missing", target: "v2.0.0", wantErr: true},
+		{name: "invalid", target: "latest", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := Pick(tags, tt.target, tt.prerelease)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Pick() = %s, want error", tag.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tag.Name != tt.want {
+				t.Errorf("Pick() = %s, want %s", tag.Name, tt.want)
+			}
+		})
+	}
+
+	if _, err := Pick(toTags([]string{"v1.0.0-rc.1"}), "", false); err == nil {
+		t.Error("Pick() with only prereleases succeeded, want error")
+	}
+}
+
+func TestClientChangelog(t *testing.T) {
+	releases := `[
+		{"tag_name":"v1.3.0","name":"1.3","body":"three"},
+		{"tag_name":"v1.1.0","name":"1.1","body":"one"},
+		{"tag_name":"v1.2.0","name":"1.2","body":"  two\n"},
+		{"tag_name":"v1.0.0","name":"1.0","body":"zero"},
+		{"tag_name":"v1.4.0","name":"1.4","body":""}
+	]`
+	c, requests := fakeGitHub(t, map[string]string{
+		"/releases?per_page=100":   releases,
+		"/compare/v1.3.0...v1.4.0": `{"commits":[{"commit":{"message":"feat: a\n\nbody"}},{"commit":{"message":"fix: b"}}]}`,
+	})
+	v := func(s string) *version.Version {
+		return version.Must(version.NewVersion(s))
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		notes    []string
+		commits  []string
+	}{
+		{name: "upgrade", from: "v1.0.0", to: "v1.2.0", notes: []string{"v1.1.0", "v1.2.0"}},
+		// 回退时返回将被撤销的版本
+		{name: "rollback", from: "v1.3.0", to: "v1.1.0", notes: []string{"v1.2.0", "v1.3.0"}},
+		// 区间内没有发布说明时使用提交标题
+		{name: "commits", from: "v1.3.0", to: "v1.4.0", commits: []string{"feat: a", "fix: b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, err := c.Changelog(t.Context(), v(tt.from), v(tt.to))
+			if err != nil {
+				t.Fatal(err)
+			}
+			notes := make([]string, 0)
+			for _, n := range log.Notes {
+				notes = append(notes, n.Tag)
+			}
+			if !slices.Equal(notes, tt.notes) {
+				t.Errorf("Notes = %v, want %v", notes, tt.notes)
+			}
+			if !slices.Equal(log.Commits, tt.commits) {
+				t.Errorf("Commits = %v, want %v", log.Commits, tt.commits)
+			}
+		})
+	}
+	if log, _ := c.Changelog(t.Context(), v("v1.1.0"), v("v1.2.0")); log.Notes[0].Body != "two" {
+		t.Errorf("Body = %q, want trimmed", log.Notes[0].Body)
+	}
+	if !slices.Contains(*requests, "/compare/v1.3.0...v1.4.0") {
+		t.Errorf("compare endpoint not requested: %v", *requests)
+	}
+}
@@ -0,0 +1,137 @@
+package sdk
+
+// fetchClient 基于 fetch 的 client.ts, 注释经 T 翻译
+const fetchClient = `export type HttpMethod = "GET" | "POST" | "PUT" | "PATCH" | "DELETE" | "HEAD" | "OPTIONS"
+
+/** {{ T "请求参数的各个部分, 与API定义中的 Uri/Header/Query/Body 对应" }} */
+export interface RequestParts {
+  uri?: object
+  header?: object
+  query?: object
+  body?: unknown
+}
+
+export type RequestOptions = Omit<RequestInit, "method" | "body">
+
+export interface ClientConfig {
+  /** {{ T "接口地址前缀, 如 https://api.example.com" }} */
+  baseURL: string
+  /** {{ T "每个请求都会带上的请求头, 如 Authorization" }} */
+  headers: Record<string, string>
+  /** {{ T "使用的 fetch 实现, 默认为全局的 fetch" }} */
+  fetch?: typeof fetch
+}
+
+export const config: ClientConfig = { baseURL: "", headers: {} }
+
+/** {{ T "修改请求配置" }} */
+export function configure(c: Partial<ClientConfig>): void {
+  Object.assign(config, c)
+}
+
+/** {{ T "HTTP状态码不是2xx时抛出的错误" }} */
+export class HttpError extends Error {
+  readonly status: number
+  readonly body: string
+
+  constructor(status: number, body: string) {
+    super("HTTP " + status)
+    this.status = status
+    this.body = body
+  }
+}
+
+/** {{ T "编码路径参数" }} */
+export function pathParam(value: unknown): string {
+  return encodeURIComponent(String(value))
+}
+
+/** {{ T "编码通配路径参数, 保留其中的 /" }} */
+export function pathRest(value: unknown): string {
+  return String(value).replace(/^\/+/, "").split("/").map(encodeURIComponent).join("/")
+}
+
+function queryString(query?: object): string {
+  const params = new URLSearchParams()
+  for (const [key, value] of Object.entries(query ?? {})) {
+    for (const v of Array.isArray(value) ? value : [value]) {
+      if (v !== undefined && v !== null) {
+        params.append(key, String(v))
+      }
+    }
+  }
+  const s = params.toString()
+  return s ? "?" + s : ""
+}
+
+/** {{ T "发送请求并解析JSON响应" }} */
+export async function request<R>(method: HttpMethod, path: string, parts: RequestParts, options: RequestOptions = {}): Promise<R> {
+  const headers = new Headers(config.headers)
+  for (const [key, value] of Object.entries(parts.header ?? {})) {
+    if (value !== undefined && value !== null) {
+      headers.set(key, String(value))
+    }
+  }
+  new Headers(options.headers).forEach((value, key) => headers.set(key, value))
+  let body: string | undefined
+  if (parts.body !== undefined) {
+    headers.set("Content-Type", "application/json")
+    body = JSON.stringify(parts.body)
+  }
+  const res = await (config.fetch ?? fetch)(config.baseURL + path + queryString(parts.query), { ...options, method, headers, body })
+  if (!res.ok) {
+    throw new HttpError(res.status, await res.text())
+  }
+  return (await res.json()) as R
+}
+`
+
+// axiosClient 基于 axios 的 client.ts, 注释经 T 翻译
+const axiosClient = `import axios from "axios"
+import type { AxiosInstance, AxiosRequestConfig } from "axios"
+
+export type HttpMethod = "GET" | "POST" | "PUT" | "PATCH" | "DELETE" | "HEAD" | "OPTIONS"
+
+/** {{ T "请求参数的各个部分, 与API定义中的 Uri/Header/Query/Body 对应" }} */
+export interface RequestParts {
+  uri?: object
+  header?: object
+  query?: object
+  body?: unknown
+}
+
+export type RequestOptions = Omit<AxiosRequestConfig, "method" | "url" | "data">
+
+/** {{ T "发送请求使用的 axios 实例, 可通过 setClient 替换为配置了 baseURL 与拦截器的实例" }} */
+export let client: AxiosInstance = axios.create()
+
+/** {{ T "替换发送请求使用的 axios 实例" }} */
+export function setClient(instance: AxiosInstance): void {
+  client = instance
+}
+
+/** {{ T "编码路径参数" }} */
+export function pathParam(value: unknown): string {
+  return encodeURIComponent(String(value))
+}
+
+/** {{ T "编码通配路径参数, 保留其中的 /" }} */
+export function pathRest(value: unknown): string {
+  return String(value).replace(/^\/+/, "").split("/").map(encodeURIComponent).join("/")
+}
+
+/** {{ T "发送请求并返回响应数据" }} */
+export async function request<R>(method: HttpMethod, path: string, parts: RequestParts, options: RequestOptions = {}): Promise<R> {
+  const res = await client.request<R>({
+    // {{ T "数组参数按 ids=1&ids=2 的形式编码, 与gin的绑定规则一致" }}
+    paramsSerializer: { indexes: null },
+    ...options,
+    method,
+    url: path,
+    params: { ...(parts.query as Record<string, unknown>), ...options.params },
+    headers: { ...(parts.header as Record<string, string>), ...options.headers },
+    data: parts.body,
+  })
+  return res.data
+}
+`
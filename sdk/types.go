@@ -0,0 +1,318 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/zjutjh/gbc/analysis"
+)
+
+// identPattern 可以不加引号作为属性名的标识符
+var identPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// reservedWords 不能作为导出名称的TypeScript保留字
+var reservedWords = []string{
+	"break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete", "do",
+	"else", "enum", "export", "extends", "false", "finally", "for", "function", "if", "import",
+	"in", "instanceof", "new", "null", "return", "super", "switch", "this", "throw", "true",
+	"try", "typeof", "var", "void", "while", "with", "implements", "interface", "let", "package",
+	"private", "protected", "public", "static", "yield", "await", "any", "unknown", "never",
+	"string", "number", "boolean", "object", "symbol", "bigint", "undefined",
+}
+
+// tsField 接口中的一个属性
+type tsField struct {
+	Name     string
+	Type     string
+	Optional bool
+}
+
+// tsTypes 将Go类型转换为TypeScript类型, 命名的结构体类型声明为同名interface
+// 生成的全部导出名称共用一个命名空间, 以便 index.ts 统一导出时不会冲突
+type tsTypes struct {
+	used     map[string]bool
+	declared typeutil.Map // *types.Named -> interface名称
+	decls    []string
+}
+
+func newTSTypes(reserved ...string) *tsTypes {
+	g := &tsTypes{used: make(map[string]bool)}
+	for _, name := range slices.Concat(reservedWords, reserved) {
+		g.used[name] = true
+	}
+	return g
+}
+
+func (g *tsTypes) reserve(candidates ...string) string {
+	return reserve(g.used, candidates...)
+}
+
+// reserve 依次尝试candidates中未被使用的名称, 均已被使用时在最后一个名称后添加数字
+func reserve(used map[string]bool, candidates ...string) string {
+	for _, name := range candidates {
+		if !used[name] {
+			used[name] = true
+			return name
+		}
+	}
+	last := candidates[len(candidates)-1]
+	for i := 2; ; i++ {
+		name := fmt.Sprintf("%s%d", last, i)
+		if !used[name] {
+			used[name] = true
+			return name
+		}
+	}
+}
+
+// typeOf Go类型对应的TypeScript类型, 规则与 encoding/json 的序列化结果一致
+func (g *tsTypes) typeOf(t types.Type) string {
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		if s, ok := specialType(named); ok {
+			return s
+		}
+		if _, ok := named.Underlying().(*types.Struct); ok {
+			return g.declare(named)
+		}
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return basicType(u)
+	case *types.Pointer:
+		elem := g.typeOf(u.Elem())
+		if strings.HasSuffix(elem, " | null") {
+			return elem
+		}
+		return elem + " | null"
+	case *types.Slice:
+		if b, ok := u.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Byte {
+			// []byte 序列化为base64字符串
+			return "string"
+		}
+		return arrayOf(g.typeOf(u.Elem()))
+	case *types.Array:
+		return arrayOf(g.typeOf(u.Elem()))
+	case *types.Map:
+		return "Record<string, " + g.typeOf(u.Elem()) + ">"
+	case *types.Struct:
+		return inline(g.fields(u, "json", false))
+	}
+	// 接口、函数、通道等无法确定序列化结果
+	return "unknown"
+}
+
+// specialType 序列化结果与结构无关的类型
+func specialType(named *types.Named) (string, bool) {
+	if obj := named.Obj(); obj.Pkg() != nil {
+		switch obj.Pkg().Path() + "." + obj.Name() {
+		case "time.Time":
+			return "string", true
+		case "time.Duration", "encoding/json.Number":
+			return "number", true
+		}
+	}
+	if analysis.HasMethod(named, "MarshalJSON") {
+		return "unknown", true
+	}
+	if analysis.HasMethod(named, "MarshalText") {
+		return "string", true
+	}
+	return "", false
+}
+
+func basicType(b *types.Basic) string {
+	info := b.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return "boolean"
+	case info&types.IsString != 0:
+		return "string"
+	case info&(types.IsInteger|types.IsFloat) != 0:
+		return "number"
+	}
+	return "unknown"
+}
+
+func arrayOf(elem string) string {
+	if strings.Contains(elem, "|") {
+		return "(" + elem + ")[]"
+	}
+	return elem + "[]"
+}
+
+// declare 将命名结构体类型声明为interface, 泛型类型的实例按类型实参命名, 如 Page[User] -> PageUser
+func (g *tsTypes) declare(named *types.Named) string {
+	if name, ok := g.declared.At(named).(string); ok {
+		return name
+	}
+	name := named.Obj().Name()
+	for arg := range named.TypeArgs().Types() {
+		name += typeArgName(g.typeOf(arg))
+	}
+	candidates := []string{name}
+	if pkg := named.Obj().Pkg(); pkg != nil {
+		candidates = append(candidates, upperFirst(pkg.Name())+name)
+	}
+	name = g.reserve(candidates...)
+	g.declared.Set(named, name)
+	// 先占位再生成属性, 使递归引用能够找到名称, 并让外层类型声明在前
+	g.decls = append(g.decls, "")
+	i := len(g.decls) - 1
+	g.decls[i] = declaration(name, g.fields(named.Underlying().(*types.Struct), "json", false))
+	return name
+}
+
+// declareAs 以name声明属性为fields的interface, 用于请求参数中按 form/uri/header 标签命名属性的结构体
+func (g *tsTypes) declareAs(name string, fields []tsField) {
+	g.decls = append(g.decls, declaration(name, fields))
+}
+
+// pkgPrefix 名称冲突时使用的包名前缀, 如 app/api/user-center -> UserCenter
+func pkgPrefix(pkgPath string) string {
+	return typeArgName(path.Base(pkgPath))
+}
+
+// typeArgName 将类型实参转换为可以拼接在类型名称中的部分
+func typeArgName(s string) string {
+	b := strings.Builder{}
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		b.WriteString(upperFirst(w))
+	}
+	return b.String()
+}
+
+// fields 结构体的属性, tagKey为决定属性名的标签, 如 json、form; 匿名嵌入的结构体按 encoding/json 的规则展开
+// request为true时按 binding:"required" 判断属性是否必填, 否则按 omitempty 判断
+func (g *tsTypes) fields(st *types.Struct, tagKey string, request bool) []tsField {
+	res := make([]tsField, 0, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i))
+		if tag.Get(tagKey) == "-" {
+			continue
+		}
+		key, opts, _ := strings.Cut(tag.Get(tagKey), ",")
+		options := strings.Split(opts, ",")
+		if f.Embedded() && key == "" {
+			typ := f.Type()
+			if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+				typ = ptr.Elem()
+			}
+			if embedded, ok := typ.Underlying().(*types.Struct); ok {
+				res = append(res, g.fields(embedded, tagKey, request)...)
+				continue
+			}
+		}
+		if !f.Exported() {
+			continue
+		}
+		if key == "" {
+			key = f.Name()
+		}
+		field := tsField{Name: key, Type: g.typeOf(f.Type())}
+		if tagKey == "json" && slices.Contains(options, "string") {
+			field.Type = "string"
+		}
+		if request {
+			field.Optional = !slices.Contains(strings.Split(tag.Get("binding"), ","), "required")
+		} else {
+			field.Optional = slices.Contains(options, "omitempty") || slices.Contains(options, "omitzero")
+		}
+		res = append(res, field)
+	}
+	return res
+}
+
+func declaration(name string, fields []tsField) string {
+	if len(fields) == 0 {
+		return "export interface " + name + " {}\n"
+	}
+	b := strings.Builder{}
+	b.WriteString("export interface " + name + " {\n")
+	for _, f := range fields {
+		b.WriteString("  " + f.String() + "\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// inline 匿名结构体对应的对象类型
+func inline(fields []tsField) string {
+	if len(fields) == 0 {
+		return "Record<string, never>"
+	}
+	items := make([]string, 0, len(fields))
+	for _, f := range fields {
+		items = append(items, f.String())
+	}
+	return "{ " + strings.Join(items, "; ") + " }"
+}
+
+func (f tsField) String() string {
+	name := propertyName(f.Name)
+	if f.Optional {
+		name += "?"
+	}
+	return name + ": " + f.Type
+}
+
+// propertyName 不是标识符的属性名需要加引号, 如 X-Token
+func propertyName(name string) string {
+	if identPattern.MatchString(name) {
+		return name
+	}
+	return quote(name)
+}
+
+// property 访问对象属性的表达式
+func property(obj, name string) string {
+	if identPattern.MatchString(name) {
+		return obj + "." + name
+	}
+	return obj + "[" + quote(name) + "]"
+}
+
+// quote TypeScript字符串字面量
+func quote(s string) string {
+	b := strings.Builder{}
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	rs := []rune(s)
+	return string(unicode.ToUpper(rs[0])) + string(rs[1:])
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	rs := []rune(s)
+	// 以缩写开头时整体小写, 如 URLInfo -> urlInfo
+	i := 0
+	for i < len(rs) && unicode.IsUpper(rs[i]) {
+		i++
+	}
+	if i > 1 && i < len(rs) {
+		i--
+	}
+	for j := 0; j < i || j == 0; j++ {
+		rs[j] = unicode.ToLower(rs[j])
+	}
+	return string(rs)
+}
@@ -0,0 +1,493 @@
+// Package sdk 根据项目中的API定义与路由注册生成前端SDK
+package sdk
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/types"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// Generator 写入生成文件头部的生成器名称
+const Generator = "gbc sdk ts"
+
+const (
+	ClientFetch = "fetch"
+	ClientAxios = "axios"
+)
+
+// Clients 生成的SDK可以使用的请求库
+var Clients = []string{ClientFetch, ClientAxios}
+
+// clientExports client.ts 与 codes.ts 中的固定导出名称
+var clientExports = []string{
+	"HttpMethod", "RequestParts", "RequestOptions", "ClientConfig", "HttpError", "config", "configure",
+	"client", "setClient", "request", "pathParam", "pathRest",
+	"BusinessCode", "BusinessCodeMessage", "Success", "Failure", "ApiResult", "isSuccess",
+}
+
+// requestParts API请求参数的部分与绑定时使用的标签
+var requestParts = []struct {
+	Field string
+	Key   string
+	Tag   string
+}{
+	{Field: "Uri", Key: "uri", Tag: "uri"},
+	{Field: "Header", Key: "header", Tag: "header"},
+	{Field: "Query", Key: "query", Tag: "form"},
+	{Field: "Body", Key: "body", Tag: "json"},
+}
+
+// identRef 类型表达式中引用的名称
+var identRef = regexp.MustCompile(`[A-Za-z_$][A-Za-z0-9_$]*`)
+
+// requestType API请求参数生成的类型
+type requestType struct {
+	Name     string
+	Optional bool            // 全部属性可选, 调用时可以省略
+	Uri      map[string]bool // Uri部分的属性, 用于替换路径参数
+}
+
+// typeScript 生成TypeScript SDK的过程状态
+type typeScript struct {
+	*tsTypes
+	client   string
+	members  map[string]string // 业务状态码 包路径.变量名 -> 枚举成员名
+	ok       string            // 成功状态码的类型
+	requests map[*analysis.APIEntry]*requestType
+	unions   map[*analysis.APIEntry]string
+	enum     string   // codes.ts 中的枚举与响应类型
+	codeDecl []string // codes.ts 中每个接口的业务状态码联合类型
+	funcs    []string
+	code     []string // 去掉注释的函数, 用于确定 api.ts 需要导入的名称
+}
+
+// TypeScript 根据路由生成TypeScript SDK, 返回文件名到内容的映射
+// routes中的Codes用于生成每个接口可能返回的业务状态码联合类型, codes为项目中的全部业务状态码
+func TypeScript(routes []analysis.Route, codes []analysis.CodeEntry, client string) (map[string][]byte, error) {
+	if !slices.Contains(Clients, client) {
+		return nil, i18n.Errorf("不支持的请求库[%s], 可选的值有: %s", client, strings.Join(Clients, ", "))
+	}
+	g := &typeScript{
+		tsTypes:  newTSTypes(clientExports...),
+		client:   client,
+		requests: make(map[*analysis.APIEntry]*requestType),
+		unions:   make(map[*analysis.APIEntry]string),
+	}
+	g.codes(codes)
+	// Any 路由放在最后, 使API注册在多条路由上时由指定了方法的路由使用API的名称
+	routes = slices.Clone(routes)
+	slices.SortStableFunc(routes, func(a, b analysis.Route) int {
+		return cmp.Compare(boolInt(a.Method == "Any"), boolInt(b.Method == "Any"))
+	})
+	for _, r := range routes {
+		g.route(r)
+	}
+
+	clientFile, err := g.clientFile()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		"client.ts": clientFile,
+		"codes.ts":  g.codesFile(),
+		"types.ts":  g.typesFile(),
+		"api.ts":    g.apiFile(),
+		"index.ts":  []byte(header() + "export * from \"./client\"\nexport * from \"./codes\"\nexport * from \"./types\"\nexport * from \"./api\"\n"),
+	}, nil
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func header() string {
+	return fmt.Sprintf("// Code generated by %q. DO NOT EDIT.\n/* eslint-disable */\n\n", Generator)
+}
+
+// codes 生成 BusinessCode 枚举及提示信息, 变量名去掉Code前缀作为枚举成员名, 如 CodeUserNotFound -> UserNotFound
+func (g *typeScript) codes(codes []analysis.CodeEntry) {
+	g.members = make(map[string]string, len(codes))
+	used := make(map[string]bool)
+	b := strings.Builder{}
+	b.WriteString(header())
+	b.WriteString("/** " + i18n.T("业务状态码") + " */\nexport enum BusinessCode {\n")
+	for _, c := range codes {
+		name := c.Var
+		if s, ok := strings.CutPrefix(name, "Code"); ok && s != "" && identPattern.MatchString(s) && strings.ToUpper(s[:1]) == s[:1] {
+			name = s
+		}
+		name = reserve(used, name, pkgPrefix(c.Package)+name)
+		g.members[c.Package+"."+c.Var] = name
+		if c.Code == 0 && g.ok == "" {
+			g.ok = "BusinessCode." + name
+		}
+		if c.Message != "" {
+			b.WriteString("  /** " + comment(c.Message) + " */\n")
+		}
+		fmt.Fprintf(&b, "  %s = %d,\n", name, c.Code)
+	}
+	b.WriteString("}\n\n")
+	if g.ok == "" {
+		g.ok = "0"
+	}
+
+	b.WriteString("/** " + i18n.T("业务状态码对应的提示信息") + " */\nexport const BusinessCodeMessage: Readonly<Record<number, string>> = {\n")
+	seen := make(map[int64]bool)
+	for _, c := range codes {
+		if seen[c.Code] {
+			continue
+		}
+		seen[c.Code] = true
+		fmt.Fprintf(&b, "  [BusinessCode.%s]: %s,\n", g.members[c.Package+"."+c.Var], quote(c.Message))
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, `/** %[1]s */
+export interface Success<T> {
+  code: %[3]s
+  message: string
+  data: T
+}
+
+/** %[2]s */
+export interface Failure<C extends BusinessCode = BusinessCode> {
+  code: C
+  message: string
+  data: null
+}
+
+/** %[4]s */
+export type ApiResult<T, C extends BusinessCode = BusinessCode> = Success<T> | Failure<Exclude<C, %[3]s>>
+
+/** %[5]s */
+export function isSuccess<T, C extends BusinessCode>(res: ApiResult<T, C>): res is Success<T> {
+  return res.code === %[3]s
+}
+`, i18n.T("请求成功时的响应"), i18n.T("请求失败时的响应, code为接口可能返回的业务状态码"), g.ok,
+		i18n.T("接口的响应, 判断code后即可得到data或失败的业务状态码"), i18n.T("响应是否成功"))
+	g.enum = b.String()
+}
+
+func (g *typeScript) codesFile() []byte {
+	b := strings.Builder{}
+	b.WriteString(g.enum)
+	for _, decl := range g.codeDecl {
+		b.WriteString("\n" + decl)
+	}
+	return []byte(b.String())
+}
+
+// route 生成一条路由的请求函数
+func (g *typeScript) route(r analysis.Route) {
+	api := r.API
+	candidates := make([]string, 0, 2)
+	if api != nil {
+		name := lowerFirst(strings.TrimSuffix(api.Type, "Api"))
+		if _, ok := g.unions[api]; ok {
+			// 同一个API注册在多条路由上时, 之后的路由按方法区分, 如 loginAny
+			name += typeArgName(strings.ToLower(r.Method))
+		}
+		candidates = append(candidates, name, lowerFirst(pkgPrefix(api.Package))+upperFirst(name))
+	} else {
+		candidates = append(candidates, routeFuncName(r))
+	}
+	fn := g.reserve(candidates...)
+
+	params := make([]string, 0, 4)
+	if r.Method == "Any" {
+		params = append(params, "method: HttpMethod")
+	}
+
+	var req *requestType
+	response, union := "unknown", "BusinessCode"
+	if api != nil {
+		req = g.request(api)
+		if t := api.FieldType("Response"); t != nil {
+			response = g.typeOf(t)
+		}
+		union = g.union(api, fn, r.Codes)
+	}
+
+	// 路径参数优先取自请求参数的Uri部分, 否则作为单独的参数
+	missing := make([]string, 0)
+	segs := strings.Split(r.Path, "/")
+	for i, seg := range segs {
+		if len(seg) < 2 || (seg[0] != ':' && seg[0] != '*') {
+			segs[i] = escapeTemplate(seg)
+			continue
+		}
+		name := seg[1:]
+		value := property("params", name)
+		if req != nil && req.Uri[name] {
+			value = property(property("req", "uri"), name)
+		} else {
+			missing = append(missing, name)
+		}
+		helper := "pathParam"
+		if seg[0] == '*' {
+			helper = "pathRest"
+		}
+		segs[i] = "${" + helper + "(" + value + ")}"
+	}
+	if len(missing) > 0 {
+		fields := make([]tsField, 0, len(missing))
+		for _, name := range missing {
+			fields = append(fields, tsField{Name: name, Type: "string | number"})
+		}
+		params = append(params, "params: "+inline(fields))
+	}
+	parts := "{}"
+	if req != nil {
+		parts = "req"
+		if req.Optional {
+			params = append(params, "req: "+req.Name+" = {}")
+		} else {
+			params = append(params, "req: "+req.Name)
+		}
+	}
+	params = append(params, "options?: RequestOptions")
+
+	method := quote(r.Method)
+	if r.Method == "Any" {
+		method = "method"
+	}
+	result := "ApiResult<" + response + ", " + union + ">"
+
+	code := fmt.Sprintf("export function %s(%s): Promise<%s> {\n", fn, strings.Join(params, ", "), result) +
+		fmt.Sprintf("  return request<%s>(%s, `%s`, %s, options)\n}\n", result, method, strings.Join(segs, "/"), parts)
+	g.code = append(g.code, code)
+
+	b := strings.Builder{}
+	b.WriteString("/**\n")
+	if api != nil && api.Name != "" {
+		b.WriteString(" * " + comment(api.Name) + "\n *\n")
+	}
+	if api != nil && api.Desc != "" && api.Desc != api.Name {
+		b.WriteString(" * " + comment(api.Desc) + "\n *\n")
+	}
+	b.WriteString(" * " + comment(r.Method+" "+r.Path) + "\n */\n")
+	b.WriteString(code)
+	g.funcs = append(g.funcs, b.String())
+}
+
+// routeFuncName 不是由API注册的路由按方法与路径命名, 如 GET /ping -> getPing
+func routeFuncName(r analysis.Route) string {
+	name := strings.ToLower(r.Method)
+	if r.Method == "Any" {
+		name = "request"
+	}
+	if words := typeArgName(r.Path); words != "" {
+		return name + words
+	}
+	return name + "Index"
+}
+
+// request 声明API请求参数的类型, 每个部分按gin绑定时使用的标签命名属性
+func (g *typeScript) request(api *analysis.APIEntry) *requestType {
+	if req, ok := g.requests[api]; ok {
+		return req
+	}
+	name := api.Type + "Request"
+	t := api.FieldType("Request")
+	if t == nil {
+		g.requests[api] = nil
+		return nil
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		name = named.Obj().Name()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		g.requests[api] = nil
+		return nil
+	}
+	req := &requestType{Name: g.reserve(name, pkgPrefix(api.Package)+name), Optional: true, Uri: make(map[string]bool)}
+	// 先占位, 使请求参数的声明位于各部分之前
+	g.decls = append(g.decls, "")
+	i := len(g.decls) - 1
+	fields := make([]tsField, 0)
+	for _, part := range requestParts {
+		var partType types.Type
+		for j := 0; j < st.NumFields(); j++ {
+			if st.Field(j).Name() == part.Field {
+				partType = st.Field(j).Type()
+			}
+		}
+		if partType == nil {
+			continue
+		}
+		field := tsField{Name: part.Key}
+		partStruct, ok := partType.Underlying().(*types.Struct)
+		if !ok {
+			field.Type = g.typeOf(partType)
+			fields = append(fields, field)
+			req.Optional = false
+			continue
+		}
+		partFields := g.fields(partStruct, part.Tag, true)
+		if len(partFields) == 0 {
+			// 模板生成的空结构体不需要传递
+			continue
+		}
+		field.Optional = true
+		for k := range partFields {
+			if part.Field == "Uri" {
+				// 路径参数总是存在
+				partFields[k].Optional = false
+				req.Uri[partFields[k].Name] = true
+			}
+			if !partFields[k].Optional {
+				field.Optional = false
+			}
+		}
+		req.Optional = req.Optional && field.Optional
+		partName := req.Name + part.Field
+		field.Type = g.reserve(partName, pkgPrefix(api.Package)+partName)
+		g.declareAs(field.Type, partFields)
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		// 没有请求参数时请求函数不需要req参数
+		g.decls = slices.Delete(g.decls, i, i+1)
+		g.requests[api] = nil
+		return nil
+	}
+	g.decls[i] = declaration(req.Name, fields)
+	g.requests[api] = req
+	return req
+}
+
+// union 声明API可能返回的业务状态码的联合类型, 如 type LoginCode = BusinessCode.OK | BusinessCode.ParameterInvalid
+func (g *typeScript) union(api *analysis.APIEntry, fn string, codes []analysis.CodeEntry) string {
+	if name, ok := g.unions[api]; ok {
+		return name
+	}
+	members := make([]string, 0, len(codes))
+	for _, c := range codes {
+		if m, ok := g.members[c.Package+"."+c.Var]; ok && !slices.Contains(members, "BusinessCode."+m) {
+			members = append(members, "BusinessCode."+m)
+		}
+	}
+	name := "BusinessCode"
+	if len(members) > 0 {
+		name = g.reserve(upperFirst(fn) + "Code")
+		g.codeDecl = append(g.codeDecl, fmt.Sprintf("/** %s */\nexport type %s = %s\n", i18n.Sprintf("%s 可能返回的业务状态码", fn), name, strings.Join(members, " | ")))
+	}
+	g.unions[api] = name
+	return name
+}
+
+func (g *typeScript) typesFile() []byte {
+	b := strings.Builder{}
+	b.WriteString(header())
+	if len(g.decls) == 0 {
+		b.WriteString("export {}\n")
+	}
+	for i, decl := range g.decls {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(decl)
+	}
+	return []byte(b.String())
+}
+
+func (g *typeScript) apiFile() []byte {
+	// 只导入用到的名称, 避免开启 noUnusedLocals 的项目报错
+	used := make(map[string]bool)
+	for _, ident := range identRef.FindAllString(strings.Join(g.code, ""), -1) {
+		used[ident] = true
+	}
+	declared := make([]string, 0)
+	g.declared.Iterate(func(_ types.Type, name any) {
+		declared = append(declared, name.(string))
+	})
+	for _, req := range g.requests {
+		if req != nil {
+			declared = append(declared, req.Name)
+		}
+	}
+	typeNames := filterUsed(declared, used)
+	codeNames := filterUsed(append([]string{"ApiResult", "BusinessCode"}, g.unionNames()...), used)
+	clientValues := filterUsed([]string{"pathParam", "pathRest", "request"}, used)
+	clientTypes := filterUsed([]string{"HttpMethod", "RequestOptions"}, used)
+
+	b := strings.Builder{}
+	b.WriteString(header())
+	if len(clientValues) > 0 {
+		b.WriteString("import { " + strings.Join(clientValues, ", ") + " } from \"./client\"\n")
+	}
+	if len(clientTypes) > 0 {
+		b.WriteString("import type { " + strings.Join(clientTypes, ", ") + " } from \"./client\"\n")
+	}
+	if len(codeNames) > 0 {
+		b.WriteString("import type { " + strings.Join(codeNames, ", ") + " } from \"./codes\"\n")
+	}
+	if len(typeNames) > 0 {
+		b.WriteString("import type { " + strings.Join(typeNames, ", ") + " } from \"./types\"\n")
+	}
+	b.WriteString("\n" + strings.Join(g.funcs, "\n"))
+	return []byte(b.String())
+}
+
+func (g *typeScript) unionNames() []string {
+	names := make([]string, 0, len(g.unions))
+	for _, name := range g.unions {
+		if name != "BusinessCode" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// filterUsed names中在used里出现过的名称, 排序去重
+func filterUsed(names []string, used map[string]bool) []string {
+	res := make([]string, 0, len(names))
+	for _, name := range names {
+		if used[name] {
+			res = append(res, name)
+		}
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+// clientFile 生成封装请求库的 client.ts
+func (g *typeScript) clientFile() ([]byte, error) {
+	src := fetchClient
+	if g.client == ClientAxios {
+		src = axiosClient
+	}
+	tmpl, err := template.New("client").Funcs(template.FuncMap{"T": i18n.T}).Parse(src)
+	if err != nil {
+		return nil, i18n.Errorf("解析模板失败: %w", err)
+	}
+	buf := bytes.Buffer{}
+	buf.WriteString(header())
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return nil, i18n.Errorf("生成文件失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// comment 可以放在块注释中的文本
+func comment(s string) string {
+	s = strings.ReplaceAll(s, "*/", "*\\/")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// escapeTemplate 转义模板字符串中的特殊字符
+func escapeTemplate(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "`", "\\`", "${", "\\${").Replace(s)
+}
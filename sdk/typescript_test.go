@@ -0,0 +1,143 @@
+package sdk
+
+import (
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zjutjh/gbc/analysis"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+var update = flag.Bool("update", false, "用生成结果更新 testdata 中的golden文件")
+
+var sources = map[string]string{
+	"app/api/user": `package user
+
+type LoginApi struct {
+	Info     struct{} ` + "`name:\"登录\" desc:\"用户登录\"`" + `
+	Request  LoginApiRequest
+	Response LoginApiResponse
+}
+
+type LoginApiRequest struct {
+	Uri struct {
+		ID string ` + "`uri:\"id\"`" + `
+	}
+	Header struct {
+		Token string ` + "`header:\"X-Token\" binding:\"required\"`" + `
+	}
+	Body struct {
+		Username string ` + "`json:\"username\" binding:\"required\"`" + `
+		Remember bool   ` + "`json:\"remember\"`" + `
+	}
+}
+
+type LoginApiResponse struct {
+	Token  string ` + "`json:\"token\"`" + `
+	Expire int64  ` + "`json:\"expire,omitempty\"`" + `
+}
+
+type FileApi struct {
+	Info    struct{} ` + "`name:\"下载文件\" desc:\"下载文件\"`" + `
+	Request struct {
+		Uri struct {
+			Path string ` + "`uri:\"path\"`" + `
+		}
+	}
+}
+`,
+	"app/api/admin": `package admin
+
+type LoginApi struct {
+	Info    struct{} ` + "`name:\"管理员登录\"`" + `
+	Request LoginApiRequest
+}
+
+type LoginApiRequest struct {
+	Query struct {
+		Code string ` + "`form:\"code\"`" + `
+	}
+}
+`,
+}
+
+// loadAPIs 类型检查sources中的包, 返回 包路径.类型名 -> API
+func loadAPIs(t *testing.T) map[string]*analysis.APIEntry {
+	t.Helper()
+	apis := make(map[string]*analysis.APIEntry)
+	for path, src := range sources {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, filepath.Base(path)+".go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg, err := (&types.Config{}).Check(path, fset, []*ast.File{f}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range pkg.Scope().Names() {
+			if obj, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok {
+				if api, ok := analysis.NewAPIEntry(obj); ok {
+					apis[path+"."+name] = &api
+				}
+			}
+		}
+	}
+	return apis
+}
+
+func TestTypeScript(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	i18n.SetLocale(i18n.ZhCN)
+
+	apis := loadAPIs(t)
+	ok := analysis.CodeEntry{Package: "app/comm", Var: "CodeOK", Code: 0, Message: "成功"}
+	userNotFound := analysis.CodeEntry{Package: "app/comm", Var: "CodeUserNotFound", Code: 10001, Message: "用户不存在"}
+	adminNotFound := analysis.CodeEntry{Package: "app/api/admin", Var: "CodeUserNotFound", Code: 20001, Message: "管理员不存在"}
+	codes := []analysis.CodeEntry{
+		ok,
+		userNotFound,
+		// 与comm中的变量同名, 枚举成员加上包名前缀
+		adminNotFound,
+		// 与 CodeUserNotFound 取值相同, 提示信息只保留第一个
+		{Package: "app/comm", Var: "CodeUserMissing", Code: 10001, Message: "用户不存在"},
+		// 去掉Code前缀后为空, 保留原名
+		{Package: "app/comm", Var: "Code", Code: 1, Message: "未知错误 */"},
+	}
+	routes := []analysis.Route{
+		{Method: "Any", Path: "/user/login/:id", API: apis["app/api/user.LoginApi"], Codes: []analysis.CodeEntry{ok, userNotFound}},
+		{Method: "POST", Path: "/user/login/:id", API: apis["app/api/user.LoginApi"], Codes: []analysis.CodeEntry{ok, userNotFound}},
+		{Method: "POST", Path: "/admin/login", API: apis["app/api/admin.LoginApi"], Codes: []analysis.CodeEntry{adminNotFound}},
+		{Method: "GET", Path: "/user/files/*path", API: apis["app/api/user.FileApi"]},
+		{Method: "GET", Path: "/static/:dir/*filepath"},
+		{Method: "GET", Path: "/ping"},
+	}
+
+	files, err := TypeScript(routes, codes, ClientFetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// client.ts 来自固定的模板, 不作比较
+	for _, name := range []string{"codes.ts", "types.ts", "api.ts", "index.ts"} {
+		golden := filepath.Join("testdata", name+".golden")
+		if *update {
+			if err := os.WriteFile(golden, files[name], 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(files[name]) != string(want) {
+			t.Errorf("%s =\n%s\nwant\n%s", name, files[name], want)
+		}
+	}
+}
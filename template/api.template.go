@@ -1,5 +1,11 @@
 package template
 
+// API Info字段的默认标签, 由 gbc todo 识别尚未修改的API
+const (
+	APIDefaultName = "API名称"
+	APIDefaultDesc = "API描述"
+)
+
 var APITemplate = `package {$PackageName}
 
 import (
@@ -12,7 +18,7 @@ import (
 	"github.com/zjutjh/mygo/nlog"
 	"github.com/zjutjh/mygo/swagger"
 
-	"app/comm"
+	"{$ModulePath}/comm"
 )
 
 // {$ApiStruct}Handler API router注册点
@@ -31,12 +37,12 @@ type {$ApiStruct}Api struct {
 type {$ApiStruct}ApiRequest struct {{$RequestUri}{$RequestHeader}{$RequestQuery}{$RequestBody}
 }
 
-type {$ApiStruct}ApiResponse struct {}
+type {$ApiStruct}ApiResponse struct {{$ResponseFields}}
 
 // Run Api业务逻辑执行点
 func ({$Receiver} *{$ApiStruct}Api) Run(ctx *gin.Context) kit.Code {
 	// TODO: 在此处编写接口业务逻辑
-	return comm.CodeOK
+{$RunCodes}	return comm.CodeOK
 }
 
 // Init Api初始化 进行参数校验和绑定
@@ -0,0 +1,41 @@
+package template
+
+import (
+	"go/scanner"
+	"go/token"
+	"strings"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// Localize 将模板中的行注释翻译为当前语言, 字符串字面量中的 // 不受影响
+func Localize(src string) string {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	s := scanner.Scanner{}
+	// 模板中的占位符不是合法的Go代码, 忽略扫描错误
+	s.Init(file, []byte(src), func(token.Position, string) {}, scanner.ScanComments)
+
+	b := strings.Builder{}
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok != token.COMMENT || !strings.HasPrefix(lit, "//") {
+			continue
+		}
+		text := strings.TrimLeft(lit[2:], " \t")
+		if text == "" {
+			continue
+		}
+		offset := file.Offset(pos)
+		start := offset + len(lit) - len(text)
+		b.WriteString(src[last:start])
+		b.WriteString(i18n.T(text))
+		last = offset + len(lit)
+	}
+	b.WriteString(src[last:])
+	return b.String()
+}
@@ -0,0 +1,53 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/zjutjh/gbc/i18n"
+)
+
+func TestLocalize(t *testing.T) {
+	defer i18n.SetLocale(i18n.Current())
+	i18n.SetLocale(i18n.En)
+
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "line comment",
+			src:  "func Run() {\n\t// TODO: 在此处编写命令业务逻辑\n}\n",
+			want: "func Run() {\n\t// TODO: write the command business logic here\n}\n",
+		},
+		{
+			name: "trailing comment",
+			src:  "type A struct {\n\tRequest {$ApiStruct}ApiRequest // API请求参数 (Uri/Header/Query/Body)\n}\n",
+			want: "type A struct {\n\tRequest {$ApiStruct}ApiRequest // API request parameters (Uri/Header/Query/Body)\n}\n",
+		},
+		{
+			name: "string literals",
+			src:  "var url = \"https://example.com // TODO: 在此处编写命令业务逻辑\"\nvar raw = `// TODO: 在此处编写命令业务逻辑`\n",
+			want: "var url = \"https://example.com // TODO: 在此处编写命令业务逻辑\"\nvar raw = `// TODO: 在此处编写命令业务逻辑`\n",
+		},
+		{
+			name: "block and empty comments",
+			src:  "/* TODO: 在此处编写命令业务逻辑 */\n//\nvar a = 1 //  TODO: 在此处编写命令业务逻辑\n",
+			want: "/* TODO: 在此处编写命令业务逻辑 */\n//\nvar a = 1 //  TODO: write the command business logic here\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Localize(tt.src); got != tt.want {
+				t.Errorf("Localize() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	// 内置模板中的注释均可被翻译
+	for name, src := range map[string]string{"api": APITemplate, "cmd": CMDTemplate, "cron": CronTemplate} {
+		if got := Localize(src); got == src {
+			t.Errorf("%s template not localized", name)
+		}
+	}
+}
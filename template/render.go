@@ -0,0 +1,108 @@
+package template
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// APIField API请求参数或响应数据中的一个字段
+type APIField struct {
+	Name     string // Go字段名, 如 UserID
+	Type     string // Go类型, 如 string、[]int64
+	Param    string // 参数名称, 写入标签, 如 user_id
+	Required bool   // 是否添加 binding:"required" 标签
+}
+
+// APISpec 渲染API模板所需的信息
+type APISpec struct {
+	PackageName string
+	Struct      string // API名称, 如 Login
+	ModulePath  string
+	// Request 启用的请求参数部分及其字段, 键为 Uri、Header、Query、Body
+	Request  map[string][]APIField
+	Response []APIField
+	// Codes Run中提示可能返回的业务状态码
+	Codes []APICode
+}
+
+// APICode 项目中声明的业务状态码
+type APICode struct {
+	Ref     string // 引用方式, 如 comm.CodeUserNotFound
+	Code    int64
+	Message string
+}
+
+// requestParts 请求参数部分的模板占位符、标签名称与绑定方法, 顺序与模板保持一致
+var requestParts = []struct {
+	Name   string
+	Tag    string
+	Method string
+}{
+	{"Uri", "uri", "ShouldBindUri"},
+	{"Header", "header", "ShouldBindHeader"},
+	{"Query", "form", "ShouldBindQuery"},
+	{"Body", "json", "ShouldBindJSON"},
+}
+
+// RenderAPI 按spec渲染API模板, 返回格式化后的源码
+func RenderAPI(spec APISpec) ([]byte, error) {
+	src := Localize(APITemplate)
+	for _, part := range requestParts {
+		fields, ok := spec.Request[part.Name]
+		if !ok {
+			src = strings.Replace(src, "{$Request"+part.Name+"}", "", 1)
+			src = strings.Replace(src, "{$Request"+part.Name+"Init}", "", 1)
+			continue
+		}
+		src = strings.Replace(src, "{$Request"+part.Name+"}", "\n\t"+part.Name+" struct {"+fieldsSource(fields, part.Tag, "\t\t", "\n\t")+"}", 1)
+		src = strings.Replace(src, "{$Request"+part.Name+"Init}", "\n\terr = ctx."+part.Method+"(&{$Receiver}.Request."+part.Name+")\n\tif err != nil {\n\t\treturn err\n\t}", 1)
+	}
+	src = strings.ReplaceAll(src, "{$ResponseFields}", fieldsSource(spec.Response, "json", "\t", "\n"))
+	src = strings.ReplaceAll(src, "{$RunCodes}", codesSource(spec.Codes))
+
+	src = strings.ReplaceAll(src, "{$PackageName}", spec.PackageName)
+	src = strings.ReplaceAll(src, "{$ApiStruct}", spec.Struct)
+	src = strings.ReplaceAll(src, "{$Receiver}", comm.ReceiverName(spec.Struct))
+	src = strings.ReplaceAll(src, "{$ModulePath}", spec.ModulePath)
+	src = strings.ReplaceAll(src, "{$ApiInfo}", fmt.Sprintf("Info     struct{}        `name:%q desc:%q`", APIDefaultName, APIDefaultDesc))
+
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return []byte(src), i18n.Errorf("格式化代码失败: %w", err)
+	}
+	return formatted, nil
+}
+
+// fieldsSource 结构体字段声明, 没有字段时返回空字符串
+func fieldsSource(fields []APIField, tag, indent, end string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	b := strings.Builder{}
+	for _, f := range fields {
+		fmt.Fprintf(&b, "\n%s%s %s `%s:%q", indent, f.Name, f.Type, tag, f.Param)
+		if f.Required {
+			b.WriteString(` binding:"required"`)
+		}
+		b.WriteString("`")
+	}
+	b.WriteString(end)
+	return b.String()
+}
+
+// codesSource Run中列出可能返回的业务状态码的注释
+func codesSource(codes []APICode) string {
+	if len(codes) == 0 {
+		return ""
+	}
+	b := strings.Builder{}
+	b.WriteString("\t// " + i18n.T("可能返回的业务状态码:") + "\n")
+	for _, c := range codes {
+		fmt.Fprintf(&b, "\t//   return %s // %d %s\n", c.Ref, c.Code, c.Message)
+	}
+	return b.String()
+}
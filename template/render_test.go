@@ -0,0 +1,25 @@
+package template
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderAPIReceiver(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Login", "func (l *LoginApi) Run("},
+		{"用户", "func (用 *用户Api) Run("},
+	}
+	for _, tt := range tests {
+		src, err := RenderAPI(APISpec{PackageName: "user", Struct: tt.name, ModulePath: "app"})
+		if err != nil {
+			t.Fatalf("RenderAPI(%q) error = %v", tt.name, err)
+		}
+		if !strings.Contains(string(src), tt.want) {
+			t.Errorf("RenderAPI(%q) does not contain %q:\n%s", tt.name, tt.want, src)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package wizard
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+	"github.com/zjutjh/gbc/template"
+)
+
+// HTTPMethods 可选的HTTP请求方法
+var HTTPMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// APIOptions 创建API向导的已知信息
+type APIOptions struct {
+	Key        string             // 命令行中指定的key, 非空时跳过包与名称的选择
+	Packages   []string           // ./api/ 下已存在的包, 如 user、user.admin
+	Codes      []template.APICode // 项目中声明的业务状态码
+	ModulePath string
+}
+
+// APIResult 创建API向导的结果
+type APIResult struct {
+	Key    string
+	Method string
+	Path   string
+	Spec   template.APISpec
+	Source []byte // 渲染后的文件内容
+}
+
+// API 依次询问包与名称、请求方法与路径、请求参数、响应数据与业务状态码, 预览并确认后返回结果
+func API(w *Wizard, opts APIOptions) (*APIResult, error) {
+	for {
+		res, err := apiOnce(w, opts)
+		if err != nil {
+			return nil, err
+		}
+		w.Step("预览")
+		w.Print(strings.Repeat("-", 60))
+		w.Print(string(res.Source))
+		w.Print(strings.Repeat("-", 60))
+		choice, err := w.Select("确认生成的文件", []string{i18n.T("写入文件"), i18n.T("重新填写"), i18n.T("取消")}, 0)
+		if err != nil {
+			return nil, err
+		}
+		switch choice {
+		case 0:
+			return res, nil
+		case 1:
+			w.Back()
+			continue
+		default:
+			return nil, ErrAborted
+		}
+	}
+}
+
+func apiOnce(w *Wizard, opts APIOptions) (*APIResult, error) {
+	key := opts.Key
+	if key == "" {
+		var err error
+		if key, err = askAPIKey(w, opts.Packages); err != nil {
+			return nil, err
+		}
+	}
+	k, err := comm.NormalizeKey(key, "api", "./api/", ".go")
+	if err != nil {
+		return nil, err
+	}
+
+	w.Step("请求方法与路径")
+	method, err := w.Select("HTTP请求方法", HTTPMethods, 0)
+	if err != nil {
+		return nil, err
+	}
+	defaultPath := "/" + strings.Join(append(slices.Clone(k.Dirs), k.FileName), "/")
+	path, err := w.Input("路由路径, 可包含 :id 等路径参数", defaultPath, validatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	w.Step("请求参数")
+	request, err := askRequest(w, path)
+	if err != nil {
+		return nil, err
+	}
+
+	w.Step("响应数据")
+	w.Print(i18n.T("逐个填写响应数据 (Body中的Data部分) 的字段, 参数名称留空结束"))
+	response, err := askFields(w, "json", false)
+	if err != nil {
+		return nil, err
+	}
+
+	w.Step("业务状态码")
+	codes, err := askCodes(w, opts.Codes)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := template.APISpec{
+		PackageName: k.PackageName,
+		Struct:      k.Ident,
+		ModulePath:  opts.ModulePath,
+		Request:     request,
+		Response:    response,
+		Codes:       codes,
+	}
+	src, err := template.RenderAPI(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &APIResult{Key: key, Method: HTTPMethods[method], Path: path, Spec: spec, Source: src}, nil
+}
+
+// askAPIKey 选择API所在的包并输入名称
+func askAPIKey(w *Wizard, packages []string) (string, error) {
+	w.Step("API所在的包与名称")
+	options := append(slices.Clone(packages), i18n.T("<新建包>"), i18n.T("<api根目录>"))
+	choice, err := w.Select("选择API所在的包", options, 0)
+	if err != nil {
+		return "", err
+	}
+	pkg := ""
+	switch {
+	case choice < len(packages):
+		pkg = packages[choice]
+	case choice == len(packages):
+		pkg, err = w.Input("新包的路径, 如 user.admin", "", func(s string) error {
+			if s == "" {
+				return i18n.Errorf("包的路径不能为空")
+			}
+			_, err := comm.NormalizeKey(s+".x", "api", "./api/", ".go")
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	prefix := ""
+	if pkg != "" {
+		prefix = pkg + "."
+	}
+	name, err := w.Input("API名称, 如 login、get_info", "", func(s string) error {
+		k, err := comm.NormalizeKey(prefix+s, "api", "./api/", ".go")
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(k.Path); err == nil {
+			return i18n.Errorf("文件[%s]已存在", k.Path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return prefix + name, nil
+}
+
+// askRequest 选择请求参数部分并逐个填写字段, 路由路径中的参数自动加入Uri
+func askRequest(w *Wizard, path string) (map[string][]template.APIField, error) {
+	request := make(map[string][]template.APIField)
+	parts := []struct{ Name, Tag string }{{"Uri", "uri"}, {"Header", "header"}, {"Query", "form"}, {"Body", "json"}}
+
+	if params := pathParams(path); len(params) > 0 {
+		for _, p := range params {
+			request["Uri"] = append(request["Uri"], template.APIField{Name: comm.ToCamel(p), Type: "string", Param: p, Required: true})
+		}
+		w.Print(i18n.Sprintf("路由路径中的参数 %s 已加入Uri", strings.Join(params, "、")))
+		parts = parts[1:]
+	}
+
+	names := make([]string, 0, len(parts))
+	for _, p := range parts {
+		names = append(names, p.Name)
+	}
+	selected, err := w.MultiSelect("API需要哪些请求参数?", names)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(selected)
+	for _, i := range selected {
+		part := parts[i]
+		w.Print(i18n.Sprintf("逐个填写%s中的字段, 参数名称留空结束", part.Name))
+		fields, err := askFields(w, part.Tag, true)
+		if err != nil {
+			return nil, err
+		}
+		request[part.Name] = fields
+	}
+	return request, nil
+}
+
+// askFields 逐个填写结构体字段, 参数名称留空时结束
+func askFields(w *Wizard, tag string, askRequired bool) ([]template.APIField, error) {
+	fields := make([]template.APIField, 0)
+	for {
+		param, err := w.Input(i18n.Sprintf("第%d个字段的参数名称 (%s标签)", len(fields)+1, tag), "", func(s string) error {
+			if s == "" {
+				return nil
+			}
+			return validateParam(s, fields)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if param == "" {
+			return fields, nil
+		}
+		typ, err := w.Input("类型", "string", validateType)
+		if err != nil {
+			return nil, err
+		}
+		required := false
+		if askRequired {
+			if required, err = w.Confirm("是否必填?", false); err != nil {
+				return nil, err
+			}
+		}
+		fields = append(fields, template.APIField{Name: comm.ToCamel(param), Type: typ, Param: param, Required: required})
+	}
+}
+
+// askCodes 选择Run中可能返回的业务状态码
+func askCodes(w *Wizard, codes []template.APICode) ([]template.APICode, error) {
+	if len(codes) == 0 {
+		w.Print(i18n.T("项目中未找到业务状态码, 跳过"))
+		return nil, nil
+	}
+	options := make([]string, 0, len(codes))
+	for _, c := range codes {
+		options = append(options, fmt.Sprintf("%s (%d %s)", c.Ref, c.Code, c.Message))
+	}
+	selected, err := w.MultiSelect("Run中可能返回哪些业务状态码?", options)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(selected)
+	res := make([]template.APICode, 0, len(selected))
+	for _, i := range selected {
+		res = append(res, codes[i])
+	}
+	return res, nil
+}
+
+// pathParams 路由路径中 :name 与 *name 形式的参数
+func pathParams(path string) []string {
+	params := make([]string, 0)
+	for _, seg := range strings.Split(path, "/") {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			if name := seg[1:]; name != "" {
+				params = append(params, name)
+			}
+		}
+	}
+	return params
+}
+
+func validatePath(s string) error {
+	if !strings.HasPrefix(s, "/") || strings.ContainsAny(s, " \t?#") {
+		return i18n.Errorf("路由路径[%s]应以/开头且不能包含空白、?与#", s)
+	}
+	return nil
+}
+
+func validateParam(s string, fields []template.APIField) error {
+	if strings.ContainsAny(s, " \t\"`") {
+		return i18n.Errorf("参数名称[%s]中不能包含空白、引号与反引号", s)
+	}
+	name := comm.ToCamel(s)
+	if !comm.IsIdentifier(name) {
+		return i18n.Errorf("参数名称[%s]无法生成合法的Go字段名", s)
+	}
+	for _, f := range fields {
+		if f.Name == name {
+			return i18n.Errorf("字段[%s]已存在", name)
+		}
+	}
+	return nil
+}
+
+func validateType(s string) error {
+	// 作为变量声明的类型解析, 排除 1+2 等可以解析为表达式但不是类型的输入
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package p\nvar _ "+s, 0)
+	if err == nil && len(f.Decls) == 1 && !strings.ContainsAny(s, "();\n") {
+		if spec, ok := f.Decls[0].(*ast.GenDecl).Specs[0].(*ast.ValueSpec); ok && len(spec.Values) == 0 {
+			return nil
+		}
+	}
+	return i18n.Errorf("[%s]不是合法的Go类型", s)
+}
@@ -0,0 +1,298 @@
+package wizard
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zjutjh/gbc/template"
+)
+
+func TestAPI(t *testing.T) {
+	codes := []template.APICode{
+		{Ref: "comm.CodeUserNotFound", Code: 10001, Message: "用户不存在"},
+		{Ref: "comm.CodePasswordWrong", Code: 10002, Message: "密码错误"},
+	}
+	login := &APIResult{
+		Key:    "user.login",
+		Method: "POST",
+		Path:   "/user/login/:id",
+		Spec: template.APISpec{
+			PackageName: "user",
+			Struct:      "Login",
+			Request: map[string][]template.APIField{
+				"Uri":  {{Name: "ID", Type: "string", Param: "id", Required: true}},
+				"Body": {{Name: "Username", Type: "string", Param: "username", Required: true}, {Name: "Remember", Type: "bool", Param: "remember"}},
+			},
+			Response: []template.APIField{{Name: "Token", Type: "string", Param: "token"}},
+			Codes:    []template.APICode{codes[1]},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		opts    APIOptions
+		input   []string
+		want    *APIResult
+		wantErr error
+	}{
+		{
+			name: "existing package",
+			opts: APIOptions{Packages: []string{"admin", "user"}, Codes: codes},
+			input: []string{
+				"2", "login", // 包与名称
+				"POST", "/user/login/:id", // 请求方法与路径
+				"Body", "username", "", "y", "remember", "bool", "", "", // 请求参数
+				"token", "", "", // 响应数据
+				"2", // 业务状态码
+				"1", // 写入文件
+			},
+			want: login,
+		},
+		{
+			name: "invalid answers are asked again",
+			opts: APIOptions{Packages: []string{"admin", "user"}, Codes: codes},
+			input: []string{
+				"9", "user", "login",
+				"TRACE", "2", "user/login", "/user/login/:id",
+				"Cookie", "3", "username", "", "maybe", "yes", "remember", "func(", "bool", "", "",
+				"token", "", "token", "",
+				"3", "2",
+				"",
+			},
+			want: login,
+		},
+		{
+			name: "key from command line",
+			opts: APIOptions{Key: "user.admin.get_info"},
+			input: []string{
+				"", "",
+				"Header, Query", "X-Token", "", "n", "", "page", "int", "", "",
+				"",
+				"1",
+			},
+			want: &APIResult{
+				Key:    "user.admin.get_info",
+				Method: "GET",
+				Path:   "/user/admin/get_info",
+				Spec: template.APISpec{
+					PackageName: "admin",
+					Struct:      "GetInfo",
+					Request: map[string][]template.APIField{
+						"Header": {{Name: "XToken", Type: "string", Param: "X-Token"}},
+						"Query":  {{Name: "Page", Type: "int", Param: "page"}},
+					},
+					Response: []template.APIField{},
+				},
+			},
+		},
+		{
+			name: "new package",
+			input: []string{
+				"1", "", "order.item", "create",
+				"", "",
+				"",
+				"",
+				"1",
+			},
+			want: &APIResult{
+				Key:    "order.item.create",
+				Method: "GET",
+				Path:   "/order/item/create",
+				Spec: template.APISpec{
+					PackageName: "item",
+					Struct:      "Create",
+					Request:     map[string][]template.APIField{},
+					Response:    []template.APIField{},
+				},
+			},
+		},
+		{
+			name: "fill in again after preview",
+			opts: APIOptions{Packages: []string{"user"}},
+			input: []string{
+				"1", "logout", "", "", "", "", "2",
+				"1", "login", "POST", "/user/login", "", "", "1",
+			},
+			want: &APIResult{
+				Key:    "user.login",
+				Method: "POST",
+				Path:   "/user/login",
+				Spec: template.APISpec{
+					PackageName: "user",
+					Struct:      "Login",
+					Request:     map[string][]template.APIField{},
+					Response:    []template.APIField{},
+				},
+			},
+		},
+		{
+			name:    "cancel after preview",
+			opts:    APIOptions{Key: "user.login"},
+			input:   []string{"", "", "", "", "3"},
+			wantErr: ErrAborted,
+		},
+		{
+			name:    "input ends",
+			opts:    APIOptions{Key: "user.login"},
+			input:   []string{"", "", "Body", "username"},
+			wantErr: ErrAborted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			out := bytes.Buffer{}
+			w := New(Script(tt.input...), &out, 6)
+			got, err := API(w, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("API() error = %v, want %v\n%s", err, tt.wantErr, out.String())
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			want := *tt.want
+			src := got.Source
+			got.Source = nil
+			if !reflect.DeepEqual(got, &want) {
+				t.Errorf("API() =\n%+v\nwant\n%+v\n%s", got, &want, out.String())
+			}
+			if expected, err := template.RenderAPI(want.Spec); err != nil || !bytes.Equal(src, expected) {
+				t.Errorf("API() source does not match the rendered spec: %v", err)
+			}
+			// 预览中展示了将要写入的文件
+			if !strings.Contains(out.String(), string(src)) {
+				t.Errorf("preview does not contain the source:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestAPIRejectsExistingFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(filepath.Join("api", "user"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("api", "user", "login.go"), []byte("package user\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := bytes.Buffer{}
+	w := New(Script("1", "login", "logout", "", "", "", "", "1"), &out, 6)
+	got, err := API(w, APIOptions{Packages: []string{"user"}})
+	if err != nil {
+		t.Fatalf("API() error = %v\n%s", err, out.String())
+	}
+	if got.Key != "user.logout" {
+		t.Errorf("API() key = %s, want user.logout", got.Key)
+	}
+	if !strings.Contains(out.String(), "login.go") {
+		t.Errorf("existing file not reported:\n%s", out.String())
+	}
+}
+
+func TestSelectLineMode(t *testing.T) {
+	out := bytes.Buffer{}
+	w := New(Script("", "post", "0", "3"), &out, 1)
+	w.FullScreen = true
+	w.Step("请求方法与路径")
+	for _, want := range []int{1, 1, 2} {
+		got, err := w.Select("HTTP请求方法", []string{"GET", "POST", "PUT"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Select() = %d, want %d", got, want)
+		}
+	}
+	s := out.String()
+	if !strings.HasPrefix(s, "\033[H\033[2J") {
+		t.Errorf("full screen step should clear the screen: %q", s)
+	}
+	if !strings.Contains(s, "   1) GET\n   2) POST\n   3) PUT\n") {
+		t.Errorf("options are not numbered:\n%s", s)
+	}
+}
+
+func TestSelectKeys(t *testing.T) {
+	options := []string{"GET", "POST", "PUT"}
+	tests := []struct {
+		name    string
+		keys    string
+		multi   bool
+		want    []int
+		wantErr error
+	}{
+		{name: "enter keeps default", keys: "\r", want: []int{1}},
+		{name: "arrow down", keys: "\033[B\r", want: []int{2}},
+		{name: "arrow up wraps", keys: "\033[A\033[A\r", want: []int{2}},
+		{name: "j/k and application mode arrows", keys: "j\033OAk\r", want: []int{0}},
+		{name: "number jumps", keys: "39\r", want: []int{2}},
+		{name: "space ignored", keys: " \r", want: []int{1}},
+		{name: "ctrl+c", keys: "\033[B\x03", wantErr: ErrAborted},
+		{name: "input ends", keys: "\033[B", wantErr: ErrAborted},
+		{name: "multi toggles", keys: " \033[B \033[B \033[A \r", multi: true, want: []int{0, 2}},
+		{name: "multi none", keys: "\r", multi: true, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := bytes.Buffer{}
+			w := New(strings.NewReader(tt.keys), &out, 1)
+			w.Keys = true
+			var got []int
+			var err error
+			if tt.multi {
+				got, err = w.MultiSelect("请求参数", options)
+			} else {
+				var i int
+				i, err = w.Select("HTTP请求方法", options, 1)
+				got = []int{i}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selected = %v, want %v\n%q", got, tt.want, out.String())
+			}
+		})
+	}
+
+	out := bytes.Buffer{}
+	w := New(strings.NewReader(" \033[B\r"), &out, 1)
+	w.Keys = true
+	if _, err := w.MultiSelect("请求参数", options); err != nil {
+		t.Fatal(err)
+	}
+	// 每次按键后将光标移回列表开头重绘
+	for _, want := range []string{"> [ ] GET", "> [x] GET", "\033[3A", "  [x] GET\r\n\r\033[2K> [ ] POST"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%q", want, out.String())
+		}
+	}
+}
+
+func TestValidateType(t *testing.T) {
+	tests := []struct {
+		typ     string
+		wantErr bool
+	}{
+		{"string", false},
+		{"[]int64", false},
+		{"map[string]*time.Time", false},
+		{"struct{ A int }", false},
+		{"1+2", true},
+		{"x.y()", true},
+		{"func(", true},
+		{"int = 1", true},
+		{"int\nvar x int", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		if err := validateType(tt.typ); (err != nil) != tt.wantErr {
+			t.Errorf("validateType(%q) error = %v, wantErr %v", tt.typ, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package wizard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/term"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+// keyAction 选择列表中按键对应的操作
+type keyAction int
+
+const (
+	keyNone keyAction = iota
+	keyUp
+	keyDown
+	keyJump // 数字键, 移动到对应序号的选项
+	keyToggle
+	keyEnter
+	keyCancel
+)
+
+// selectKeys 通过方向键在options中移动光标, multi为true时空格勾选, 回车确认; 返回所选项的下标
+// 输入为终端时在选择期间切换到raw模式, 以便逐个读取按键
+func (w *Wizard) selectKeys(label string, options []string, cursor int, multi bool) ([]int, error) {
+	if len(options) == 0 {
+		return []int{}, nil
+	}
+	if w.tty != nil {
+		fd := int(w.tty.Fd())
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = term.Restore(fd, state) }()
+	}
+	hint := "(↑/↓ 移动, 回车确认)"
+	if multi {
+		hint = "(↑/↓ 移动, 空格勾选, 回车确认)"
+	}
+	// raw模式下换行不会回到行首, 因此统一使用 \r\n
+	fmt.Fprint(w.out, i18n.T(label)+" "+comm.Paint(w.out, comm.Debug, i18n.T(hint))+"\r\n")
+	checked := make(map[int]bool)
+	w.renderOptions(options, cursor, checked, multi, false)
+	for {
+		action, index, err := w.readKey()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case keyUp:
+			cursor = (cursor - 1 + len(options)) % len(options)
+		case keyDown:
+			cursor = (cursor + 1) % len(options)
+		case keyJump:
+			if index >= len(options) {
+				continue
+			}
+			cursor = index
+		case keyToggle:
+			if !multi {
+				continue
+			}
+			checked[cursor] = !checked[cursor]
+		case keyEnter:
+			if !multi {
+				return []int{cursor}, nil
+			}
+			selected := make([]int, 0, len(checked))
+			for i := range options {
+				if checked[i] {
+					selected = append(selected, i)
+				}
+			}
+			return selected, nil
+		case keyCancel:
+			return nil, ErrAborted
+		default:
+			continue
+		}
+		w.renderOptions(options, cursor, checked, multi, true)
+	}
+}
+
+// renderOptions 输出选项列表, redraw为true时先将光标移回列表第一行再覆盖输出
+func (w *Wizard) renderOptions(options []string, cursor int, checked map[int]bool, multi, redraw bool) {
+	if redraw {
+		fmt.Fprintf(w.out, "\033[%dA", len(options))
+	}
+	for i, o := range options {
+		line := "  "
+		if i == cursor {
+			line = "> "
+		}
+		if multi {
+			if checked[i] {
+				line += "[x] "
+			} else {
+				line += "[ ] "
+			}
+		}
+		line += o
+		if i == cursor {
+			line = comm.Paint(w.out, comm.Look, line)
+		}
+		fmt.Fprint(w.out, "\r\033[2K"+line+"\r\n")
+	}
+}
+
+// readKey 读取一个按键, 支持方向键、j/k、数字键、空格与回车, Ctrl+C、Ctrl+D或输入结束时取消
+func (w *Wizard) readKey() (keyAction, int, error) {
+	b, err := w.in.ReadByte()
+	if errors.Is(err, io.EOF) {
+		return keyCancel, 0, nil
+	}
+	if err != nil {
+		return keyNone, 0, err
+	}
+	switch {
+	case b == '\r' || b == '\n':
+		return keyEnter, 0, nil
+	case b == ' ':
+		return keyToggle, 0, nil
+	case b == 3 || b == 4:
+		return keyCancel, 0, nil
+	case b == 'k':
+		return keyUp, 0, nil
+	case b == 'j':
+		return keyDown, 0, nil
+	case b >= '1' && b <= '9':
+		return keyJump, int(b - '1'), nil
+	case b == '\033':
+		// 方向键为 ESC [ A 或 ESC O A (上), B为下
+		if next, err := w.in.ReadByte(); err != nil || (next != '[' && next != 'O') {
+			return keyNone, 0, nil
+		}
+		code, err := w.in.ReadByte()
+		if err != nil {
+			return keyNone, 0, nil
+		}
+		switch code {
+		case 'A':
+			return keyUp, 0, nil
+		case 'B':
+			return keyDown, 0, nil
+		}
+	}
+	return keyNone, 0, nil
+}
@@ -0,0 +1,236 @@
+// Package wizard 终端交互式向导
+//
+// 向导从任意 io.Reader 逐行读取输入, 交互时读取标准输入, 也可以使用 Script 提供预先编写的输入以便测试与自动化
+// 输入与输出均为终端时, 选择题通过方向键移动、空格勾选、回车确认; 否则以带序号的列表输出, 通过输入序号或选项文本作答
+package wizard
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/zjutjh/gbc/comm"
+	"github.com/zjutjh/gbc/i18n"
+)
+
+type abortError struct{}
+
+func (abortError) Error() string {
+	return i18n.T("向导已取消")
+}
+
+// ErrAborted 输入已结束或用户取消了向导
+var ErrAborted error = abortError{}
+
+// Wizard 由若干步骤组成的交互式向导
+type Wizard struct {
+	in  *bufio.Reader
+	out io.Writer
+	// FullScreen 每一步开始时清屏并显示进度
+	FullScreen bool
+	// Keys 选择题通过方向键选择, 输入与输出均为终端时启用
+	Keys bool
+	// Echo 输出读取到的输入, 输入不是终端 (如管道或 Script) 时便于查看每一步的回答
+	Echo bool
+
+	tty   *os.File // 输入所在的终端, 选择时切换到raw模式
+	steps int
+	step  int
+}
+
+// New 创建向导, steps为步骤总数; out为终端时启用全屏模式, in与out均为终端时通过方向键选择, in不是终端时回显输入
+func New(in io.Reader, out io.Writer, steps int) *Wizard {
+	w := &Wizard{in: bufio.NewReader(in), out: out, steps: steps, Echo: true}
+	if f, ok := out.(*os.File); ok && comm.IsTerminal(f) {
+		w.FullScreen = true
+	}
+	if f, ok := in.(*os.File); ok && comm.IsTerminal(f) {
+		w.Echo = false
+		w.tty = f
+		w.Keys = w.FullScreen
+	}
+	return w
+}
+
+// Script 将预先编写的输入逐行提供给向导, 每个元素对应一次输入, 空字符串表示直接回车
+func Script(lines ...string) io.Reader {
+	return strings.NewReader(strings.Join(lines, "\n") + "\n")
+}
+
+// Step 开始新的一步
+func (w *Wizard) Step(title string) {
+	w.step++
+	if w.FullScreen {
+		fmt.Fprint(w.out, "\033[H\033[2J")
+	} else {
+		fmt.Fprint(w.out, comm.NewLine)
+	}
+	header := fmt.Sprintf("[%d/%d] %s", w.step, w.steps, i18n.T(title))
+	fmt.Fprint(w.out, comm.Paint(w.out, comm.Look, header)+comm.NewLine)
+}
+
+// Back 回到第一步, 用于重新填写
+func (w *Wizard) Back() {
+	w.step = 0
+}
+
+// Print 输出一段文本, 如预览内容
+func (w *Wizard) Print(text string) {
+	fmt.Fprint(w.out, text)
+	if !strings.HasSuffix(text, "\n") {
+		fmt.Fprint(w.out, comm.NewLine)
+	}
+}
+
+func (w *Wizard) prompt(label string) {
+	fmt.Fprint(w.out, comm.Paint(w.out, comm.UserInterface, label)+" ")
+}
+
+func (w *Wizard) invalid(format string, a ...any) {
+	fmt.Fprint(w.out, comm.Paint(w.out, comm.Error, i18n.Sprintf(format, a...))+comm.NewLine)
+}
+
+// readLine 读取一行输入, 输入已结束时返回 ErrAborted
+func (w *Wizard) readLine() (string, error) {
+	line, err := w.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		if errors.Is(err, io.EOF) {
+			fmt.Fprint(w.out, comm.NewLine)
+			return "", ErrAborted
+		}
+		return "", err
+	}
+	line = strings.TrimSpace(line)
+	if w.Echo {
+		fmt.Fprint(w.out, line+comm.NewLine)
+	}
+	return line, nil
+}
+
+// Input 文本输入, 直接回车使用默认值; validate不为空时校验输入, 未通过则重新输入
+func (w *Wizard) Input(label, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			w.prompt(i18n.Sprintf("%s [%s]:", i18n.T(label), def))
+		} else {
+			w.prompt(i18n.T(label) + ":")
+		}
+		s, err := w.readLine()
+		if err != nil {
+			return "", err
+		}
+		if s == "" {
+			s = def
+		}
+		if validate != nil {
+			if err := validate(s); err != nil {
+				w.invalid("%s", err)
+				continue
+			}
+		}
+		return s, nil
+	}
+}
+
+// Confirm 是否确认, 直接回车使用默认值
+func (w *Wizard) Confirm(label string, def bool) (bool, error) {
+	hint := "(y|n(default))"
+	if def {
+		hint = "(y(default)|n)"
+	}
+	for {
+		w.prompt(i18n.T(label) + " " + hint + ":")
+		s, err := w.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(s) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		w.invalid("输入不符合期望, 请重新输入")
+	}
+}
+
+// Select 从options中选择一项, 光标初始位于def; 逐行输入时输入序号或选项文本均可, 直接回车选择def; 返回所选项的下标
+func (w *Wizard) Select(label string, options []string, def int) (int, error) {
+	if w.Keys {
+		selected, err := w.selectKeys(label, options, def, false)
+		if err != nil || len(selected) == 0 {
+			return 0, err
+		}
+		return selected[0], nil
+	}
+	w.Print(i18n.T(label))
+	for i, o := range options {
+		fmt.Fprintf(w.out, "  %2d) %s%s", i+1, o, comm.NewLine)
+	}
+	for {
+		w.prompt(i18n.Sprintf("请输入序号 [%d]:", def+1))
+		s, err := w.readLine()
+		if err != nil {
+			return 0, err
+		}
+		if s == "" {
+			return def, nil
+		}
+		if i, ok := parseIndex(s, options); ok {
+			return i, nil
+		}
+		w.invalid("输入不符合期望, 请重新输入")
+	}
+}
+
+// MultiSelect 从options中选择任意项; 逐行输入时输入以逗号或空格分隔的序号或选项文本, 直接回车表示不选; 返回所选项的下标
+func (w *Wizard) MultiSelect(label string, options []string) ([]int, error) {
+	if w.Keys {
+		return w.selectKeys(label, options, 0, true)
+	}
+	w.Print(i18n.T(label))
+	for i, o := range options {
+		fmt.Fprintf(w.out, "  %2d) %s%s", i+1, o, comm.NewLine)
+	}
+next:
+	for {
+		w.prompt(i18n.T("请输入序号, 多个以逗号分隔 (直接回车不选):"))
+		s, err := w.readLine()
+		if err != nil {
+			return nil, err
+		}
+		selected := make([]int, 0)
+		seen := make(map[int]bool)
+		for _, item := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '，' }) {
+			i, ok := parseIndex(item, options)
+			if !ok {
+				w.invalid("选项[%s]不存在, 请重新输入", item)
+				continue next
+			}
+			if !seen[i] {
+				seen[i] = true
+				selected = append(selected, i)
+			}
+		}
+		return selected, nil
+	}
+}
+
+// parseIndex 将从1开始的序号或选项文本解析为下标
+func parseIndex(s string, options []string) (int, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n - 1, n >= 1 && n <= len(options)
+	}
+	for i, o := range options {
+		if strings.EqualFold(o, s) {
+			return i, true
+		}
+	}
+	return 0, false
+}